@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/application/usecases/apikey"
+	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/maintenance"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/application/usecases/tenant"
+	"user-management/internal/application/usecases/user"
+	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/signing"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// AdminApp reúne as dependências usadas pelos comandos administrativos
+type AdminApp struct {
+	Log      *logrus.Logger
+	MongoDB  *database.MongoDB
+	Migrator *migrations.Migrator
+
+	CreateUser       *user.CreateUserUseCase
+	GetUser          *user.GetUserUseCase
+	UpdateUser       *user.UpdateUserUseCase
+	DeleteUser       *user.DeleteUserUseCase
+	ListUsers        *user.ListUsersUseCase
+	RestoreUser      *user.RestoreUserUseCase
+	ChangeUserStatus *user.ChangeUserStatusUseCase
+	ResendInvitation *user.ResendInvitationUseCase
+	ResetMFA         *mfa.ResetUseCase
+
+	CreateGroup         *group.CreateGroupUseCase
+	ListGroups          *group.ListGroupsUseCase
+	AddUserToGroup      *group.AddUserToGroupUseCase
+	RemoveUserFromGroup *group.RemoveUserFromGroupUseCase
+	UpdateMemberRole    *group.UpdateMemberRoleUseCase
+	AddSubgroup         *group.AddSubgroupUseCase
+	RemoveSubgroup      *group.RemoveSubgroupUseCase
+	SetGroupMFAPolicy   *group.SetGroupMFAPolicyUseCase
+
+	Tenants      repositories.ITenantRepository
+	CreateTenant *tenant.CreateTenantUseCase
+	ListTenants  *tenant.ListTenantsUseCase
+	DeleteTenant *tenant.DeleteTenantUseCase
+
+	CreateAPIKey *apikey.CreateAPIKeyUseCase
+	ListAPIKeys  *apikey.ListAPIKeysUseCase
+	RevokeAPIKey *apikey.RevokeAPIKeyUseCase
+
+	RegisterOAuthClient *oidc.RegisterClientUseCase
+	ListOAuthClients    *oidc.ListClientsUseCase
+	DeleteOAuthClient   *oidc.DeleteClientUseCase
+	SigningKeys         *signing.KeyManager
+
+	PurgeDeleted *maintenance.PurgeDeletedUseCase
+}
+
+// Close encerra a conexão com o banco
+func (a *AdminApp) Close(ctx context.Context) error {
+	return a.MongoDB.Client.Disconnect(ctx)
+}
+
+// loadConfig carrega a configuração em camadas usando as flags globais do comando
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	return config.Load(config.LoadOptions{File: configFile, Flags: cmd.Flags()})
+}
+
+// runAdmin inicializa o AdminApp, executa fn e fecha a conexão com o banco ao final.
+// O contexto carrega uma entrada de log identificando o comando executado, um principal
+// administrativo da plataforma, já que quem executa a CLI tem acesso direto ao banco, e o tenant
+// escolhido com --tenant
+func runAdmin(cmd *cobra.Command, fn func(ctx context.Context, app *AdminApp) error) error {
+	if !tenancy.ValidSlug(cliTenant) {
+		return fmt.Errorf("--tenant: %w", tenancy.ErrInvalidSlug)
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	app, err := InitializeAdmin(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := app.Close(context.Background()); err != nil {
+			app.Log.WithError(err).Error("Failed to close database connection")
+		}
+	}()
+
+	ctx := logger.WithEntry(cmd.Context(), app.Log.WithFields(logrus.Fields{"command": cmd.CommandPath(), "tenant": cliTenant}))
+	ctx = auth.WithPrincipal(ctx, auth.Principal{ID: "cli", Type: auth.PrincipalSystem, Admin: true, PlatformAdmin: true})
+	if cliTenant != tenancy.DefaultTenant {
+		if _, err := app.Tenants.GetBySlug(ctx, cliTenant); err != nil {
+			return fmt.Errorf("tenant %s: %w", cliTenant, err)
+		}
+	}
+	return fn(tenancy.WithTenant(ctx, cliTenant), app)
+}
+
+// addListFilterFlags registra as flags de ordenação e de filtro por data das listagens
+func addListFilterFlags(cmd *cobra.Command, sort *string, filter *dto.ListFilterQueryParam) {
+	cmd.Flags().StringVar(sort, "sort", "", "Sort by field (e.g. created_at, updated_at, name); prefix with - for descending order")
+	cmd.Flags().StringVar(&filter.CreatedAfter, "created-after", "", "Only records created at or after this RFC 3339 date")
+	cmd.Flags().StringVar(&filter.CreatedBefore, "created-before", "", "Only records created before this RFC 3339 date")
+	cmd.Flags().StringVar(&filter.UpdatedAfter, "updated-after", "", "Only records updated at or after this RFC 3339 date")
+	cmd.Flags().StringVar(&filter.UpdatedBefore, "updated-before", "", "Only records updated before this RFC 3339 date")
+}
+
+// zeroBasedPage converte a página das flags --page, baseada em 1 como na API, no índice baseado
+// em 0 usado pelos use cases
+func zeroBasedPage(page int64) int64 {
+	if page > 0 {
+		return page - 1
+	}
+	return page
+}
+
+var inputValidator = validators.NewInputValidator()
+
+// validateInput aplica as mesmas regras de validação dos DTOs usadas pela API
+func validateInput(input interface{}) error {
+	if err := inputValidator.ValidateStruct(input); err != nil {
+		return fmt.Errorf("invalid input: %s", inputValidator.FormatValidationError(err))
+	}
+	return nil
+}
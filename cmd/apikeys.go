@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/application/dto"
+
+	"github.com/spf13/cobra"
+)
+
+var apiKeysCmd = &cobra.Command{
+	Use:   "api-keys",
+	Short: "Manage API keys of the tenant selected with --tenant",
+}
+
+var (
+	apiKeyName      string
+	apiKeyScopes    []string
+	apiKeyExpiresIn time.Duration
+	apiKeyPage      int64
+	apiKeyPerPage   int64
+)
+
+var apiKeysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key and print its secret (shown only once)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.CreateAPIKeyRequestDTO{Name: apiKeyName, Scopes: apiKeyScopes}
+		if apiKeyExpiresIn > 0 {
+			expiresAt := time.Now().UTC().Add(apiKeyExpiresIn)
+			input.ExpiresAt = &expiresAt
+		}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			created, err := app.CreateAPIKey.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd.OutOrStdout(), created, func(tw *tabwriter.Writer) {
+				writeAPIKeysTable(tw, []*dto.APIKeyResponseDTO{&created.APIKeyResponseDTO})
+				fmt.Fprintf(tw, "\nKey: %s\nStore it now; it cannot be shown again.\n", created.Key)
+			})
+		})
+	},
+}
+
+var apiKeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys, including revoked ones",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.PageQueryParam{Page: apiKeyPage, PerPage: apiKeyPerPage}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		input.Page = zeroBasedPage(input.Page)
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			list, err := app.ListAPIKeys.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd.OutOrStdout(), list, func(tw *tabwriter.Writer) {
+				writeAPIKeysTable(tw, list.Data)
+			})
+		})
+	},
+}
+
+var apiKeysRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.RevokeAPIKey.Execute(ctx, args[0]); err != nil {
+				return fmt.Errorf("API key %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "API key %s revoked\n", args[0])
+			return nil
+		})
+	},
+}
+
+func writeAPIKeysTable(tw *tabwriter.Writer, keys []*dto.APIKeyResponseDTO) {
+	fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tEXPIRES AT\tLAST USED AT\tREVOKED AT")
+	for _, k := range keys {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix, strings.Join(k.Scopes, ","),
+			formatOptionalTime(k.ExpiresAt), formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt))
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func init() {
+	apiKeysCreateCmd.Flags().StringVar(&apiKeyName, "name", "", "API key name")
+	apiKeysCreateCmd.Flags().StringSliceVar(&apiKeyScopes, "scopes", nil, "Comma-separated scopes: users:read, users:write, groups:read, groups:write, admin")
+	apiKeysCreateCmd.Flags().DurationVar(&apiKeyExpiresIn, "expires-in", 0, "Expire the key after this duration (e.g. 720h); never expires by default")
+	apiKeysListCmd.Flags().Int64Var(&apiKeyPage, "page", 1, "Page number")
+	apiKeysListCmd.Flags().Int64Var(&apiKeyPerPage, "per-page", 10, "API keys per page")
+
+	apiKeysCmd.AddCommand(apiKeysCreateCmd, apiKeysListCmd, apiKeysRevokeCmd)
+	addOutputFlag(apiKeysCmd)
+	rootCmd.AddCommand(apiKeysCmd)
+}
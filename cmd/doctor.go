@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"user-management/internal/infrastructure/doctor"
+
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check users and groups for inconsistent data",
+	Long: `Scan the users and groups collections of every tenant and print a JSON report with
+dangling or malformed member references, members and subgroups from another tenant,
+duplicate emails within a tenant (case-insensitive) and documents that fail the API
+validation rules. With --fix, member references are cleaned up and duplicate users are
+merged into the oldest account; invalid documents are only reported.
+Exits with a non-zero status while unresolved issues remain.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			report, err := doctor.NewDoctor(app.MongoDB).Run(ctx, doctorFix)
+			if report != nil {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if encodeErr := encoder.Encode(report); encodeErr != nil && err == nil {
+					err = encodeErr
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if report.Unresolved > 0 {
+				return fmt.Errorf("%d unresolved issue(s) found", report.Unresolved)
+			}
+			return nil
+		})
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair the issues that have a safe automatic fix")
+	rootCmd.AddCommand(doctorCmd)
+}
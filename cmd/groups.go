@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+
+	"github.com/spf13/cobra"
+)
+
+var groupsCmd = &cobra.Command{
+	Use:   "groups",
+	Short: "Manage groups",
+}
+
+var (
+	groupName    string
+	groupMembers []string
+	groupOwners  []string
+	groupRole    string
+	groupPage    int64
+	groupPerPage int64
+	groupSort    string
+	groupFilter  dto.ListFilterQueryParam
+	groupNoMFA   bool
+)
+
+var groupsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a group",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.CreateGroupRequestDTO{Name: groupName, Members: groupMembers, Owners: groupOwners}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			groupDTO, err := app.CreateGroup.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printGroups(cmd, groupDTO, []*dto.GroupResponseDTO{groupDTO})
+		})
+	},
+}
+
+var groupsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List groups",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.ListGroupQueryParam{Page: groupPage, PerPage: groupPerPage, Sort: groupSort, ListFilterQueryParam: groupFilter}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		input.Page = zeroBasedPage(input.Page)
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			list, err := app.ListGroups.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			if err := printGroups(cmd, list, list.Data); err != nil {
+				return err
+			}
+			if outputFormat == outputTable {
+				fmt.Fprintf(cmd.OutOrStdout(), "\nPage %d of %d (%d groups)\n", list.Meta.Page, list.Meta.TotalPages, list.Meta.Total)
+			}
+			return nil
+		})
+	},
+}
+
+var groupsAddMemberCmd = &cobra.Command{
+	Use:   "add-member <groupId> <userId>",
+	Short: "Add a user to a group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.AddGroupMemberRequestDTO{Role: groupRole}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.AddUserToGroup.Execute(ctx, args[0], args[1], entities.GroupRole(input.Role)); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "User %s added to group %s as %s\n", args[1], args[0], input.Role)
+			return nil
+		})
+	},
+}
+
+var groupsSetRoleCmd = &cobra.Command{
+	Use:   "set-role <groupId> <userId>",
+	Short: "Change the role of a group member",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.UpdateGroupMemberRequestDTO{Role: groupRole}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			groupDTO, err := app.UpdateMemberRole.Execute(ctx, args[0], args[1], entities.GroupRole(input.Role))
+			if err != nil {
+				return err
+			}
+			return printGroups(cmd, groupDTO, []*dto.GroupResponseDTO{groupDTO})
+		})
+	},
+}
+
+var groupsRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member <groupId> <userId>",
+	Short: "Remove a user from a group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.RemoveUserFromGroup.Execute(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "User %s removed from group %s\n", args[1], args[0])
+			return nil
+		})
+	},
+}
+
+var groupsAddSubgroupCmd = &cobra.Command{
+	Use:   "add-subgroup <groupId> <subgroupId>",
+	Short: "Nest a group inside another group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.AddSubgroup.Execute(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Group %s added to group %s\n", args[1], args[0])
+			return nil
+		})
+	},
+}
+
+var groupsRemoveSubgroupCmd = &cobra.Command{
+	Use:   "remove-subgroup <groupId> <subgroupId>",
+	Short: "Remove a nested group from a group",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.RemoveSubgroup.Execute(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Group %s removed from group %s\n", args[1], args[0])
+			return nil
+		})
+	},
+}
+
+var groupsRequireMFACmd = &cobra.Command{
+	Use:   "require-mfa <groupId>",
+	Short: "Require multi-factor authentication from the group's members",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			groupDTO, err := app.SetGroupMFAPolicy.Execute(ctx, args[0], !groupNoMFA)
+			if err != nil {
+				return fmt.Errorf("group %s: %w", args[0], err)
+			}
+			return printGroups(cmd, groupDTO, []*dto.GroupResponseDTO{groupDTO})
+		})
+	},
+}
+
+// printGroups imprime value (grupo ou lista) em json/yaml ou as linhas em formato de tabela
+func printGroups(cmd *cobra.Command, value interface{}, groups []*dto.GroupResponseDTO) error {
+	return printOutput(cmd.OutOrStdout(), value, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tNAME\tMEMBERS\tSUBGROUPS\tREQUIRE MFA")
+		for _, g := range groups {
+			members := make([]string, 0, len(g.Memberships))
+			for _, m := range g.Memberships {
+				members = append(members, m.UserID+":"+m.Role)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", g.ID, g.Name, strings.Join(members, ","), strings.Join(g.Subgroups, ","), g.RequireMFA)
+		}
+	})
+}
+
+func init() {
+	groupsCreateCmd.Flags().StringVar(&groupName, "name", "", "Group name")
+	groupsCreateCmd.Flags().StringSliceVar(&groupMembers, "members", nil, "Comma-separated user IDs")
+	groupsCreateCmd.Flags().StringSliceVar(&groupOwners, "owners", nil, "Comma-separated user IDs added with the owner role")
+	groupsAddMemberCmd.Flags().StringVar(&groupRole, "role", string(entities.GroupRoleMember), "Member role: owner, manager or member")
+	groupsSetRoleCmd.Flags().StringVar(&groupRole, "role", "", "New role: owner, manager or member")
+	groupsListCmd.Flags().Int64Var(&groupPage, "page", 1, "Page number")
+	groupsListCmd.Flags().Int64Var(&groupPerPage, "per-page", 10, "Groups per page")
+	addListFilterFlags(groupsListCmd, &groupSort, &groupFilter)
+	groupsRequireMFACmd.Flags().BoolVar(&groupNoMFA, "off", false, "Stop requiring MFA from the group's members")
+
+	groupsCmd.AddCommand(groupsCreateCmd, groupsListCmd, groupsAddMemberCmd, groupsSetRoleCmd, groupsRemoveMemberCmd, groupsAddSubgroupCmd, groupsRemoveSubgroupCmd, groupsRequireMFACmd)
+	addOutputFlag(groupsCmd)
+	rootCmd.AddCommand(groupsCmd)
+}
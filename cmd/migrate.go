@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/infrastructure/database/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+}
+
+var migrateSteps int
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			applied, err := app.Migrator.Up(ctx)
+			printMigrations(cmd, "Applied", applied)
+			if err != nil {
+				return err
+			}
+			if len(applied) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Database schema is already up to date")
+			}
+			return nil
+		})
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the most recently applied migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			reverted, err := app.Migrator.Down(ctx, migrateSteps)
+			printMigrations(cmd, "Reverted", reverted)
+			if err != nil {
+				return err
+			}
+			if len(reverted) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No applied migrations to revert")
+			}
+			return nil
+		})
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			statuses, err := app.Migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd.OutOrStdout(), statuses, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "VERSION\tDESCRIPTION\tSTATUS\tAPPLIED AT")
+				for _, s := range statuses {
+					status, appliedAt := "pending", "-"
+					if s.Applied {
+						status, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+					}
+					fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Version, s.Description, status, appliedAt)
+				}
+			})
+		})
+	},
+}
+
+func printMigrations(cmd *cobra.Command, action string, list []migrations.Migration) {
+	for _, m := range list {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %03d %s\n", action, m.Version, m.Description)
+	}
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateSteps, "steps", 1, "Number of migrations to revert")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+	addOutputFlag(migrateStatusCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
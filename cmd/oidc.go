@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/application/dto"
+
+	"github.com/spf13/cobra"
+)
+
+var oidcCmd = &cobra.Command{
+	Use:   "oidc",
+	Short: "Manage the OpenID Connect provider",
+}
+
+var oidcClientsCmd = &cobra.Command{
+	Use:   "clients",
+	Short: "Manage OpenID Connect clients of the tenant selected with --tenant",
+}
+
+var (
+	oidcClientName         string
+	oidcClientRedirectURIs []string
+	oidcClientPublic       bool
+	oidcClientPage         int64
+	oidcClientPerPage      int64
+)
+
+var oidcClientsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Register a client and print its secret (shown only once)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.CreateOAuthClientRequestDTO{Name: oidcClientName, RedirectURIs: oidcClientRedirectURIs, Public: oidcClientPublic}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			created, err := app.RegisterOAuthClient.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd.OutOrStdout(), created, func(tw *tabwriter.Writer) {
+				writeOAuthClientsTable(tw, []*dto.OAuthClientResponseDTO{&created.OAuthClientResponseDTO})
+				if created.ClientSecret != "" {
+					fmt.Fprintf(tw, "\nClient secret: %s\nStore it now; it cannot be shown again.\n", created.ClientSecret)
+				}
+			})
+		})
+	},
+}
+
+var oidcClientsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List clients",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.PageQueryParam{Page: oidcClientPage, PerPage: oidcClientPerPage}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		input.Page = zeroBasedPage(input.Page)
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			list, err := app.ListOAuthClients.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd.OutOrStdout(), list, func(tw *tabwriter.Writer) {
+				writeOAuthClientsTable(tw, list.Data)
+			})
+		})
+	},
+}
+
+var oidcClientsDeleteCmd = &cobra.Command{
+	Use:   "delete <client-id>",
+	Short: "Delete a client; tokens already issued stay valid until they expire",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.DeleteOAuthClient.Execute(ctx, args[0]); err != nil {
+				return fmt.Errorf("client %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Client %s deleted\n", args[0])
+			return nil
+		})
+	},
+}
+
+var oidcRotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Generate a new signing key now",
+	Long: `Generate a new signing key for the tokens of the OpenID Connect provider.
+
+Keys are rotated automatically every OIDC_KEY_ROTATION_INTERVAL; use this command to rotate
+immediately, e.g. if the current key may have leaked. Previous keys stay in the JWKS until the
+tokens signed with them expire, so clients are not affected.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			kid, err := app.SigningKeys.Rotate(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "New signing key %s\n", kid)
+			return nil
+		})
+	},
+}
+
+func writeOAuthClientsTable(tw *tabwriter.Writer, clients []*dto.OAuthClientResponseDTO) {
+	fmt.Fprintln(tw, "CLIENT ID\tNAME\tPUBLIC\tREDIRECT URIS\tCREATED AT")
+	for _, c := range clients {
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", c.ClientID, c.Name, c.Public, strings.Join(c.RedirectURIs, ","), c.CreatedAt.Format(time.RFC3339))
+	}
+}
+
+func init() {
+	oidcClientsCreateCmd.Flags().StringVar(&oidcClientName, "name", "", "Client name")
+	oidcClientsCreateCmd.Flags().StringSliceVar(&oidcClientRedirectURIs, "redirect-uri", nil, "Allowed redirect URI (repeat or separate with commas)")
+	oidcClientsCreateCmd.Flags().BoolVar(&oidcClientPublic, "public", false, "Register a public client (no secret; PKCE only)")
+	oidcClientsListCmd.Flags().Int64Var(&oidcClientPage, "page", 1, "Page number")
+	oidcClientsListCmd.Flags().Int64Var(&oidcClientPerPage, "per-page", 10, "Clients per page")
+
+	oidcClientsCmd.AddCommand(oidcClientsCreateCmd, oidcClientsListCmd, oidcClientsDeleteCmd)
+	addOutputFlag(oidcClientsCmd)
+	oidcCmd.AddCommand(oidcClientsCmd, oidcRotateKeysCmd)
+	rootCmd.AddCommand(oidcCmd)
+}
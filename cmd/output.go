@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormat string
+
+// addOutputFlag registra a flag --output nos comandos que imprimem resultados
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "Output format: table, json or yaml")
+}
+
+// printOutput imprime value no formato escolhido; no formato table usa writeTable
+func printOutput(w io.Writer, value interface{}, writeTable func(tw *tabwriter.Writer)) error {
+	switch outputFormat {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputYAML:
+		// Converte via JSON para reaproveitar os nomes de campos das tags json dos DTOs
+		content, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(content, &generic); err != nil {
+			return err
+		}
+		return yaml.NewEncoder(w).Encode(generic)
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		writeTable(tw)
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q (use table, json or yaml)", outputFormat)
+	}
+}
@@ -1,30 +1,73 @@
 package cmd
 
 import (
-	"github.com/spf13/cobra"
+	"context"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	configFile string
+	cliTenant  string
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "initApiServer",
+	Use:   "user-management",
 	Short: "User Management API",
-	Run: func(cmd *cobra.Command, args []string) {
-
-		server, err := InitializeServer()
-		if err != nil {
-			log.Fatalf("Failed to initialize server: %v", err)
-		}
-		if err := server.Start(); err != nil {
-			log.Fatalf("Failed to start server: %v", err)
-		}
+	// Sem subcomando, inicia o servidor (mantém o comportamento anterior)
+	RunE:          serveCmd.RunE,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
 
-	},
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path to a YAML or TOML config file (env "+config.ConfigFileEnv+")")
+	rootCmd.PersistentFlags().StringVar(&cliTenant, "tenant", tenancy.DefaultTenant, "Tenant used by the users, groups and seed commands")
+	config.RegisterFlags(rootCmd.PersistentFlags())
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	if err := Run(context.Background(), os.Args[1:], os.Stdout); err != nil {
 		log.Println(err)
 		os.Exit(1)
 	}
 }
+
+// Run executa a CLI com args, escrevendo a saída dos comandos em out. As flags do comando
+// escolhido voltam aos valores padrão antes da execução, já que ficam em variáveis do pacote e
+// sobreviveriam de uma execução para a outra no mesmo processo
+func Run(ctx context.Context, args []string, out io.Writer) error {
+	if target, _, err := rootCmd.Find(args); err == nil {
+		resetFlags(target)
+	}
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(out)
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// resetFlags restaura os valores padrão das flags de cmd e das flags persistentes herdadas
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var values []string
+			if defaults := strings.Trim(flag.DefValue, "[]"); defaults != "" {
+				values = strings.Split(defaults, ",")
+			}
+			_ = slice.Replace(values)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	for c := cmd; c != nil; c = c.Parent() {
+		c.PersistentFlags().VisitAll(reset)
+	}
+}
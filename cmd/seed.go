@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/infrastructure/seed"
+
+	"github.com/spf13/cobra"
+)
+
+var seedOptions seed.Options
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Populate the database with realistic fake users and groups",
+	Long: `Populate the database with realistic fake users and groups for local development
+and load testing. Data is written through the same use cases as the API. The same
+--seed always generates the same data; without it a random seed is used and printed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if seedOptions.Users < 0 || seedOptions.Groups < 0 || seedOptions.MaxMembers < 0 {
+			return fmt.Errorf("--users, --groups and --max-members must not be negative")
+		}
+		if !cmd.Flags().Changed("seed") {
+			seedOptions.Seed = time.Now().UnixNano()
+		}
+
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			seeder := seed.NewSeeder(app.CreateUser, app.CreateGroup, app.AddUserToGroup)
+			result, err := seeder.Run(ctx, seedOptions)
+			if result != nil {
+				if printErr := printOutput(cmd.OutOrStdout(), result, func(tw *tabwriter.Writer) {
+					fmt.Fprintln(tw, "SEED\tUSERS CREATED\tUSERS SKIPPED\tGROUPS\tMEMBERSHIPS")
+					fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\n", result.Seed, result.UsersCreated, result.UsersSkipped, result.Groups, result.Memberships)
+				}); printErr != nil && err == nil {
+					err = printErr
+				}
+			}
+			return err
+		})
+	},
+}
+
+func init() {
+	seedCmd.Flags().IntVar(&seedOptions.Users, "users", 50, "Number of users to create")
+	seedCmd.Flags().IntVar(&seedOptions.Groups, "groups", 10, "Number of groups to create")
+	seedCmd.Flags().IntVar(&seedOptions.MaxMembers, "max-members", 20, "Maximum members per group (0 means no limit)")
+	seedCmd.Flags().Int64Var(&seedOptions.Seed, "seed", 0, "Random seed for reproducible data")
+
+	addOutputFlag(seedCmd)
+	rootCmd.AddCommand(seedCmd)
+}
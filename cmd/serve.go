@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	Aliases: []string{"initApiServer"},
+	Short:   "Start the HTTP API server",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		server, err := InitializeServer(cfg)
+		if err != nil {
+			return err
+		}
+		return server.Start()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+}
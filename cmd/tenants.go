@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/application/dto"
+
+	"github.com/spf13/cobra"
+)
+
+var tenantsCmd = &cobra.Command{
+	Use:   "tenants",
+	Short: "Manage tenants",
+}
+
+var (
+	tenantSlug    string
+	tenantName    string
+	tenantPage    int64
+	tenantPerPage int64
+)
+
+var tenantsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a tenant",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.CreateTenantRequestDTO{Slug: tenantSlug, Name: tenantName}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			tenantDTO, err := app.CreateTenant.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printTenants(cmd, tenantDTO, []*dto.TenantResponseDTO{tenantDTO})
+		})
+	},
+}
+
+var tenantsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tenants",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.PageQueryParam{Page: tenantPage, PerPage: tenantPerPage}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		input.Page = zeroBasedPage(input.Page)
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			list, err := app.ListTenants.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printTenants(cmd, list, list.Data)
+		})
+	},
+}
+
+var tenantsDeleteCmd = &cobra.Command{
+	Use:   "delete <slug>",
+	Short: "Delete a tenant without users or groups",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.DeleteTenant.Execute(ctx, args[0]); err != nil {
+				return fmt.Errorf("tenant %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Tenant %s deleted\n", args[0])
+			return nil
+		})
+	},
+}
+
+// printTenants imprime value (tenant ou lista) em json/yaml ou as linhas em formato de tabela
+func printTenants(cmd *cobra.Command, value interface{}, tenants []*dto.TenantResponseDTO) error {
+	return printOutput(cmd.OutOrStdout(), value, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "SLUG\tNAME\tCREATED AT")
+		for _, t := range tenants {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", t.Slug, t.Name, t.CreatedAt.Format(time.RFC3339))
+		}
+	})
+}
+
+func init() {
+	tenantsCreateCmd.Flags().StringVar(&tenantSlug, "slug", "", "Tenant slug (lowercase DNS label, cannot be changed)")
+	tenantsCreateCmd.Flags().StringVar(&tenantName, "name", "", "Tenant name")
+	tenantsListCmd.Flags().Int64Var(&tenantPage, "page", 1, "Page number")
+	tenantsListCmd.Flags().Int64Var(&tenantPerPage, "per-page", 10, "Tenants per page")
+
+	tenantsCmd.AddCommand(tenantsCreateCmd, tenantsListCmd, tenantsDeleteCmd)
+	addOutputFlag(tenantsCmd)
+	rootCmd.AddCommand(tenantsCmd)
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+
+	"github.com/spf13/cobra"
+)
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage users",
+}
+
+var (
+	userName     string
+	userEmail    string
+	userStatus   string
+	userNoInvite bool
+	userReason   string
+	userPage     int64
+	userPerPage  int64
+	userSearch   string
+	userDeleted  bool
+	userSort     string
+	userFilter   dto.ListFilterQueryParam
+)
+
+var usersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a user",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.CreateUserRequestDTO{Name: userName, Email: userEmail, Status: userStatus}
+		if userNoInvite {
+			sendInvitation := false
+			input.SendInvitation = &sendInvitation
+		}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			userDTO, err := app.CreateUser.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			return printUsers(cmd, userDTO, []dto.UserResponseDTO{*userDTO})
+		})
+	},
+}
+
+var usersGetCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Show a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			userDTO, err := app.GetUser.Execute(ctx, args[0], userDeleted)
+			if err != nil {
+				return fmt.Errorf("user %s: %w", args[0], err)
+			}
+			return printUsers(cmd, userDTO, []dto.UserResponseDTO{*userDTO})
+		})
+	},
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := dto.ListUserQueryParam{Page: userPage, PerPage: userPerPage, Search: userSearch, Sort: userSort, IncludeDeleted: userDeleted, ListFilterQueryParam: userFilter}
+		if err := validateInput(&input); err != nil {
+			return err
+		}
+		input.Page = zeroBasedPage(input.Page)
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			list, err := app.ListUsers.Execute(ctx, &input)
+			if err != nil {
+				return err
+			}
+			if err := printUsers(cmd, list, list.Data); err != nil {
+				return err
+			}
+			if outputFormat == outputTable {
+				fmt.Fprintf(cmd.OutOrStdout(), "\nPage %d of %d (%d users)\n", list.Meta.Page, list.Meta.TotalPages, list.Meta.Total)
+			}
+			return nil
+		})
+	},
+}
+
+var usersUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update a user (only the given flags are changed)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			existing, err := app.GetUser.Execute(ctx, args[0], false)
+			if err != nil {
+				return fmt.Errorf("user %s: %w", args[0], err)
+			}
+
+			input := dto.UpdateUserRequestDTO{Name: existing.Name, Email: existing.Email}
+			if cmd.Flags().Changed("name") {
+				input.Name = userName
+			}
+			if cmd.Flags().Changed("email") {
+				input.Email = userEmail
+			}
+			if err := validateInput(&input); err != nil {
+				return err
+			}
+
+			userDTO, err := app.UpdateUser.Execute(ctx, args[0], &input)
+			if err != nil {
+				return err
+			}
+			return printUsers(cmd, userDTO, []dto.UserResponseDTO{*userDTO})
+		})
+	},
+}
+
+var usersDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.DeleteUser.Execute(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "User %s deleted\n", args[0])
+			return nil
+		})
+	},
+}
+
+var usersRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a deleted user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			userDTO, err := app.RestoreUser.Execute(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("user %s: %w", args[0], err)
+			}
+			return printUsers(cmd, userDTO, []dto.UserResponseDTO{*userDTO})
+		})
+	},
+}
+
+var usersResetMFACmd = &cobra.Command{
+	Use:   "reset-mfa <id>",
+	Short: "Remove a user's TOTP enrollment so a new authenticator can be enrolled",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			if err := app.ResetMFA.Execute(ctx, args[0]); err != nil {
+				return fmt.Errorf("user %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "MFA reset for user %s\n", args[0])
+			return nil
+		})
+	},
+}
+
+var usersResendInvitationCmd = &cobra.Command{
+	Use:   "resend-invitation <id>",
+	Short: "Send a new invitation to a pending user, invalidating the previous links",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+			invitation, err := app.ResendInvitation.Execute(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("user %s: %w", args[0], err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Invitation sent to %s (expires at %s)\n", invitation.Email, invitation.ExpiresAt.Format(time.RFC3339))
+			return nil
+		})
+	},
+}
+
+// newUserStatusCmd cria os comandos de transição de status (activate, suspend, deprovision)
+func newUserStatusCmd(use, short string, status entities.UserStatus) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := dto.ChangeUserStatusRequestDTO{Reason: userReason}
+			if err := validateInput(&input); err != nil {
+				return err
+			}
+			return runAdmin(cmd, func(ctx context.Context, app *AdminApp) error {
+				userDTO, err := app.ChangeUserStatus.Execute(ctx, args[0], status, &input)
+				if err != nil {
+					return fmt.Errorf("user %s: %w", args[0], err)
+				}
+				return printUsers(cmd, userDTO, []dto.UserResponseDTO{*userDTO})
+			})
+		},
+	}
+	cmd.Flags().StringVar(&userReason, "reason", "", "Reason for the status change (required to suspend or deprovision)")
+	return cmd
+}
+
+// printUsers imprime value (usuário ou lista) em json/yaml ou as linhas em formato de tabela
+func printUsers(cmd *cobra.Command, value interface{}, users []dto.UserResponseDTO) error {
+	return printOutput(cmd.OutOrStdout(), value, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tNAME\tEMAIL\tSTATUS")
+		for _, u := range users {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", u.ID, u.Name, u.Email, u.Status)
+		}
+	})
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{usersCreateCmd, usersUpdateCmd} {
+		cmd.Flags().StringVar(&userName, "name", "", "User name")
+		cmd.Flags().StringVar(&userEmail, "email", "", "User email")
+	}
+	usersCreateCmd.Flags().StringVar(&userStatus, "status", "", "Initial status: pending (default) or active")
+	usersCreateCmd.Flags().BoolVar(&userNoInvite, "no-invite", false, "Do not email an invitation to a pending user")
+	usersListCmd.Flags().Int64Var(&userPage, "page", 1, "Page number")
+	usersListCmd.Flags().Int64Var(&userPerPage, "per-page", 10, "Users per page")
+	usersListCmd.Flags().StringVar(&userSearch, "search", "", "Search by name or email")
+	addListFilterFlags(usersListCmd, &userSort, &userFilter)
+	for _, cmd := range []*cobra.Command{usersGetCmd, usersListCmd} {
+		cmd.Flags().BoolVar(&userDeleted, "include-deleted", false, "Include deleted users")
+	}
+
+	usersCmd.AddCommand(usersCreateCmd, usersGetCmd, usersListCmd, usersUpdateCmd, usersDeleteCmd, usersRestoreCmd, usersResetMFACmd, usersResendInvitationCmd,
+		newUserStatusCmd("activate", "Activate a pending or suspended user", entities.UserStatusActive),
+		newUserStatusCmd("suspend", "Suspend an active user", entities.UserStatusSuspended),
+		newUserStatusCmd("deprovision", "Deprovision a user and remove it from all groups", entities.UserStatusDeprovisioned),
+	)
+	addOutputFlag(usersCmd)
+	rootCmd.AddCommand(usersCmd)
+}
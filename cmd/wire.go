@@ -5,40 +5,170 @@
 package cmd
 
 import (
+	"user-management/internal/application/auth"
+	"user-management/internal/application/usecases/apikey"
 	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/maintenance"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/application/usecases/passwordreset"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/application/usecases/tenant"
 	"user-management/internal/application/usecases/user"
 	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/clock"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/health"
+	"user-management/internal/infrastructure/jobs"
 	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/mail"
+	"user-management/internal/infrastructure/password"
+	"user-management/internal/infrastructure/ratelimit"
 	irepos "user-management/internal/infrastructure/repositories"
+	"user-management/internal/infrastructure/signing"
+	"user-management/internal/infrastructure/token"
+	"user-management/internal/infrastructure/tracing"
 	"user-management/internal/infrastructure/web"
 	"user-management/internal/infrastructure/web/controllers"
+	"user-management/internal/infrastructure/web/middleware"
 
 	"github.com/google/wire"
 )
 
-func InitializeServer() (*web.Server, error) {
+// persistenceSet fornece o logger, a conexão com o banco, as migrations e os repositórios
+var persistenceSet = wire.NewSet(
+	logger.NewLogger,
+	database.NewMongoDB,
+	migrations.NewMigrator,
+	irepos.NewUserRepository,
+	irepos.NewGroupRepository,
+	irepos.NewTenantRepository,
+	irepos.NewAPIKeyRepository,
+	irepos.NewOAuthClientRepository,
+	irepos.NewAuthorizationCodeRepository,
+	irepos.NewSigningKeyRepository,
+	irepos.NewMFAEnrollmentRepository,
+	irepos.NewInvitationRepository,
+	irepos.NewPasswordResetRepository,
+	irepos.NewAuditEventRepository,
+	irepos.NewSessionRepository,
+	irepos.NewRefreshTokenRepository,
+	irepos.NewIdempotencyKeyRepository,
+)
+
+// useCaseSet fornece os casos de uso compartilhados entre a API e os comandos administrativos,
+// junto com o relógio usado nos campos de auditoria, as chaves de assinatura do provedor OpenID
+// Connect, o envio de emails e o hash de senhas
+var useCaseSet = wire.NewSet(
+	clock.NewSystemClock,
+	mail.NewMailer,
+	password.NewBcryptHasher,
+	user.NewCreateUserUseCase,
+	user.NewGetUserUseCase,
+	user.NewUpdateUserUseCase,
+	user.NewDeleteUserUseCase,
+	user.NewListUsersUseCase,
+	user.NewRestoreUserUseCase,
+	user.NewChangeUserStatusUseCase,
+	user.NewListEffectiveGroupsUseCase,
+	user.NewListUserGroupsUseCase,
+	user.NewResendInvitationUseCase,
+	user.NewAcceptInvitationUseCase,
+	group.NewCreateGroupUseCase,
+	group.NewGetGroupUseCase,
+	group.NewUpdateGroupUseCase,
+	group.NewDeleteGroupUseCase,
+	group.NewListGroupsUseCase,
+	group.NewAddUserToGroupUseCase,
+	group.NewRemoveUserFromGroupUseCase,
+	group.NewRestoreGroupUseCase,
+	group.NewAddSubgroupUseCase,
+	group.NewRemoveSubgroupUseCase,
+	group.NewListEffectiveMembersUseCase,
+	group.NewListGroupMembersUseCase,
+	group.NewAddGroupMembersUseCase,
+	group.NewRemoveGroupMembersUseCase,
+	group.NewReplaceGroupMembersUseCase,
+	group.NewUpdateMemberRoleUseCase,
+	group.NewSetGroupMFAPolicyUseCase,
+	tenant.NewCreateTenantUseCase,
+	tenant.NewGetTenantUseCase,
+	tenant.NewListTenantsUseCase,
+	tenant.NewUpdateTenantUseCase,
+	tenant.NewDeleteTenantUseCase,
+	apikey.NewCreateAPIKeyUseCase,
+	apikey.NewGetAPIKeyUseCase,
+	apikey.NewListAPIKeysUseCase,
+	apikey.NewRevokeAPIKeyUseCase,
+	apikey.NewAuthenticateAPIKeyUseCase,
+	signing.NewKeyManager,
+	wire.Bind(new(services.ISigningKeys), new(*signing.KeyManager)),
+	oidc.NewRegisterClientUseCase,
+	oidc.NewGetClientUseCase,
+	oidc.NewListClientsUseCase,
+	oidc.NewDeleteClientUseCase,
+	oidc.NewAuthorizeUseCase,
+	oidc.NewExchangeCodeUseCase,
+	oidc.NewUserInfoUseCase,
+	mfa.NewGetStatusUseCase,
+	mfa.NewEnrollUseCase,
+	mfa.NewConfirmUseCase,
+	mfa.NewVerifyUseCase,
+	mfa.NewRegenerateRecoveryCodesUseCase,
+	mfa.NewDisableUseCase,
+	mfa.NewResetUseCase,
+	mfa.NewCheckUseCase,
+	passwordreset.NewRequestUseCase,
+	passwordreset.NewConfirmUseCase,
+	session.NewValidateUseCase,
+	session.NewLoginUseCase,
+	session.NewRefreshUseCase,
+	session.NewListUseCase,
+	session.NewRevokeUseCase,
+	session.NewRevokeAllUseCase,
+	maintenance.NewPurgeDeletedUseCase,
+)
+
+func InitializeServer(cfg *config.Config) (*web.Server, error) {
 	wire.Build(
-		logger.NewLogger,
-		config.NewConfig,
-		database.NewMongoDB,
-		irepos.NewUserRepository,
-		irepos.NewGroupRepository,
-		user.NewCreateUserUseCase,
-		user.NewGetUserUseCase,
-		user.NewUpdateUserUseCase,
-		user.NewDeleteUserUseCase,
-		user.NewListUsersUseCase,
-		group.NewCreateGroupUseCase,
-		group.NewGetGroupUseCase,
-		group.NewUpdateGroupUseCase,
-		group.NewDeleteGroupUseCase,
-		group.NewListGroupsUseCase,
-		group.NewAddUserToGroupUseCase,
-		group.NewRemoveUserFromGroupUseCase,
+		persistenceSet,
+		useCaseSet,
+		tracing.NewProvider,
+		health.NewService,
+		token.NewService,
+		wire.Bind(new(auth.TokenIssuer), new(*token.Service)),
+		middleware.NewAuthenticator,
+		middleware.NewTenantResolver,
+		middleware.NewMFAEnforcer,
+		ratelimit.NewStore,
+		middleware.NewRateLimiter,
+		middleware.NewIdempotency,
+		jobs.NewPurgeJob,
+		jobs.NewPasswordResetQueue,
 		controllers.NewUserController,
 		controllers.NewGroupController,
+		controllers.NewTenantController,
+		controllers.NewAPIKeyController,
+		controllers.NewOAuthClientController,
+		controllers.NewOIDCController,
+		controllers.NewMFAController,
+		controllers.NewInvitationController,
+		controllers.NewPasswordResetController,
+		controllers.NewSessionController,
+		controllers.NewHealthController,
 		web.NewServer,
 	)
 	return &web.Server{}, nil
 }
+
+// InitializeAdmin monta os casos de uso para os comandos administrativos, sem iniciar o Fiber
+func InitializeAdmin(cfg *config.Config) (*AdminApp, error) {
+	wire.Build(
+		persistenceSet,
+		useCaseSet,
+		wire.Struct(new(AdminApp), "*"),
+	)
+	return &AdminApp{}, nil
+}
@@ -7,50 +7,328 @@
 package cmd
 
 import (
+	"github.com/google/wire"
+	"user-management/internal/application/usecases/apikey"
 	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/maintenance"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/application/usecases/passwordreset"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/application/usecases/tenant"
 	"user-management/internal/application/usecases/user"
 	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/clock"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/health"
+	"user-management/internal/infrastructure/jobs"
 	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/mail"
+	"user-management/internal/infrastructure/password"
+	"user-management/internal/infrastructure/ratelimit"
 	"user-management/internal/infrastructure/repositories"
+	"user-management/internal/infrastructure/signing"
+	"user-management/internal/infrastructure/token"
+	"user-management/internal/infrastructure/tracing"
 	"user-management/internal/infrastructure/web"
 	"user-management/internal/infrastructure/web/controllers"
+	"user-management/internal/infrastructure/web/middleware"
 )
 
 // Injectors from wire.go:
 
-func InitializeServer() (*web.Server, error) {
-	configConfig, err := config.NewConfig()
+func InitializeServer(cfg *config.Config) (*web.Server, error) {
+	logrusLogger := logger.NewLogger(cfg)
+	mongoDB, err := database.NewMongoDB(cfg, logrusLogger)
 	if err != nil {
 		return nil, err
 	}
-	logrusLogger := logger.NewLogger()
-	mongoDB, err := database.NewMongoDB(configConfig, logrusLogger)
+	iUserRepository, err := repositories.NewUserRepository(mongoDB)
 	if err != nil {
 		return nil, err
 	}
-	iUserRepository, err := repositories.NewUserRepository(mongoDB)
+	iInvitationRepository, err := repositories.NewInvitationRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	iMailer, err := mail.NewMailer(cfg)
 	if err != nil {
 		return nil, err
 	}
-	createUserUseCase := user.NewCreateUserUseCase(iUserRepository)
+	iClock := clock.NewSystemClock()
+	createUserUseCase := user.NewCreateUserUseCase(cfg, iUserRepository, iInvitationRepository, iMailer, iClock)
 	getUserUseCase := user.NewGetUserUseCase(iUserRepository)
-	updateUserUseCase := user.NewUpdateUserUseCase(iUserRepository)
-	deleteUserUseCase := user.NewDeleteUserUseCase(iUserRepository)
+	updateUserUseCase := user.NewUpdateUserUseCase(iUserRepository, iClock)
+	iSessionRepository, err := repositories.NewSessionRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	deleteUserUseCase := user.NewDeleteUserUseCase(iUserRepository, iSessionRepository, iClock)
 	listUsersUseCase := user.NewListUsersUseCase(iUserRepository)
-	userController := controllers.NewUserController(createUserUseCase, getUserUseCase, updateUserUseCase, deleteUserUseCase, listUsersUseCase)
+	restoreUserUseCase := user.NewRestoreUserUseCase(iUserRepository, iClock)
 	iGroupRepository, err := repositories.NewGroupRepository(mongoDB)
 	if err != nil {
 		return nil, err
 	}
-	createGroupUseCase := group.NewCreateGroupUseCase(iGroupRepository)
+	changeUserStatusUseCase := user.NewChangeUserStatusUseCase(iUserRepository, iGroupRepository, iSessionRepository, iClock)
+	listEffectiveGroupsUseCase := user.NewListEffectiveGroupsUseCase(iUserRepository, iGroupRepository)
+	listUserGroupsUseCase := user.NewListUserGroupsUseCase(iUserRepository, iGroupRepository)
+	resendInvitationUseCase := user.NewResendInvitationUseCase(cfg, iUserRepository, iInvitationRepository, iMailer, iClock)
+	userController := controllers.NewUserController(createUserUseCase, getUserUseCase, updateUserUseCase, deleteUserUseCase, listUsersUseCase, restoreUserUseCase, changeUserStatusUseCase, listEffectiveGroupsUseCase, listUserGroupsUseCase, resendInvitationUseCase)
+	createGroupUseCase := group.NewCreateGroupUseCase(iGroupRepository, iClock)
 	getGroupUseCase := group.NewGetGroupUseCase(iGroupRepository)
-	updateGroupUseCase := group.NewUpdateGroupUseCase(iGroupRepository)
-	deleteGroupUseCase := group.NewDeleteGroupUseCase(iGroupRepository)
+	updateGroupUseCase := group.NewUpdateGroupUseCase(iGroupRepository, iClock)
+	deleteGroupUseCase := group.NewDeleteGroupUseCase(iGroupRepository, iClock)
 	listGroupsUseCase := group.NewListGroupsUseCase(iGroupRepository)
-	addUserToGroupUseCase := group.NewAddUserToGroupUseCase(iGroupRepository, iUserRepository)
-	removeUserFromGroupUseCase := group.NewRemoveUserFromGroupUseCase(iGroupRepository)
-	groupController := controllers.NewGroupController(createGroupUseCase, getGroupUseCase, updateGroupUseCase, deleteGroupUseCase, listGroupsUseCase, addUserToGroupUseCase, removeUserFromGroupUseCase)
-	server := web.NewServer(configConfig, userController, groupController, logrusLogger, mongoDB)
+	addUserToGroupUseCase := group.NewAddUserToGroupUseCase(iGroupRepository, iUserRepository, iClock)
+	removeUserFromGroupUseCase := group.NewRemoveUserFromGroupUseCase(iGroupRepository, iClock)
+	restoreGroupUseCase := group.NewRestoreGroupUseCase(iGroupRepository, iClock)
+	addSubgroupUseCase := group.NewAddSubgroupUseCase(iGroupRepository, iClock)
+	removeSubgroupUseCase := group.NewRemoveSubgroupUseCase(iGroupRepository, iClock)
+	listEffectiveMembersUseCase := group.NewListEffectiveMembersUseCase(iGroupRepository)
+	updateMemberRoleUseCase := group.NewUpdateMemberRoleUseCase(iGroupRepository, iClock)
+	listGroupMembersUseCase := group.NewListGroupMembersUseCase(iGroupRepository)
+	addGroupMembersUseCase := group.NewAddGroupMembersUseCase(iGroupRepository, iUserRepository, iClock)
+	removeGroupMembersUseCase := group.NewRemoveGroupMembersUseCase(iGroupRepository, iClock)
+	replaceGroupMembersUseCase := group.NewReplaceGroupMembersUseCase(iGroupRepository, iUserRepository, iClock)
+	setGroupMFAPolicyUseCase := group.NewSetGroupMFAPolicyUseCase(iGroupRepository, iClock)
+	groupController := controllers.NewGroupController(createGroupUseCase, getGroupUseCase, updateGroupUseCase, deleteGroupUseCase, listGroupsUseCase, addUserToGroupUseCase, removeUserFromGroupUseCase, restoreGroupUseCase, addSubgroupUseCase, removeSubgroupUseCase, listEffectiveMembersUseCase, updateMemberRoleUseCase, listGroupMembersUseCase, addGroupMembersUseCase, removeGroupMembersUseCase, replaceGroupMembersUseCase, setGroupMFAPolicyUseCase)
+	iTenantRepository, err := repositories.NewTenantRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	createTenantUseCase := tenant.NewCreateTenantUseCase(iTenantRepository, iClock)
+	getTenantUseCase := tenant.NewGetTenantUseCase(iTenantRepository)
+	listTenantsUseCase := tenant.NewListTenantsUseCase(iTenantRepository)
+	updateTenantUseCase := tenant.NewUpdateTenantUseCase(iTenantRepository, iClock)
+	deleteTenantUseCase := tenant.NewDeleteTenantUseCase(iTenantRepository, iUserRepository, iGroupRepository)
+	tenantController := controllers.NewTenantController(createTenantUseCase, getTenantUseCase, listTenantsUseCase, updateTenantUseCase, deleteTenantUseCase)
+	iapiKeyRepository, err := repositories.NewAPIKeyRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(iapiKeyRepository, iClock)
+	getAPIKeyUseCase := apikey.NewGetAPIKeyUseCase(iapiKeyRepository)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(iapiKeyRepository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(iapiKeyRepository, iClock)
+	apiKeyController := controllers.NewAPIKeyController(createAPIKeyUseCase, getAPIKeyUseCase, listAPIKeysUseCase, revokeAPIKeyUseCase)
+	ioAuthClientRepository, err := repositories.NewOAuthClientRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	registerClientUseCase := oidc.NewRegisterClientUseCase(ioAuthClientRepository, iClock)
+	getClientUseCase := oidc.NewGetClientUseCase(ioAuthClientRepository)
+	listClientsUseCase := oidc.NewListClientsUseCase(ioAuthClientRepository)
+	deleteClientUseCase := oidc.NewDeleteClientUseCase(ioAuthClientRepository)
+	oAuthClientController := controllers.NewOAuthClientController(registerClientUseCase, getClientUseCase, listClientsUseCase, deleteClientUseCase)
+	iSigningKeyRepository, err := repositories.NewSigningKeyRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	keyManager, err := signing.NewKeyManager(cfg, iSigningKeyRepository, iClock)
+	if err != nil {
+		return nil, err
+	}
+	iAuthorizationCodeRepository, err := repositories.NewAuthorizationCodeRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	authorizeUseCase := oidc.NewAuthorizeUseCase(cfg, ioAuthClientRepository, iAuthorizationCodeRepository, iUserRepository, iClock)
+	exchangeCodeUseCase := oidc.NewExchangeCodeUseCase(cfg, ioAuthClientRepository, iAuthorizationCodeRepository, iUserRepository, iGroupRepository, keyManager, iClock)
+	userInfoUseCase := oidc.NewUserInfoUseCase(cfg, iUserRepository, iGroupRepository, keyManager)
+	oidcController := controllers.NewOIDCController(cfg, keyManager, authorizeUseCase, exchangeCodeUseCase, userInfoUseCase)
+	imfaEnrollmentRepository, err := repositories.NewMFAEnrollmentRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	getStatusUseCase := mfa.NewGetStatusUseCase(imfaEnrollmentRepository, iGroupRepository)
+	enrollUseCase := mfa.NewEnrollUseCase(cfg, imfaEnrollmentRepository, iUserRepository, iClock)
+	service := token.NewService(cfg)
+	confirmUseCase := mfa.NewConfirmUseCase(imfaEnrollmentRepository, iSessionRepository, service, iClock)
+	verifyUseCase := mfa.NewVerifyUseCase(imfaEnrollmentRepository, iSessionRepository, service, iClock)
+	regenerateRecoveryCodesUseCase := mfa.NewRegenerateRecoveryCodesUseCase(imfaEnrollmentRepository, iClock)
+	disableUseCase := mfa.NewDisableUseCase(imfaEnrollmentRepository, iGroupRepository, iClock)
+	resetUseCase := mfa.NewResetUseCase(imfaEnrollmentRepository)
+	mfaController := controllers.NewMFAController(getStatusUseCase, enrollUseCase, confirmUseCase, verifyUseCase, regenerateRecoveryCodesUseCase, disableUseCase, resetUseCase)
+	iPasswordHasher := password.NewBcryptHasher()
+	acceptInvitationUseCase := user.NewAcceptInvitationUseCase(cfg, iUserRepository, iInvitationRepository, iPasswordHasher, iClock)
+	invitationController := controllers.NewInvitationController(acceptInvitationUseCase)
+	iPasswordResetRepository, err := repositories.NewPasswordResetRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	requestUseCase := passwordreset.NewRequestUseCase(cfg, iUserRepository, iPasswordResetRepository, iMailer, iClock)
+	passwordResetQueue := jobs.NewPasswordResetQueue(requestUseCase, logrusLogger)
+	iAuditEventRepository, err := repositories.NewAuditEventRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	passwordresetConfirmUseCase := passwordreset.NewConfirmUseCase(iUserRepository, iPasswordResetRepository, iSessionRepository, iAuditEventRepository, iPasswordHasher, iClock)
+	passwordResetController := controllers.NewPasswordResetController(passwordResetQueue, passwordresetConfirmUseCase)
+	iRefreshTokenRepository, err := repositories.NewRefreshTokenRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	loginUseCase := session.NewLoginUseCase(cfg, iUserRepository, iSessionRepository, iRefreshTokenRepository, iPasswordHasher, service, iClock)
+	refreshUseCase := session.NewRefreshUseCase(iUserRepository, iSessionRepository, iRefreshTokenRepository, service, iClock)
+	listUseCase := session.NewListUseCase(iUserRepository, iSessionRepository, iClock)
+	revokeUseCase := session.NewRevokeUseCase(iUserRepository, iSessionRepository, iClock)
+	revokeAllUseCase := session.NewRevokeAllUseCase(iUserRepository, iSessionRepository, iClock)
+	sessionController := controllers.NewSessionController(loginUseCase, refreshUseCase, listUseCase, revokeUseCase, revokeAllUseCase)
+	migrator := migrations.NewMigrator(mongoDB)
+	healthService := health.NewService(cfg, mongoDB, migrator)
+	healthController := controllers.NewHealthController(healthService)
+	provider, err := tracing.NewProvider(cfg, logrusLogger)
+	if err != nil {
+		return nil, err
+	}
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(iapiKeyRepository, iClock)
+	validateUseCase := session.NewValidateUseCase(iUserRepository, iSessionRepository, iClock)
+	authenticator := middleware.NewAuthenticator(cfg, service, authenticateAPIKeyUseCase, validateUseCase)
+	tenantResolver := middleware.NewTenantResolver(cfg, iTenantRepository)
+	checkUseCase := mfa.NewCheckUseCase(imfaEnrollmentRepository, iGroupRepository)
+	mfaEnforcer := middleware.NewMFAEnforcer(checkUseCase)
+	iRateLimitStore, err := ratelimit.NewStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rateLimiter := middleware.NewRateLimiter(cfg, iRateLimitStore, iClock)
+	iIdempotencyKeyRepository, err := repositories.NewIdempotencyKeyRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	idempotency := middleware.NewIdempotency(cfg, iIdempotencyKeyRepository, iClock)
+	purgeDeletedUseCase := maintenance.NewPurgeDeletedUseCase(iUserRepository, iGroupRepository, imfaEnrollmentRepository)
+	purgeJob := jobs.NewPurgeJob(cfg, purgeDeletedUseCase, iClock, logrusLogger)
+	server := web.NewServer(cfg, userController, groupController, tenantController, apiKeyController, oAuthClientController, oidcController, mfaController, invitationController, passwordResetController, sessionController, healthController, logrusLogger, mongoDB, provider, healthService, migrator, authenticator, tenantResolver, mfaEnforcer, rateLimiter, idempotency, purgeJob, passwordResetQueue)
 	return server, nil
 }
+
+// InitializeAdmin monta os casos de uso para os comandos administrativos, sem iniciar o Fiber
+func InitializeAdmin(cfg *config.Config) (*AdminApp, error) {
+	logrusLogger := logger.NewLogger(cfg)
+	mongoDB, err := database.NewMongoDB(cfg, logrusLogger)
+	if err != nil {
+		return nil, err
+	}
+	migrator := migrations.NewMigrator(mongoDB)
+	iUserRepository, err := repositories.NewUserRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	iInvitationRepository, err := repositories.NewInvitationRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	iMailer, err := mail.NewMailer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	iClock := clock.NewSystemClock()
+	createUserUseCase := user.NewCreateUserUseCase(cfg, iUserRepository, iInvitationRepository, iMailer, iClock)
+	getUserUseCase := user.NewGetUserUseCase(iUserRepository)
+	updateUserUseCase := user.NewUpdateUserUseCase(iUserRepository, iClock)
+	iSessionRepository, err := repositories.NewSessionRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	deleteUserUseCase := user.NewDeleteUserUseCase(iUserRepository, iSessionRepository, iClock)
+	listUsersUseCase := user.NewListUsersUseCase(iUserRepository)
+	restoreUserUseCase := user.NewRestoreUserUseCase(iUserRepository, iClock)
+	iGroupRepository, err := repositories.NewGroupRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	changeUserStatusUseCase := user.NewChangeUserStatusUseCase(iUserRepository, iGroupRepository, iSessionRepository, iClock)
+	resendInvitationUseCase := user.NewResendInvitationUseCase(cfg, iUserRepository, iInvitationRepository, iMailer, iClock)
+	imfaEnrollmentRepository, err := repositories.NewMFAEnrollmentRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	resetUseCase := mfa.NewResetUseCase(imfaEnrollmentRepository)
+	createGroupUseCase := group.NewCreateGroupUseCase(iGroupRepository, iClock)
+	listGroupsUseCase := group.NewListGroupsUseCase(iGroupRepository)
+	addUserToGroupUseCase := group.NewAddUserToGroupUseCase(iGroupRepository, iUserRepository, iClock)
+	removeUserFromGroupUseCase := group.NewRemoveUserFromGroupUseCase(iGroupRepository, iClock)
+	updateMemberRoleUseCase := group.NewUpdateMemberRoleUseCase(iGroupRepository, iClock)
+	addSubgroupUseCase := group.NewAddSubgroupUseCase(iGroupRepository, iClock)
+	removeSubgroupUseCase := group.NewRemoveSubgroupUseCase(iGroupRepository, iClock)
+	setGroupMFAPolicyUseCase := group.NewSetGroupMFAPolicyUseCase(iGroupRepository, iClock)
+	iTenantRepository, err := repositories.NewTenantRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	createTenantUseCase := tenant.NewCreateTenantUseCase(iTenantRepository, iClock)
+	listTenantsUseCase := tenant.NewListTenantsUseCase(iTenantRepository)
+	deleteTenantUseCase := tenant.NewDeleteTenantUseCase(iTenantRepository, iUserRepository, iGroupRepository)
+	iapiKeyRepository, err := repositories.NewAPIKeyRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(iapiKeyRepository, iClock)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(iapiKeyRepository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(iapiKeyRepository, iClock)
+	ioAuthClientRepository, err := repositories.NewOAuthClientRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	registerClientUseCase := oidc.NewRegisterClientUseCase(ioAuthClientRepository, iClock)
+	listClientsUseCase := oidc.NewListClientsUseCase(ioAuthClientRepository)
+	deleteClientUseCase := oidc.NewDeleteClientUseCase(ioAuthClientRepository)
+	iSigningKeyRepository, err := repositories.NewSigningKeyRepository(mongoDB)
+	if err != nil {
+		return nil, err
+	}
+	keyManager, err := signing.NewKeyManager(cfg, iSigningKeyRepository, iClock)
+	if err != nil {
+		return nil, err
+	}
+	purgeDeletedUseCase := maintenance.NewPurgeDeletedUseCase(iUserRepository, iGroupRepository, imfaEnrollmentRepository)
+	adminApp := &AdminApp{
+		Log:                 logrusLogger,
+		MongoDB:             mongoDB,
+		Migrator:            migrator,
+		CreateUser:          createUserUseCase,
+		GetUser:             getUserUseCase,
+		UpdateUser:          updateUserUseCase,
+		DeleteUser:          deleteUserUseCase,
+		ListUsers:           listUsersUseCase,
+		RestoreUser:         restoreUserUseCase,
+		ChangeUserStatus:    changeUserStatusUseCase,
+		ResendInvitation:    resendInvitationUseCase,
+		ResetMFA:            resetUseCase,
+		CreateGroup:         createGroupUseCase,
+		ListGroups:          listGroupsUseCase,
+		AddUserToGroup:      addUserToGroupUseCase,
+		RemoveUserFromGroup: removeUserFromGroupUseCase,
+		UpdateMemberRole:    updateMemberRoleUseCase,
+		AddSubgroup:         addSubgroupUseCase,
+		RemoveSubgroup:      removeSubgroupUseCase,
+		SetGroupMFAPolicy:   setGroupMFAPolicyUseCase,
+		Tenants:             iTenantRepository,
+		CreateTenant:        createTenantUseCase,
+		ListTenants:         listTenantsUseCase,
+		DeleteTenant:        deleteTenantUseCase,
+		CreateAPIKey:        createAPIKeyUseCase,
+		ListAPIKeys:         listAPIKeysUseCase,
+		RevokeAPIKey:        revokeAPIKeyUseCase,
+		RegisterOAuthClient: registerClientUseCase,
+		ListOAuthClients:    listClientsUseCase,
+		DeleteOAuthClient:   deleteClientUseCase,
+		SigningKeys:         keyManager,
+		PurgeDeleted:        purgeDeletedUseCase,
+	}
+	return adminApp, nil
+}
+
+// wire.go:
+
+// persistenceSet fornece o logger, a conexão com o banco, as migrations e os repositórios
+var persistenceSet = wire.NewSet(logger.NewLogger, database.NewMongoDB, migrations.NewMigrator, repositories.NewUserRepository, repositories.NewGroupRepository, repositories.NewTenantRepository, repositories.NewAPIKeyRepository, repositories.NewOAuthClientRepository, repositories.NewAuthorizationCodeRepository, repositories.NewSigningKeyRepository, repositories.NewMFAEnrollmentRepository, repositories.NewInvitationRepository, repositories.NewPasswordResetRepository, repositories.NewAuditEventRepository, repositories.NewSessionRepository, repositories.NewRefreshTokenRepository, repositories.NewIdempotencyKeyRepository)
+
+// useCaseSet fornece os casos de uso compartilhados entre a API e os comandos administrativos,
+// junto com o relógio usado nos campos de auditoria, as chaves de assinatura do provedor OpenID
+// Connect, o envio de emails e o hash de senhas
+var useCaseSet = wire.NewSet(clock.NewSystemClock, mail.NewMailer, password.NewBcryptHasher, user.NewCreateUserUseCase, user.NewGetUserUseCase, user.NewUpdateUserUseCase, user.NewDeleteUserUseCase, user.NewListUsersUseCase, user.NewRestoreUserUseCase, user.NewChangeUserStatusUseCase, user.NewListEffectiveGroupsUseCase, user.NewListUserGroupsUseCase, user.NewResendInvitationUseCase, user.NewAcceptInvitationUseCase, group.NewCreateGroupUseCase, group.NewGetGroupUseCase, group.NewUpdateGroupUseCase, group.NewDeleteGroupUseCase, group.NewListGroupsUseCase, group.NewAddUserToGroupUseCase, group.NewRemoveUserFromGroupUseCase, group.NewRestoreGroupUseCase, group.NewAddSubgroupUseCase, group.NewRemoveSubgroupUseCase, group.NewListEffectiveMembersUseCase, group.NewListGroupMembersUseCase, group.NewAddGroupMembersUseCase, group.NewRemoveGroupMembersUseCase, group.NewReplaceGroupMembersUseCase, group.NewUpdateMemberRoleUseCase, group.NewSetGroupMFAPolicyUseCase, tenant.NewCreateTenantUseCase, tenant.NewGetTenantUseCase, tenant.NewListTenantsUseCase, tenant.NewUpdateTenantUseCase, tenant.NewDeleteTenantUseCase, apikey.NewCreateAPIKeyUseCase, apikey.NewGetAPIKeyUseCase, apikey.NewListAPIKeysUseCase, apikey.NewRevokeAPIKeyUseCase, apikey.NewAuthenticateAPIKeyUseCase, signing.NewKeyManager, wire.Bind(new(services.ISigningKeys), new(*signing.KeyManager)), oidc.NewRegisterClientUseCase, oidc.NewGetClientUseCase, oidc.NewListClientsUseCase, oidc.NewDeleteClientUseCase, oidc.NewAuthorizeUseCase, oidc.NewExchangeCodeUseCase, oidc.NewUserInfoUseCase, mfa.NewGetStatusUseCase, mfa.NewEnrollUseCase, mfa.NewConfirmUseCase, mfa.NewVerifyUseCase, mfa.NewRegenerateRecoveryCodesUseCase, mfa.NewDisableUseCase, mfa.NewResetUseCase, mfa.NewCheckUseCase, passwordreset.NewRequestUseCase, passwordreset.NewConfirmUseCase, session.NewValidateUseCase, session.NewLoginUseCase, session.NewRefreshUseCase, session.NewListUseCase, session.NewRevokeUseCase, session.NewRevokeAllUseCase, maintenance.NewPurgeDeletedUseCase)
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tipos de principal
+const (
+	PrincipalUser   = "user"
+	PrincipalSystem = "system"
+	PrincipalAPIKey = "api_key"
+)
+
+// AnonymousID identifica chamadas sem principal autenticado (ex.: AUTH_ENABLED=false)
+const AnonymousID = "anonymous"
+
+var (
+	ErrUnauthorized = errors.New("authentication required")
+	ErrForbidden    = errors.New("insufficient permissions")
+)
+
+// Principal é a identidade que executa a operação: um usuário autenticado, uma API key, a CLI ou
+// um job interno. TenantID é o tenant ao qual o principal pertence (vazio para o tenant padrão) e
+// Admin vale apenas dentro dele; PlatformAdmin administra todos os tenants e escolhe em qual opera.
+// Scopes restringe os recursos acessíveis (ver scopes.go); nil significa sem restrição. MFA indica
+// que o usuário confirmou o segundo fator ao obter o token, IssuedAt é quando o token foi emitido
+// (zero para os demais principais) e SessionID é a sessão de login do token, quando ele foi
+// emitido pelo login do serviço
+type Principal struct {
+	ID            string
+	Type          string
+	TenantID      string
+	Admin         bool
+	PlatformAdmin bool
+	Scopes        []string
+	MFA           bool
+	IssuedAt      time.Time
+	SessionID     string
+}
+
+type principalKey struct{}
+
+// WithPrincipal associa o principal ao contexto
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext retorna o principal associado ao contexto, se houver
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Actor retorna o ID usado nos campos de auditoria (deleted_by, ...)
+func Actor(ctx context.Context) string {
+	if principal, ok := FromContext(ctx); ok && principal.ID != "" {
+		return principal.ID
+	}
+	return AnonymousID
+}
+
+// IsAdmin indica se o principal do contexto tem permissões administrativas no tenant da operação
+func IsAdmin(ctx context.Context) bool {
+	principal, ok := FromContext(ctx)
+	return ok && (principal.Admin || principal.PlatformAdmin)
+}
+
+// IsPlatformAdmin indica se o principal do contexto administra todos os tenants
+func IsPlatformAdmin(ctx context.Context) bool {
+	principal, ok := FromContext(ctx)
+	return ok && principal.PlatformAdmin
+}
+
+// RequireAdmin retorna ErrForbidden se o principal do contexto não for administrador
+func RequireAdmin(ctx context.Context) error {
+	if !IsAdmin(ctx) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// RequireSelfOrAdmin retorna ErrForbidden se o principal do contexto não for o próprio usuário
+// userID nem administrador
+func RequireSelfOrAdmin(ctx context.Context, userID string) error {
+	if principal, ok := FromContext(ctx); ok && principal.Type == PrincipalUser && principal.ID == userID {
+		return nil
+	}
+	return RequireAdmin(ctx)
+}
+
+// RequirePlatformAdmin retorna ErrForbidden se o principal do contexto não administrar a plataforma
+func RequirePlatformAdmin(ctx context.Context) error {
+	if !IsPlatformAdmin(ctx) {
+		return ErrForbidden
+	}
+	return nil
+}
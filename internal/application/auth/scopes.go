@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Escopos das API keys. Os tokens de usuário não têm escopos e acessam tudo o que seus papéis
+// permitem; uma API key só acessa os recursos dos escopos com que foi criada
+const (
+	ScopeUsersRead   = "users:read"
+	ScopeUsersWrite  = "users:write"
+	ScopeGroupsRead  = "groups:read"
+	ScopeGroupsWrite = "groups:write"
+	// ScopeAdmin concede as permissões administrativas do tenant (Principal.Admin)
+	ScopeAdmin = "admin"
+)
+
+// Scopes lista os escopos aceitos na criação de API keys
+var Scopes = []string{ScopeUsersRead, ScopeUsersWrite, ScopeGroupsRead, ScopeGroupsWrite, ScopeAdmin}
+
+// ValidScope indica se scope é um dos escopos conhecidos
+func ValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// HasScope indica se o principal pode acessar o escopo; principais sem escopos não têm restrição
+func (p Principal) HasScope(scope string) bool {
+	return p.Scopes == nil || slices.Contains(p.Scopes, scope)
+}
+
+// RequireScope retorna ErrForbidden se o principal do contexto não tiver o escopo
+func RequireScope(ctx context.Context, scope string) error {
+	principal, ok := FromContext(ctx)
+	if !ok || !principal.HasScope(scope) {
+		return fmt.Errorf("%w: missing scope %s", ErrForbidden, scope)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package auth
+
+import "time"
+
+// TokenIssuer emite os access tokens do serviço para um principal; é implementado pelo
+// token.Service e usado pelos casos de uso que trocam uma credencial por um novo token
+type TokenIssuer interface {
+	Issue(principal Principal) (string, time.Time, error)
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyRequestDTO struct {
+	Name   string   `json:"name" validate:"required,min=2,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=users:read users:write groups:read groups:write admin"`
+	// ExpiresAt é opcional; sem ele a chave vale até ser revogada
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyResponseDTO struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy  string     `json:"revoked_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	UpdatedBy  string     `json:"updated_by,omitempty"`
+}
+
+// CreateAPIKeyResponseDTO é a única resposta que contém o segredo da chave
+type CreateAPIKeyResponseDTO struct {
+	APIKeyResponseDTO
+	Key string `json:"key"`
+}
+
+type ListAPIKeyResponseDTO struct {
+	Data []*APIKeyResponseDTO `json:"api_keys"`
+	Meta Meta                 `json:"meta"`
+}
@@ -1,8 +1,58 @@
 package dto
 
+import "time"
+
 type CreateGroupRequestDTO struct {
 	Name    string   `json:"name" validate:"required,min=2,max=100"`
 	Members []string `json:"members"`
+	// Owners entram no grupo com o papel owner. Sem owners, o usuário autenticado que cria o
+	// grupo se torna o owner
+	Owners []string `json:"owners"`
+}
+
+// UpdateGroupRequestDTO substitui o nome e o conjunto de membros; quem continua no grupo mantém o
+// papel e os novos membros entram como member
+type UpdateGroupRequestDTO struct {
+	Name    string   `json:"name" validate:"required,min=2,max=100"`
+	Members []string `json:"members"`
+}
+
+// AddGroupMemberRequestDTO é o body opcional de POST /groups/:groupId/members/:userId
+type AddGroupMemberRequestDTO struct {
+	Role string `json:"role" validate:"omitempty,oneof=owner manager member"`
+}
+
+// UpdateGroupMemberRequestDTO é o body de PUT /groups/:groupId/members/:userId
+type UpdateGroupMemberRequestDTO struct {
+	Role string `json:"role" validate:"required,oneof=owner manager member"`
+}
+
+// AddGroupMembersRequestDTO é o body de POST /groups/:id/members; todos entram com o mesmo papel
+type AddGroupMembersRequestDTO struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=1000,dive,required"`
+	Role    string   `json:"role" validate:"omitempty,oneof=owner manager member"`
+}
+
+// RemoveGroupMembersRequestDTO é o body de DELETE /groups/:id/members
+type RemoveGroupMembersRequestDTO struct {
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// ReplaceGroupMembersRequestDTO é o body de PUT /groups/:id/members; uma lista vazia remove todos
+type ReplaceGroupMembersRequestDTO struct {
+	UserIDs []string `json:"user_ids" validate:"required,max=1000,dive,required"`
+}
+
+// GroupMFAPolicyRequestDTO é o body de PUT /groups/:id/mfa-policy
+type GroupMFAPolicyRequestDTO struct {
+	Required *bool `json:"required" validate:"required"`
+}
+
+// GroupMembersDiffResponseDTO informa quem de fato entrou e saiu do grupo em uma operação em lote
+type GroupMembersDiffResponseDTO struct {
+	Added   []string          `json:"added"`
+	Removed []string          `json:"removed"`
+	Group   *GroupResponseDTO `json:"group"`
 }
 
 type ListGroupResponseDTO struct {
@@ -13,10 +63,32 @@ type ListGroupResponseDTO struct {
 type ListGroupQueryParam struct {
 	Page    int64 `query:"page" default:"1" validate:"min=0"`
 	PerPage int64 `query:"per_page" default:"10" validate:"min=1,max=100"`
+	// Sort aceita o campo com prefixo "-" para ordem decrescente (ex.: -created_at)
+	Sort string `query:"sort" validate:"omitempty,oneof=created_at -created_at updated_at -updated_at name -name"`
+
+	IncludeDeleted bool `query:"include_deleted"`
+	ListFilterQueryParam
 }
 
 type GroupResponseDTO struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Members []string `json:"members"`
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name"`
+	// Members lista apenas os IDs; os papéis estão em Memberships
+	Members     []string                  `json:"members"`
+	Memberships []*GroupMemberResponseDTO `json:"memberships"`
+	Subgroups   []string                  `json:"subgroups"`
+	RequireMFA  bool                      `json:"require_mfa"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+	CreatedBy   string                    `json:"created_by,omitempty"`
+	UpdatedBy   string                    `json:"updated_by,omitempty"`
+	DeletedAt   *time.Time                `json:"deleted_at,omitempty"`
+	DeletedBy   string                    `json:"deleted_by,omitempty"`
+}
+
+type GroupMemberResponseDTO struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
 }
@@ -0,0 +1,10 @@
+package dto
+
+// ListFilterQueryParam são os filtros por data de criação/atualização comuns às listagens.
+// As datas seguem a RFC 3339 (ex.: 2024-01-31T15:04:05Z); "after" é inclusivo e "before" exclusivo
+type ListFilterQueryParam struct {
+	CreatedAfter  string `query:"created_after" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	CreatedBefore string `query:"created_before" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedAfter  string `query:"updated_after" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedBefore string `query:"updated_before" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
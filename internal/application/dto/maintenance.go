@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type PurgeResultDTO struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	UsersPurged   int       `json:"users_purged"`
+	GroupsPurged  int       `json:"groups_purged"`
+}
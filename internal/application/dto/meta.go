@@ -6,3 +6,17 @@ type Meta struct {
 	Page       int64 `json:"page"`
 	TotalPages int64 `json:"total_pages"`
 }
+
+// PageQueryParam é a paginação das listagens que não aceitam filtros
+type PageQueryParam struct {
+	Page    int64 `query:"page" default:"1" validate:"min=0"`
+	PerPage int64 `query:"per_page" default:"10" validate:"min=1,max=100"`
+}
+
+// SearchPageQueryParam é a paginação das listagens de membros e de grupos de um usuário, com
+// busca opcional por nome (e email, quando a listagem é de usuários)
+type SearchPageQueryParam struct {
+	Page    int64  `query:"page" default:"1" validate:"min=0"`
+	PerPage int64  `query:"per_page" default:"10" validate:"min=1,max=100"`
+	Search  string `query:"search" validate:"max=100"`
+}
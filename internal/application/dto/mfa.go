@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+// MFAStatusResponseDTO é a resposta de GET /auth/mfa. Required indica se os tokens do usuário
+// precisam ser verificados com o segundo fator, porque ele o ativou ou por política de grupo
+type MFAStatusResponseDTO struct {
+	Enabled                bool       `json:"enabled"`
+	Pending                bool       `json:"pending"`
+	Required               bool       `json:"required"`
+	RequiredByPolicy       bool       `json:"required_by_policy"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+}
+
+// EnrollMFAResponseDTO traz o segredo e o otpauth URI, que é o conteúdo do QR code lido pelo
+// aplicativo autenticador; o segredo não pode ser consultado depois
+type EnrollMFAResponseDTO struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// MFACodeRequestDTO é o body das operações que exigem um código TOTP atual
+type MFACodeRequestDTO struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// VerifyMFARequestDTO aceita um código TOTP ou, sem acesso ao aplicativo, um código de recuperação
+type VerifyMFARequestDTO struct {
+	Code         string `json:"code" validate:"omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,excluded_with=Code,max=32"`
+}
+
+// MFATokenResponseDTO é o access token emitido depois da verificação do segundo fator
+type MFATokenResponseDTO struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ConfirmMFAResponseDTO traz os códigos de recuperação, mostrados uma única vez, e um token já
+// verificado, já que o código usado na confirmação não pode ser reutilizado
+type ConfirmMFAResponseDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	MFATokenResponseDTO
+}
+
+type RecoveryCodesResponseDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
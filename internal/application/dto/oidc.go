@@ -0,0 +1,92 @@
+package dto
+
+import "time"
+
+type CreateOAuthClientRequestDTO struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+	// RedirectURIs são comparadas exatamente com a redirect_uri das autorizações
+	RedirectURIs []string `json:"redirect_uris" validate:"required,min=1,max=10,dive,url,max=2000"`
+	// Public registra um cliente sem segredo (SPA ou app nativo), que depende apenas do PKCE
+	Public bool `json:"public"`
+}
+
+type OAuthClientResponseDTO struct {
+	ClientID     string    `json:"client_id"`
+	TenantID     string    `json:"tenant_id"`
+	Name         string    `json:"name"`
+	Public       bool      `json:"public"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedBy    string    `json:"created_by,omitempty"`
+	UpdatedBy    string    `json:"updated_by,omitempty"`
+}
+
+// CreateOAuthClientResponseDTO é a única resposta que contém o segredo de um cliente confidencial
+type CreateOAuthClientResponseDTO struct {
+	OAuthClientResponseDTO
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+type ListOAuthClientResponseDTO struct {
+	Data []*OAuthClientResponseDTO `json:"clients"`
+	Meta Meta                      `json:"meta"`
+}
+
+// AuthorizeRequestDTO são os parâmetros do endpoint de autorização (RFC 6749 e RFC 7636). Não há
+// regras de validação: os erros são devolvidos no formato do OAuth, não no da API
+type AuthorizeRequestDTO struct {
+	ResponseType        string `query:"response_type"`
+	ClientID            string `query:"client_id"`
+	RedirectURI         string `query:"redirect_uri"`
+	Scope               string `query:"scope"`
+	State               string `query:"state"`
+	Nonce               string `query:"nonce"`
+	CodeChallenge       string `query:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method"`
+}
+
+// TokenRequestDTO são os parâmetros do endpoint de token, enviados como formulário. As
+// credenciais do cliente também podem vir no cabeçalho Authorization: Basic
+type TokenRequestDTO struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier"`
+}
+
+type TokenResponseDTO struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// UserInfoResponseDTO traz as claims do usuário liberadas pelos escopos do access token
+type UserInfoResponseDTO struct {
+	Subject string   `json:"sub"`
+	Tenant  string   `json:"tenant"`
+	Name    string   `json:"name,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+}
+
+// DiscoveryResponseDTO é o documento de /.well-known/openid-configuration
+type DiscoveryResponseDTO struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// PasswordResetRequestDTO é o body de POST /auth/password-reset
+type PasswordResetRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ConfirmPasswordResetRequestDTO é o body de POST /auth/password-reset/confirm. O limite de 72
+// caracteres acompanha o do bcrypt, que é de 72 bytes
+type ConfirmPasswordResetRequestDTO struct {
+	Token    string `json:"token" validate:"required,max=100"`
+	Password string `json:"password" validate:"required,min=12,max=72"`
+}
+
+// ClientInfo identifica de onde veio a requisição, para o log de auditoria
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// LoginRequestDTO é o body de POST /auth/login
+type LoginRequestDTO struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// RefreshSessionRequestDTO é o body de POST /auth/refresh
+type RefreshSessionRequestDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=100"`
+}
+
+// SessionTokenResponseDTO traz o access token e o refresh token de uma sessão. O refresh token vale
+// uma única vez: cada renovação devolve um novo
+type SessionTokenResponseDTO struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	SessionID             string    `json:"session_id"`
+}
+
+// SessionResponseDTO descreve uma sessão ativa; Current indica a sessão do token da requisição
+type SessionResponseDTO struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	MFA        bool      `json:"mfa"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type ListSessionsResponseDTO struct {
+	Data []*SessionResponseDTO `json:"sessions"`
+}
+
+// RevokeSessionsResponseDTO é a resposta de DELETE /users/:id/sessions
+type RevokeSessionsResponseDTO struct {
+	Revoked int64 `json:"revoked"`
+}
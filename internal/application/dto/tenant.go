@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type CreateTenantRequestDTO struct {
+	// Slug identifica o tenant em tenant_id, no cabeçalho X-Tenant-ID, no subdomínio e no token;
+	// precisa ser um rótulo DNS em minúsculas e não pode ser alterado depois
+	Slug string `json:"slug" validate:"required,max=63"`
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type UpdateTenantRequestDTO struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type TenantResponseDTO struct {
+	ID        string    `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+type ListTenantResponseDTO struct {
+	Data []*TenantResponseDTO `json:"tenants"`
+	Meta Meta                 `json:"meta"`
+}
@@ -1,15 +1,48 @@
 package dto
 
+import "time"
+
 type CreateUserRequestDTO struct {
-	Name     string `json:"name" validate:"required,min=2,max=100"`
-	Email    string `json:"email" validate:"required,email"`
-	IsActive bool   `json:"is_active"`
+	Name  string `json:"name" validate:"required,min=2,max=100"`
+	Email string `json:"email" validate:"required,email"`
+	// Status inicial; o padrão é pending. Depois da criação o status só muda pelos endpoints de transição
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=pending active"`
+	// SendInvitation controla o envio do convite por email aos usuários criados como pending; o
+	// padrão é true
+	SendInvitation *bool `json:"send_invitation,omitempty"`
+}
+
+// AcceptInvitationRequestDTO é o body opcional de POST /invitations/:token/accept. O limite de
+// 72 caracteres acompanha o do bcrypt, que é de 72 bytes
+type AcceptInvitationRequestDTO struct {
+	Password string `json:"password,omitempty" validate:"omitempty,min=12,max=72"`
+}
+
+type InvitationResponseDTO struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type UpdateUserRequestDTO struct {
+	Name  string `json:"name" validate:"required,min=2,max=100"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ChangeUserStatusRequestDTO é o body de /activate, /suspend e /deprovision
+type ChangeUserStatusRequestDTO struct {
+	Reason string `json:"reason" validate:"max=500"`
 }
 
 type ListUserQueryParam struct {
 	Page    int64  `query:"page" default:"1" validate:"min=0"`
 	PerPage int64  `query:"per_page" default:"10" validate:"min=1,max=100"`
 	Search  string `query:"search" validate:"max=100"`
+	// Sort aceita o campo com prefixo "-" para ordem decrescente (ex.: -created_at)
+	Sort string `query:"sort" validate:"omitempty,oneof=created_at -created_at updated_at -updated_at name -name email -email"`
+
+	IncludeDeleted bool `query:"include_deleted"`
+	ListFilterQueryParam
 }
 
 type UserListResponseDTO struct {
@@ -19,7 +52,20 @@ type UserListResponseDTO struct {
 
 type UserResponseDTO struct {
 	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
 	Name     string `json:"name"`
 	Email    string `json:"email"`
-	IsActive bool   `json:"is_active"`
+	// EmailVerifiedAt é preenchido quando o usuário aceita o convite
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	Status          string     `json:"status"`
+	// Dados da última transição de status
+	StatusReason    string     `json:"status_reason,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	StatusChangedBy string     `json:"status_changed_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	CreatedBy       string     `json:"created_by,omitempty"`
+	UpdatedBy       string     `json:"updated_by,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy       string     `json:"deleted_by,omitempty"`
 }
@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+)
+
+func ToAPIKeyResponseDTO(key *entities.APIKey) *dto.APIKeyResponseDTO {
+	return &dto.APIKeyResponseDTO{
+		ID:         key.ID.Hex(),
+		TenantID:   key.TenantID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		RevokedBy:  key.RevokedBy,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+		CreatedBy:  key.CreatedBy,
+		UpdatedBy:  key.UpdatedBy,
+	}
+}
+
+func ToListAPIKeyResponseDTO(keys []*entities.APIKey, total int64, page int64, perPage int64) *dto.ListAPIKeyResponseDTO {
+	keyDTOs := make([]*dto.APIKeyResponseDTO, 0, len(keys))
+	for _, key := range keys {
+		keyDTOs = append(keyDTOs, ToAPIKeyResponseDTO(key))
+	}
+	return &dto.ListAPIKeyResponseDTO{
+		Data: keyDTOs,
+		Meta: dto.Meta{
+			Total:      total,
+			Page:       page + 1, // Converte de volta para página baseada em 1 para o usuário
+			PerPage:    perPage,
+			TotalPages: calculateTotalPages(total, perPage),
+		},
+	}
+}
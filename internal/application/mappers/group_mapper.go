@@ -1,6 +1,7 @@
 package mappers
 
 import (
+	"time"
 	"user-management/internal/application/dto"
 	"user-management/internal/domain/entities"
 )
@@ -27,15 +28,39 @@ func ToListGroupResponseDTO(groups []*entities.Group, total int64, page int64, p
 
 func ToGroupResponseDTO(group *entities.Group) *dto.GroupResponseDTO {
 	return &dto.GroupResponseDTO{
-		ID:      group.ID.Hex(),
-		Name:    group.Name,
-		Members: group.Members,
+		ID:          group.ID.Hex(),
+		TenantID:    group.TenantID,
+		Name:        group.Name,
+		Members:     group.MemberIDs(),
+		Memberships: toGroupMemberResponseDTOs(group.Members),
+		Subgroups:   hexIDs(group.Subgroups),
+		RequireMFA:  group.RequireMFA,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+		CreatedBy:   group.CreatedBy,
+		UpdatedBy:   group.UpdatedBy,
+		DeletedAt:   group.DeletedAt,
+		DeletedBy:   group.DeletedBy,
 	}
 }
 
-func ToGroupEntityFromRequest(dto *dto.CreateGroupRequestDTO) *entities.Group {
-	return &entities.Group{
-		Name:    dto.Name,
-		Members: dto.Members,
+func toGroupMemberResponseDTOs(members []entities.GroupMember) []*dto.GroupMemberResponseDTO {
+	result := make([]*dto.GroupMemberResponseDTO, 0, len(members))
+	for _, member := range members {
+		result = append(result, &dto.GroupMemberResponseDTO{
+			UserID:   member.UserID,
+			Role:     string(member.Role),
+			JoinedAt: member.JoinedAt,
+		})
 	}
+	return result
+}
+
+// ToGroupEntityFromRequest cria o grupo com os owners e membros do request, todos entrando em
+// joinedAt; um usuário informado nas duas listas fica apenas como owner
+func ToGroupEntityFromRequest(dto *dto.CreateGroupRequestDTO, joinedAt time.Time) *entities.Group {
+	group := &entities.Group{Name: dto.Name}
+	group.AddMembers(dto.Owners, entities.GroupRoleOwner, joinedAt)
+	group.AddMembers(dto.Members, entities.GroupRoleMember, joinedAt)
+	return group
 }
@@ -0,0 +1,39 @@
+package mappers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+// ToListFilter converte os parâmetros de listagem para o filtro dos repositórios.
+// sort usa o prefixo "-" para ordem decrescente (ex.: -created_at)
+func ToListFilter(sort string, includeDeleted bool, query dto.ListFilterQueryParam) (repositories.ListFilter, error) {
+	filter := repositories.ListFilter{IncludeDeleted: includeDeleted}
+	filter.SortBy, filter.SortDesc = strings.CutPrefix(sort, "-")
+
+	bounds := []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"created_after", query.CreatedAfter, &filter.CreatedAfter},
+		{"created_before", query.CreatedBefore, &filter.CreatedBefore},
+		{"updated_after", query.UpdatedAfter, &filter.UpdatedAfter},
+		{"updated_before", query.UpdatedBefore, &filter.UpdatedBefore},
+	}
+	for _, bound := range bounds {
+		if bound.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return repositories.ListFilter{}, fmt.Errorf("%s: invalid date %q, expected RFC 3339", bound.name, bound.value)
+		}
+		parsed = parsed.UTC()
+		*bound.dest = &parsed
+	}
+	return filter, nil
+}
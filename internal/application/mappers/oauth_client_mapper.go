@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+)
+
+func ToOAuthClientResponseDTO(client *entities.OAuthClient) *dto.OAuthClientResponseDTO {
+	return &dto.OAuthClientResponseDTO{
+		ClientID:     client.ClientID,
+		TenantID:     client.TenantID,
+		Name:         client.Name,
+		Public:       client.Public(),
+		RedirectURIs: client.RedirectURIs,
+		CreatedAt:    client.CreatedAt,
+		UpdatedAt:    client.UpdatedAt,
+		CreatedBy:    client.CreatedBy,
+		UpdatedBy:    client.UpdatedBy,
+	}
+}
+
+func ToListOAuthClientResponseDTO(clients []*entities.OAuthClient, total int64, page int64, perPage int64) *dto.ListOAuthClientResponseDTO {
+	clientDTOs := make([]*dto.OAuthClientResponseDTO, 0, len(clients))
+	for _, client := range clients {
+		clientDTOs = append(clientDTOs, ToOAuthClientResponseDTO(client))
+	}
+	return &dto.ListOAuthClientResponseDTO{
+		Data: clientDTOs,
+		Meta: dto.Meta{
+			Total:      total,
+			Page:       page + 1, // Converte de volta para página baseada em 1 para o usuário
+			PerPage:    perPage,
+			TotalPages: calculateTotalPages(total, perPage),
+		},
+	}
+}
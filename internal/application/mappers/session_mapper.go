@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+)
+
+func ToSessionResponseDTO(session *entities.Session, currentID string) *dto.SessionResponseDTO {
+	id := session.ID.Hex()
+	return &dto.SessionResponseDTO{
+		ID:         id,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+		MFA:        session.MFA,
+		Current:    id == currentID,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+func ToListSessionsResponseDTO(sessions []*entities.Session, currentID string) *dto.ListSessionsResponseDTO {
+	sessionDTOs := make([]*dto.SessionResponseDTO, 0, len(sessions))
+	for _, session := range sessions {
+		sessionDTOs = append(sessionDTOs, ToSessionResponseDTO(session, currentID))
+	}
+	return &dto.ListSessionsResponseDTO{Data: sessionDTOs}
+}
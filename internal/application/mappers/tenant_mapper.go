@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+)
+
+func ToTenantResponseDTO(tenant *entities.Tenant) *dto.TenantResponseDTO {
+	return &dto.TenantResponseDTO{
+		ID:        tenant.ID.Hex(),
+		Slug:      tenant.Slug,
+		Name:      tenant.Name,
+		CreatedAt: tenant.CreatedAt,
+		UpdatedAt: tenant.UpdatedAt,
+		CreatedBy: tenant.CreatedBy,
+		UpdatedBy: tenant.UpdatedBy,
+	}
+}
+
+func ToListTenantResponseDTO(tenants []*entities.Tenant, total int64, page int64, perPage int64) *dto.ListTenantResponseDTO {
+	tenantDTOs := make([]*dto.TenantResponseDTO, 0, len(tenants))
+	for _, tenant := range tenants {
+		tenantDTOs = append(tenantDTOs, ToTenantResponseDTO(tenant))
+	}
+	return &dto.ListTenantResponseDTO{
+		Data: tenantDTOs,
+		Meta: dto.Meta{
+			Total:      total,
+			Page:       page + 1, // Converte de volta para página baseada em 1 para o usuário
+			PerPage:    perPage,
+			TotalPages: calculateTotalPages(total, perPage),
+		},
+	}
+}
@@ -26,17 +26,32 @@ func ToUserListResponseDTO(users []*entities.User, total int64, page int64, perP
 
 func ToUserResponseDTO(user *entities.User) *dto.UserResponseDTO {
 	return &dto.UserResponseDTO{
-		ID:       user.ID.Hex(),
-		Name:     user.Name,
-		Email:    user.Email,
-		IsActive: user.IsActive,
+		ID:              user.ID.Hex(),
+		TenantID:        user.TenantID,
+		Name:            user.Name,
+		Email:           user.Email,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Status:          string(user.Status),
+		StatusReason:    user.StatusReason,
+		StatusChangedAt: user.StatusChangedAt,
+		StatusChangedBy: user.StatusChangedBy,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		CreatedBy:       user.CreatedBy,
+		UpdatedBy:       user.UpdatedBy,
+		DeletedAt:       user.DeletedAt,
+		DeletedBy:       user.DeletedBy,
 	}
 }
 
 func ToUserEntityFromRequest(dto *dto.CreateUserRequestDTO) *entities.User {
+	status := entities.UserStatusPending
+	if dto.Status != "" {
+		status = entities.UserStatus(dto.Status)
+	}
 	return &entities.User{
-		Name:     dto.Name,
-		Email:    dto.Email,
-		IsActive: dto.IsActive,
+		Name:   dto.Name,
+		Email:  dto.Email,
+		Status: status,
 	}
 }
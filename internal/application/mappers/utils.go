@@ -1,8 +1,21 @@
 package mappers
 
-import "math"
+import (
+	"math"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
 
 // calculateTotalPages calcula o número total de páginas
 func calculateTotalPages(total int64, perPage int64) int64 {
 	return int64(math.Ceil(float64(total) / float64(perPage)))
 }
+
+// hexIDs converte ObjectIDs para a representação em string usada nos DTOs; nunca retorna nil
+func hexIDs(ids []bson.ObjectID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.Hex())
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package secret
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Random gera um valor aleatório de size bytes em base64url, que pode ir em URLs e cabeçalhos.
+// É a base dos tokens entregues aos clientes: links de convite e de redefinição de senha, refresh
+// tokens, segredos de API key e de clientes OAuth e códigos de autorização
+func Random(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Hash é o valor guardado no banco no lugar do token, em hexadecimal. Os tokens são aleatórios e
+// longos o bastante para dispensar salt, e o hash determinístico permite buscá-los por ele
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign é o HMAC-SHA256 do token com key, em hexadecimal. Usado no lugar de Hash quando quem lê o
+// banco não deve conseguir nem conferir tokens sem o segredo; trocar key invalida os já emitidos
+func Sign(key, token string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
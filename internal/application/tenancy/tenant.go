@@ -0,0 +1,70 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// DefaultTenant é o tenant dos dados anteriores à multi-tenancy e das requisições que não
+// indicam outro; existe sempre, mesmo sem documento na coleção tenants
+const DefaultTenant = "default"
+
+// Header é o cabeçalho HTTP que seleciona o tenant da requisição
+const Header = "X-Tenant-ID"
+
+// ErrTenantRequired indica uma operação por tenant executada sem tenant no contexto.
+// Um contexto sem tenant nunca é tratado como "todos os tenants"
+var ErrTenantRequired = errors.New("tenant is required")
+
+// ErrInvalidSlug é retornado para identificadores de tenant fora do formato aceito por ValidSlug
+var ErrInvalidSlug = errors.New("tenant slug must be a lowercase DNS label (a-z, 0-9 and -)")
+
+// slugPattern restringe o identificador a um rótulo DNS, para que possa ser usado como subdomínio
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidSlug indica se id pode ser usado como identificador de tenant
+func ValidSlug(id string) bool {
+	return slugPattern.MatchString(id)
+}
+
+type scope struct {
+	tenantID   string
+	allTenants bool
+}
+
+type scopeKey struct{}
+
+// WithTenant restringe as operações do contexto ao tenant informado
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope{tenantID: tenantID})
+}
+
+// WithAllTenants libera as operações do contexto em todos os tenants. Deve ser usado apenas por
+// rotinas de manutenção (ex.: expurgo), nunca a partir de dados da requisição
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope{allTenants: true})
+}
+
+// FromContext retorna o tenant do contexto; ok é false se não houver um tenant específico
+func FromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(scopeKey{}).(scope)
+	if !ok || s.allTenants || s.tenantID == "" {
+		return "", false
+	}
+	return s.tenantID, true
+}
+
+// Scope retorna o tenant do contexto ou all=true para contextos de manutenção; sem nenhum dos
+// dois retorna ErrTenantRequired
+func Scope(ctx context.Context) (tenantID string, all bool, err error) {
+	s, ok := ctx.Value(scopeKey{}).(scope)
+	switch {
+	case ok && s.allTenants:
+		return "", true, nil
+	case ok && s.tenantID != "":
+		return s.tenantID, false, nil
+	default:
+		return "", false, ErrTenantRequired
+	}
+}
@@ -0,0 +1,66 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ErrInvalidAPIKey é retornado para segredos que não correspondem a nenhuma chave
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// lastUsedResolution é o intervalo mínimo entre duas gravações de last_used_at da mesma chave
+const lastUsedResolution = time.Minute
+
+type AuthenticateAPIKeyUseCase struct {
+	repo  repositories.IAPIKeyRepository
+	clock services.IClock
+}
+
+func NewAuthenticateAPIKeyUseCase(repo repositories.IAPIKeyRepository, clock services.IClock) *AuthenticateAPIKeyUseCase {
+	return &AuthenticateAPIKeyUseCase{repo: repo, clock: clock}
+}
+
+// Execute valida o segredo e retorna o principal da chave: preso ao tenant da chave, com os seus
+// escopos e administrador do tenant apenas com o escopo admin. Chaves revogadas ou expiradas
+// retornam entities.ErrAPIKeyRevoked ou entities.ErrAPIKeyExpired
+func (uc *AuthenticateAPIKeyUseCase) Execute(ctx context.Context, secret string) (auth.Principal, error) {
+	ctx, span := tracer.Start(ctx, "AuthenticateAPIKeyUseCase.Execute")
+	defer span.End()
+
+	if !IsSecret(secret) {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	key, err := uc.repo.GetByHash(ctx, hashSecret(secret))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	now := uc.clock.Now()
+	if err := key.Usable(now); err != nil {
+		return auth.Principal{}, err
+	}
+
+	// Falhar ao registrar o uso não impede a requisição
+	if err := uc.repo.TouchLastUsed(ctx, key, now, lastUsedResolution); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("api_key_id", key.ID.Hex()).Warn("Failed to record API key usage")
+	}
+
+	scopes := append([]string{}, key.Scopes...)
+	return auth.Principal{
+		ID:       "api-key:" + key.ID.Hex(),
+		Type:     auth.PrincipalAPIKey,
+		TenantID: key.TenantID,
+		Admin:    slices.Contains(scopes, auth.ScopeAdmin),
+		Scopes:   scopes,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrExpiryInPast é retornado ao criar uma chave com expires_at no passado
+var ErrExpiryInPast = errors.New("expires_at must be in the future")
+
+type CreateAPIKeyUseCase struct {
+	repo  repositories.IAPIKeyRepository
+	clock services.IClock
+}
+
+func NewCreateAPIKeyUseCase(repo repositories.IAPIKeyRepository, clock services.IClock) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{repo: repo, clock: clock}
+}
+
+// Execute cria uma API key no tenant do contexto e retorna o segredo, que não pode ser consultado
+// depois. Exige um administrador; uma API key só cria chaves com escopos que ela mesma tem
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, input *dto.CreateAPIKeyRequestDTO) (*dto.CreateAPIKeyResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "CreateAPIKeyUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	scopes := make([]string, 0, len(input.Scopes))
+	for _, scope := range input.Scopes {
+		if err := auth.RequireScope(ctx, scope); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	now := uc.clock.Now()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, ErrExpiryInPast
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	key := &entities.APIKey{
+		Name:      input.Name,
+		Prefix:    secret[:displayPrefixLength],
+		KeyHash:   hashSecret(secret),
+		Scopes:    scopes,
+		ExpiresAt: input.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: auth.Actor(ctx),
+		UpdatedBy: auth.Actor(ctx),
+	}
+	if err := uc.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"api_key_id": key.ID.Hex(),
+		"scopes":     scopes,
+	}).Info("API key created")
+	return &dto.CreateAPIKeyResponseDTO{APIKeyResponseDTO: *mappers.ToAPIKeyResponseDTO(key), Key: secret}, nil
+}
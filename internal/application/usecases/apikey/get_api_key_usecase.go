@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type GetAPIKeyUseCase struct {
+	repo repositories.IAPIKeyRepository
+}
+
+func NewGetAPIKeyUseCase(repo repositories.IAPIKeyRepository) *GetAPIKeyUseCase {
+	return &GetAPIKeyUseCase{repo: repo}
+}
+
+// Execute retorna os dados da chave, sem o segredo
+func (uc *GetAPIKeyUseCase) Execute(ctx context.Context, id string) (*dto.APIKeyResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetAPIKeyUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	key, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToAPIKeyResponseDTO(key), nil
+}
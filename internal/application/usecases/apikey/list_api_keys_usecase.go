@@ -0,0 +1,37 @@
+package apikey
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListAPIKeysUseCase struct {
+	repo repositories.IAPIKeyRepository
+}
+
+func NewListAPIKeysUseCase(repo repositories.IAPIKeyRepository) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{repo: repo}
+}
+
+// Execute lista as chaves do tenant, inclusive as revogadas, das mais novas para as mais antigas;
+// input.Page já vem baseado em 0
+func (uc *ListAPIKeysUseCase) Execute(ctx context.Context, input *dto.PageQueryParam) (*dto.ListAPIKeyResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListAPIKeysUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	keys, err := uc.repo.List(ctx, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.repo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToListAPIKeyResponseDTO(keys, total, input.Page, input.PerPage), nil
+}
@@ -0,0 +1,40 @@
+package apikey
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type RevokeAPIKeyUseCase struct {
+	repo  repositories.IAPIKeyRepository
+	clock services.IClock
+}
+
+func NewRevokeAPIKeyUseCase(repo repositories.IAPIKeyRepository, clock services.IClock) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{repo: repo, clock: clock}
+}
+
+// Execute revoga a chave; a partir daí ela é recusada na autenticação, mas continua listada
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "RevokeAPIKeyUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return err
+	}
+	key, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := key.Revoke(uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return err
+	}
+	if err := uc.repo.Revoke(ctx, key); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("api_key_id", id).Info("API key revoked")
+	return nil
+}
@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"strings"
+	"user-management/internal/application/secret"
+)
+
+// SecretPrefix inicia todo segredo de API key e permite distingui-lo de um JWT no
+// cabeçalho Authorization: Bearer
+const SecretPrefix = "umk_"
+
+// displayPrefixLength é quanto do segredo é guardado em claro para identificar a chave
+const displayPrefixLength = len(SecretPrefix) + 8
+
+// IsSecret indica se o valor tem o formato de um segredo de API key
+func IsSecret(value string) bool {
+	return strings.HasPrefix(value, SecretPrefix)
+}
+
+// generateSecret cria um segredo com 256 bits aleatórios
+func generateSecret() (string, error) {
+	value, err := secret.Random(32)
+	if err != nil {
+		return "", err
+	}
+	return SecretPrefix + value, nil
+}
+
+// hashSecret é o valor guardado em key_hash, que permite buscar a chave pelo hash
+func hashSecret(value string) string {
+	return secret.Hash(value)
+}
@@ -0,0 +1,74 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type AddGroupMembersUseCase struct {
+	groupRepo repositories.IGroupRepository
+	userRepo  repositories.IUserRepository
+	clock     services.IClock
+}
+
+func NewAddGroupMembersUseCase(groupRepo repositories.IGroupRepository, userRepo repositories.IUserRepository, clock services.IClock) *AddGroupMembersUseCase {
+	return &AddGroupMembersUseCase{groupRepo: groupRepo, userRepo: userRepo, clock: clock}
+}
+
+// Execute adiciona vários usuários ao grupo com o mesmo papel. Quem já é membro é ignorado (e
+// mantém o papel atual); se algum usuário não existir ou estiver desprovisionado nada é alterado
+func (uc *AddGroupMembersUseCase) Execute(ctx context.Context, groupID string, input *dto.AddGroupMembersRequestDTO) (*dto.GroupMembersDiffResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "AddGroupMembersUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	role := entities.GroupRole(input.Role)
+	if role == "" {
+		role = entities.GroupRoleMember
+	}
+	if err := authorizeMembership(ctx, group, role); err != nil {
+		return nil, err
+	}
+
+	added := []string{}
+	for _, userID := range uniqueIDs(input.UserIDs) {
+		if _, ok := group.Member(userID); !ok {
+			added = append(added, userID)
+		}
+	}
+	if err := checkNewMembers(ctx, uc.userRepo, added); err != nil {
+		return nil, err
+	}
+
+	if len(added) > 0 {
+		now := uc.clock.Now()
+		members := make([]entities.GroupMember, 0, len(added))
+		for _, userID := range added {
+			members = append(members, entities.GroupMember{UserID: userID, Role: role, JoinedAt: now})
+		}
+		if err := uc.groupRepo.AddMembers(ctx, groupID, members, now, auth.Actor(ctx)); err != nil {
+			return nil, err
+		}
+		logger.FromContext(ctx).WithFields(logrus.Fields{
+			"group_id": groupID,
+			"added":    len(added),
+			"role":     role,
+		}).Info("Users added to group")
+
+		if group, err = uc.groupRepo.GetByID(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+	return &dto.GroupMembersDiffResponseDTO{Added: added, Removed: []string{}, Group: mappers.ToGroupResponseDTO(group)}, nil
+}
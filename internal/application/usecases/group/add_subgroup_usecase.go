@@ -0,0 +1,82 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type AddSubgroupUseCase struct {
+	groupRepo repositories.IGroupRepository
+	clock     services.IClock
+}
+
+func NewAddSubgroupUseCase(groupRepo repositories.IGroupRepository, clock services.IClock) *AddSubgroupUseCase {
+	return &AddSubgroupUseCase{groupRepo: groupRepo, clock: clock}
+}
+
+// Execute aninha subgroupID em groupID; retorna entities.ErrGroupCycle se groupID já descender
+// de subgroupID (ou for o próprio subgrupo)
+func (uc *AddSubgroupUseCase) Execute(ctx context.Context, groupID, subgroupID string) error {
+	ctx, span := tracer.Start(ctx, "AddSubgroupUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	// Os membros do subgrupo passam a ser membros efetivos, como se fossem adicionados como member
+	if err := authorizeMembership(ctx, group, entities.GroupRoleMember); err != nil {
+		return err
+	}
+	if _, err := uc.groupRepo.GetByID(ctx, subgroupID); err != nil {
+		return err
+	}
+	descendants, err := uc.groupRepo.GetDescendantIDs(ctx, subgroupID)
+	if err != nil {
+		return err
+	}
+	if err := entities.CheckSubgroup(groupID, subgroupID, descendants); err != nil {
+		return err
+	}
+
+	if err := uc.groupRepo.AddSubgroup(ctx, groupID, subgroupID, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return err
+	}
+	// A verificação acima não impede que um aninhamento concorrente no sentido oposto feche um
+	// ciclo entre a leitura e a escrita; por isso ela é refeita e, havendo ciclo, o aninhamento é
+	// desfeito. Se os dois pedidos concorrentes virem o ciclo, ambos são desfeitos
+	if err := uc.recheck(ctx, groupID, subgroupID); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id":    groupID,
+		"subgroup_id": subgroupID,
+	}).Info("Subgroup added to group")
+	return nil
+}
+
+// recheck procura um ciclo depois da escrita e desfaz o aninhamento se encontrar
+func (uc *AddSubgroupUseCase) recheck(ctx context.Context, groupID, subgroupID string) error {
+	descendants, err := uc.groupRepo.GetDescendantIDs(ctx, subgroupID)
+	if err != nil {
+		return err
+	}
+	cycle := entities.CheckSubgroup(groupID, subgroupID, descendants)
+	if cycle == nil {
+		return nil
+	}
+	if err := uc.groupRepo.RemoveSubgroup(ctx, groupID, subgroupID, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		logger.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"group_id":    groupID,
+			"subgroup_id": subgroupID,
+		}).Error("Failed to roll back subgroup that closed a cycle")
+		return err
+	}
+	return cycle
+}
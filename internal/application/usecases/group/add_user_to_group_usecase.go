@@ -2,26 +2,58 @@ package group
 
 import (
 	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
 )
 
+var ErrUserDeprovisioned = errors.New("deprovisioned users cannot be added to groups")
+
 type AddUserToGroupUseCase struct {
 	groupRepo repositories.IGroupRepository
 	userRepo  repositories.IUserRepository
+	clock     services.IClock
 }
 
-func NewAddUserToGroupUseCase(groupRepo repositories.IGroupRepository, userRepo repositories.IUserRepository) *AddUserToGroupUseCase {
-	return &AddUserToGroupUseCase{groupRepo: groupRepo, userRepo: userRepo}
+func NewAddUserToGroupUseCase(groupRepo repositories.IGroupRepository, userRepo repositories.IUserRepository, clock services.IClock) *AddUserToGroupUseCase {
+	return &AddUserToGroupUseCase{groupRepo: groupRepo, userRepo: userRepo, clock: clock}
 }
 
-func (uc *AddUserToGroupUseCase) Execute(ctx context.Context, groupID, userID string) error {
-	_, errGroup := uc.groupRepo.GetByID(ctx, groupID)
+// Execute adiciona o usuário ao grupo com o papel informado. Se ele já for membro nada muda;
+// para alterar o papel use UpdateMemberRoleUseCase
+func (uc *AddUserToGroupUseCase) Execute(ctx context.Context, groupID, userID string, role entities.GroupRole) error {
+	ctx, span := tracer.Start(ctx, "AddUserToGroupUseCase.Execute")
+	defer span.End()
+
+	group, errGroup := uc.groupRepo.GetByID(ctx, groupID)
 	if errGroup != nil {
 		return errGroup
 	}
-	_, errUser := uc.userRepo.GetByID(ctx, userID)
+	if err := authorizeMembership(ctx, group, role); err != nil {
+		return err
+	}
+	user, errUser := uc.userRepo.GetByID(ctx, userID)
 	if errUser != nil {
 		return errUser
 	}
-	return uc.groupRepo.AddUserToGroup(ctx, groupID, userID)
+	if user.Status == entities.UserStatusDeprovisioned {
+		return ErrUserDeprovisioned
+	}
+
+	now := uc.clock.Now()
+	member := entities.GroupMember{UserID: userID, Role: role, JoinedAt: now}
+	if err := uc.groupRepo.AddUserToGroup(ctx, groupID, member, now, auth.Actor(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id": groupID,
+		"user_id":  userID,
+		"role":     role,
+	}).Info("User added to group")
+	return nil
 }
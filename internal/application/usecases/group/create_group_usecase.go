@@ -2,24 +2,43 @@ package group
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
 )
 
 type CreateGroupUseCase struct {
-	repo repositories.IGroupRepository
+	repo  repositories.IGroupRepository
+	clock services.IClock
 }
 
-func NewCreateGroupUseCase(repo repositories.IGroupRepository) *CreateGroupUseCase {
-	return &CreateGroupUseCase{repo: repo}
+func NewCreateGroupUseCase(repo repositories.IGroupRepository, clock services.IClock) *CreateGroupUseCase {
+	return &CreateGroupUseCase{repo: repo, clock: clock}
 }
 
 func (uc *CreateGroupUseCase) Execute(ctx context.Context, groupDTO *dto.CreateGroupRequestDTO) (*dto.GroupResponseDTO, error) {
-	group := mappers.ToGroupEntityFromRequest(groupDTO)
+	ctx, span := tracer.Start(ctx, "CreateGroupUseCase.Execute")
+	defer span.End()
+
+	// Sem owners explícitos, o usuário que cria o grupo passa a gerenciá-lo
+	request := *groupDTO
+	if principal, ok := auth.FromContext(ctx); ok && principal.Type == auth.PrincipalUser && len(request.Owners) == 0 {
+		request.Owners = []string{principal.ID}
+	}
+
+	now := uc.clock.Now()
+	group := mappers.ToGroupEntityFromRequest(&request, now)
+	group.CreatedAt = now
+	group.UpdatedAt = group.CreatedAt
+	group.CreatedBy = auth.Actor(ctx)
+	group.UpdatedBy = group.CreatedBy
 	err := uc.repo.Create(ctx, group)
 	if err != nil {
 		return nil, err
 	}
+	logger.FromContext(ctx).WithField("group_id", group.ID.Hex()).Info("Group created")
 	return mappers.ToGroupResponseDTO(group), nil
 }
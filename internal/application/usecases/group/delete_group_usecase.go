@@ -2,17 +2,29 @@ package group
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
 )
 
 type DeleteGroupUseCase struct {
-	repo repositories.IGroupRepository
+	repo  repositories.IGroupRepository
+	clock services.IClock
 }
 
-func NewDeleteGroupUseCase(repo repositories.IGroupRepository) *DeleteGroupUseCase {
-	return &DeleteGroupUseCase{repo: repo}
+func NewDeleteGroupUseCase(repo repositories.IGroupRepository, clock services.IClock) *DeleteGroupUseCase {
+	return &DeleteGroupUseCase{repo: repo, clock: clock}
 }
 
 func (uc *DeleteGroupUseCase) Execute(ctx context.Context, id string) error {
-	return uc.repo.Delete(ctx, id)
+	ctx, span := tracer.Start(ctx, "DeleteGroupUseCase.Execute")
+	defer span.End()
+
+	// Soft delete: o registro pode ser restaurado até ser expurgado pelo job de retenção
+	if err := uc.repo.Delete(ctx, id, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("group_id", id).Info("Group deleted")
+	return nil
 }
@@ -2,6 +2,7 @@ package group
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/interfaces/repositories"
@@ -15,8 +16,20 @@ func NewGetGroupUseCase(repo repositories.IGroupRepository) *GetGroupUseCase {
 	return &GetGroupUseCase{repo: repo}
 }
 
-func (uc *GetGroupUseCase) Execute(ctx context.Context, id string) (*dto.GroupResponseDTO, error) {
-	group, err := uc.repo.GetByID(ctx, id)
+// Execute busca o grupo; com includeDeleted (apenas administradores) também retorna removidos
+func (uc *GetGroupUseCase) Execute(ctx context.Context, id string, includeDeleted bool) (*dto.GroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetGroupUseCase.Execute")
+	defer span.End()
+
+	get := uc.repo.GetByID
+	if includeDeleted {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+		get = uc.repo.GetByIDIncludingDeleted
+	}
+
+	group, err := get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
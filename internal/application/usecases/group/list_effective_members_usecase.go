@@ -0,0 +1,31 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListEffectiveMembersUseCase struct {
+	repo repositories.IGroupRepository
+}
+
+func NewListEffectiveMembersUseCase(repo repositories.IGroupRepository) *ListEffectiveMembersUseCase {
+	return &ListEffectiveMembersUseCase{repo: repo}
+}
+
+// Execute lista os membros diretos do grupo e os de todos os seus subgrupos
+func (uc *ListEffectiveMembersUseCase) Execute(ctx context.Context, groupID string, input *dto.PageQueryParam) (*dto.UserListResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListEffectiveMembersUseCase.Execute")
+	defer span.End()
+
+	if _, err := uc.repo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	users, total, err := uc.repo.ListEffectiveMembers(ctx, groupID, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToUserListResponseDTO(users, total, input.Page, input.PerPage), nil
+}
@@ -0,0 +1,31 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListGroupMembersUseCase struct {
+	repo repositories.IGroupRepository
+}
+
+func NewListGroupMembersUseCase(repo repositories.IGroupRepository) *ListGroupMembersUseCase {
+	return &ListGroupMembersUseCase{repo: repo}
+}
+
+// Execute lista os membros diretos do grupo com os dados completos de cada usuário
+func (uc *ListGroupMembersUseCase) Execute(ctx context.Context, groupID string, input *dto.SearchPageQueryParam) (*dto.UserListResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListGroupMembersUseCase.Execute")
+	defer span.End()
+
+	if _, err := uc.repo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	users, total, err := uc.repo.ListMembers(ctx, groupID, input.Search, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToUserListResponseDTO(users, total, input.Page, input.PerPage), nil
+}
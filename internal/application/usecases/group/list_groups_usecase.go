@@ -2,6 +2,7 @@ package group
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/interfaces/repositories"
@@ -16,13 +17,27 @@ func NewListGroupsUseCase(repo repositories.IGroupRepository) *ListGroupsUseCase
 }
 
 func (gc *ListGroupsUseCase) Execute(ctx context.Context, input *dto.ListGroupQueryParam) (*dto.ListGroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListGroupsUseCase.Execute")
+	defer span.End()
 
-	groups, err := gc.repo.List(ctx, input.Page, input.PerPage)
+	// Apenas administradores podem listar grupos removidos
+	if input.IncludeDeleted {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := mappers.ToListFilter(input.Sort, input.IncludeDeleted, input.ListFilterQueryParam)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := gc.repo.List(ctx, input.Page, input.PerPage, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := gc.repo.Count(ctx)
+	total, err := gc.repo.Count(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
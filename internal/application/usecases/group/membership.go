@@ -0,0 +1,85 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+var (
+	ErrMemberNotFound = errors.New("user is not a member of the group")
+	ErrUsersNotFound  = errors.New("users not found")
+)
+
+// authorizeMembership permite alterar membros com os papéis informados se o principal for
+// administrador ou um membro direto do grupo cujo papel gerencia todos eles (ver
+// entities.GroupRole.CanManage); caso contrário retorna auth.ErrForbidden
+func authorizeMembership(ctx context.Context, group *entities.Group, roles ...entities.GroupRole) error {
+	if auth.IsAdmin(ctx) {
+		return nil
+	}
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Type != auth.PrincipalUser {
+		return auth.ErrForbidden
+	}
+	member, ok := group.Member(principal.ID)
+	if !ok {
+		return auth.ErrForbidden
+	}
+	for _, role := range roles {
+		if !member.Role.CanManage(role) {
+			return auth.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// checkNewMembers verifica em uma única consulta se todos os usuários existem e podem entrar em
+// grupos, listando os IDs problemáticos no erro
+func checkNewMembers(ctx context.Context, userRepo repositories.IUserRepository, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	users, err := userRepo.ListByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(users))
+	var deprovisioned []string
+	for _, user := range users {
+		found[user.ID.Hex()] = true
+		if user.Status == entities.UserStatusDeprovisioned {
+			deprovisioned = append(deprovisioned, user.ID.Hex())
+		}
+	}
+	var missing []string
+	for _, userID := range userIDs {
+		if !found[userID] {
+			missing = append(missing, userID)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrUsersNotFound, strings.Join(missing, ", "))
+	}
+	if len(deprovisioned) > 0 {
+		return fmt.Errorf("%w: %s", ErrUserDeprovisioned, strings.Join(deprovisioned, ", "))
+	}
+	return nil
+}
+
+// uniqueIDs remove IDs repetidos mantendo a ordem da primeira ocorrência
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,78 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type RemoveGroupMembersUseCase struct {
+	groupRepo repositories.IGroupRepository
+	clock     services.IClock
+}
+
+func NewRemoveGroupMembersUseCase(groupRepo repositories.IGroupRepository, clock services.IClock) *RemoveGroupMembersUseCase {
+	return &RemoveGroupMembersUseCase{groupRepo: groupRepo, clock: clock}
+}
+
+// Execute remove vários usuários do grupo; os que não são membros são ignorados. É preciso poder
+// gerenciar o papel de todos os removidos e o grupo não pode ficar sem owner
+func (uc *RemoveGroupMembersUseCase) Execute(ctx context.Context, groupID string, input *dto.RemoveGroupMembersRequestDTO) (*dto.GroupMembersDiffResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RemoveGroupMembersUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	var roles []entities.GroupRole
+	owners := 0
+	for _, userID := range uniqueIDs(input.UserIDs) {
+		member, ok := group.Member(userID)
+		if !ok {
+			continue
+		}
+		removed = append(removed, userID)
+		roles = append(roles, member.Role)
+		if member.Role == entities.GroupRoleOwner {
+			owners++
+		}
+	}
+	if len(removed) == 0 {
+		return &dto.GroupMembersDiffResponseDTO{Added: []string{}, Removed: removed, Group: mappers.ToGroupResponseDTO(group)}, nil
+	}
+	if err := authorizeMembership(ctx, group, roles...); err != nil {
+		return nil, err
+	}
+	if owners > 0 && owners == group.CountOwners() {
+		return nil, entities.ErrLastOwner
+	}
+
+	err = uc.groupRepo.RemoveMembers(ctx, groupID, removed, uc.clock.Now(), auth.Actor(ctx))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, entities.ErrLastOwner
+	}
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id": groupID,
+		"removed":  len(removed),
+	}).Info("Users removed from group")
+
+	if group, err = uc.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	return &dto.GroupMembersDiffResponseDTO{Added: []string{}, Removed: removed, Group: mappers.ToGroupResponseDTO(group)}, nil
+}
@@ -0,0 +1,42 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RemoveSubgroupUseCase struct {
+	groupRepo repositories.IGroupRepository
+	clock     services.IClock
+}
+
+func NewRemoveSubgroupUseCase(groupRepo repositories.IGroupRepository, clock services.IClock) *RemoveSubgroupUseCase {
+	return &RemoveSubgroupUseCase{groupRepo: groupRepo, clock: clock}
+}
+
+func (uc *RemoveSubgroupUseCase) Execute(ctx context.Context, groupID, subgroupID string) error {
+	ctx, span := tracer.Start(ctx, "RemoveSubgroupUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeMembership(ctx, group, entities.GroupRoleMember); err != nil {
+		return err
+	}
+	if err := uc.groupRepo.RemoveSubgroup(ctx, groupID, subgroupID, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id":    groupID,
+		"subgroup_id": subgroupID,
+	}).Info("Subgroup removed from group")
+	return nil
+}
@@ -2,17 +2,57 @@ package group
 
 import (
 	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type RemoveUserFromGroupUseCase struct {
 	groupRepo repositories.IGroupRepository
+	clock     services.IClock
 }
 
-func NewRemoveUserFromGroupUseCase(groupRepo repositories.IGroupRepository) *RemoveUserFromGroupUseCase {
-	return &RemoveUserFromGroupUseCase{groupRepo: groupRepo}
+func NewRemoveUserFromGroupUseCase(groupRepo repositories.IGroupRepository, clock services.IClock) *RemoveUserFromGroupUseCase {
+	return &RemoveUserFromGroupUseCase{groupRepo: groupRepo, clock: clock}
 }
 
+// Execute remove o usuário do grupo; remover quem não é membro não é um erro
 func (uc *RemoveUserFromGroupUseCase) Execute(ctx context.Context, groupID, userID string) error {
-	return uc.groupRepo.RemoveUserFromGroup(ctx, groupID, userID)
+	ctx, span := tracer.Start(ctx, "RemoveUserFromGroupUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	member, ok := group.Member(userID)
+	if !ok {
+		return nil
+	}
+	if err := authorizeMembership(ctx, group, member.Role); err != nil {
+		return err
+	}
+	if member.Role == entities.GroupRoleOwner && group.CountOwners() == 1 {
+		return entities.ErrLastOwner
+	}
+
+	err = uc.groupRepo.RemoveUserFromGroup(ctx, groupID, userID, uc.clock.Now(), auth.Actor(ctx))
+	// O repositório repete a verificação de forma atômica: outro owner pode ter saído nesse intervalo
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return entities.ErrLastOwner
+	}
+	if err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id": groupID,
+		"user_id":  userID,
+	}).Info("User removed from group")
+	return nil
 }
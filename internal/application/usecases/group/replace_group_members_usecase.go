@@ -0,0 +1,116 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ReplaceGroupMembersUseCase struct {
+	groupRepo repositories.IGroupRepository
+	userRepo  repositories.IUserRepository
+	clock     services.IClock
+}
+
+func NewReplaceGroupMembersUseCase(groupRepo repositories.IGroupRepository, userRepo repositories.IUserRepository, clock services.IClock) *ReplaceGroupMembersUseCase {
+	return &ReplaceGroupMembersUseCase{groupRepo: groupRepo, userRepo: userRepo, clock: clock}
+}
+
+// replaceMembersAttempts é quantas vezes a substituição é refeita quando outra requisição altera o
+// grupo entre a leitura e a gravação
+const replaceMembersAttempts = 3
+
+// Execute torna a lista informada o conjunto de membros do grupo e retorna quem entrou e quem
+// saiu. Quem continua mantém o papel; os novos entram como member. A diferença é calculada sobre o
+// grupo lido e só é gravada se ele não mudou nesse meio tempo; senão é refeita com o grupo atual
+func (uc *ReplaceGroupMembersUseCase) Execute(ctx context.Context, groupID string, input *dto.ReplaceGroupMembersRequestDTO) (*dto.GroupMembersDiffResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ReplaceGroupMembersUseCase.Execute")
+	defer span.End()
+
+	wanted := uniqueIDs(input.UserIDs)
+	for attempt := 1; ; attempt++ {
+		diff, err := uc.replace(ctx, groupID, wanted)
+		if !errors.Is(err, entities.ErrGroupModified) || attempt == replaceMembersAttempts {
+			return diff, err
+		}
+	}
+}
+
+// replace faz uma tentativa da substituição; retorna entities.ErrGroupModified se o grupo mudou
+// depois de lido
+func (uc *ReplaceGroupMembersUseCase) replace(ctx context.Context, groupID string, wanted []string) (*dto.GroupMembersDiffResponseDTO, error) {
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.clock.Now()
+	members := make([]entities.GroupMember, 0, len(wanted))
+	added := []string{}
+	var roles []entities.GroupRole
+	for _, userID := range wanted {
+		if member, ok := group.Member(userID); ok {
+			members = append(members, member)
+			continue
+		}
+		members = append(members, entities.GroupMember{UserID: userID, Role: entities.GroupRoleMember, JoinedAt: now})
+		added = append(added, userID)
+		roles = append(roles, entities.GroupRoleMember)
+	}
+	kept := make(map[string]bool, len(wanted))
+	for _, userID := range wanted {
+		kept[userID] = true
+	}
+	removed := []string{}
+	owners := 0
+	for _, member := range group.Members {
+		if kept[member.UserID] {
+			continue
+		}
+		removed = append(removed, member.UserID)
+		roles = append(roles, member.Role)
+		if member.Role == entities.GroupRoleOwner {
+			owners++
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return &dto.GroupMembersDiffResponseDTO{Added: added, Removed: removed, Group: mappers.ToGroupResponseDTO(group)}, nil
+	}
+
+	if err := authorizeMembership(ctx, group, roles...); err != nil {
+		return nil, err
+	}
+	if owners > 0 && owners == group.CountOwners() {
+		return nil, entities.ErrLastOwner
+	}
+	if err := checkNewMembers(ctx, uc.userRepo, added); err != nil {
+		return nil, err
+	}
+
+	err = uc.groupRepo.ReplaceMembers(ctx, group, members, now, auth.Actor(ctx))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, entities.ErrGroupModified
+	}
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id": groupID,
+		"added":    len(added),
+		"removed":  len(removed),
+	}).Info("Group members replaced")
+
+	if group, err = uc.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	return &dto.GroupMembersDiffResponseDTO{Added: added, Removed: removed, Group: mappers.ToGroupResponseDTO(group)}, nil
+}
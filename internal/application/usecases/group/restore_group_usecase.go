@@ -0,0 +1,39 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type RestoreGroupUseCase struct {
+	repo  repositories.IGroupRepository
+	clock services.IClock
+}
+
+func NewRestoreGroupUseCase(repo repositories.IGroupRepository, clock services.IClock) *RestoreGroupUseCase {
+	return &RestoreGroupUseCase{repo: repo, clock: clock}
+}
+
+// Execute desfaz a remoção de um grupo (apenas administradores)
+func (uc *RestoreGroupUseCase) Execute(ctx context.Context, id string) (*dto.GroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestoreGroupUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Restore(ctx, id, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return nil, err
+	}
+	group, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("group_id", id).Info("Group restored")
+	return mappers.ToGroupResponseDTO(group), nil
+}
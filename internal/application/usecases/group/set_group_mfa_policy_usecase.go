@@ -0,0 +1,45 @@
+package group
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SetGroupMFAPolicyUseCase struct {
+	repo  repositories.IGroupRepository
+	clock services.IClock
+}
+
+func NewSetGroupMFAPolicyUseCase(repo repositories.IGroupRepository, clock services.IClock) *SetGroupMFAPolicyUseCase {
+	return &SetGroupMFAPolicyUseCase{repo: repo, clock: clock}
+}
+
+// Execute liga ou desliga a exigência de MFA para os membros efetivos do grupo (apenas
+// administradores: owners e managers não podem afrouxar a política do próprio grupo)
+func (uc *SetGroupMFAPolicyUseCase) Execute(ctx context.Context, id string, required bool) (*dto.GroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "SetGroupMFAPolicyUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SetRequireMFA(ctx, id, required, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return nil, err
+	}
+	group, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id":    id,
+		"require_mfa": required,
+	}).Info("Group MFA policy updated")
+	return mappers.ToGroupResponseDTO(group), nil
+}
@@ -0,0 +1,5 @@
+package group
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("user-management/internal/application/usecases/group")
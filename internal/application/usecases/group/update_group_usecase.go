@@ -2,35 +2,81 @@ package group
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
 )
 
 type UpdateGroupUseCase struct {
-	repo repositories.IGroupRepository
+	repo  repositories.IGroupRepository
+	clock services.IClock
 }
 
-func NewUpdateGroupUseCase(repo repositories.IGroupRepository) *UpdateGroupUseCase {
-	return &UpdateGroupUseCase{repo: repo}
+func NewUpdateGroupUseCase(repo repositories.IGroupRepository, clock services.IClock) *UpdateGroupUseCase {
+	return &UpdateGroupUseCase{repo: repo, clock: clock}
 }
 
-func (uc *UpdateGroupUseCase) Execute(ctx context.Context, groupID string, groupDTO *dto.CreateGroupRequestDTO) (*dto.GroupResponseDTO, error) {
-	// First get the existing group to preserve members
+// Execute substitui o nome e os membros do grupo. Alterar os membros exige as mesmas permissões
+// de adicioná-los e removê-los individualmente, e o grupo não pode perder o último owner
+func (uc *UpdateGroupUseCase) Execute(ctx context.Context, groupID string, groupDTO *dto.UpdateGroupRequestDTO) (*dto.GroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UpdateGroupUseCase.Execute")
+	defer span.End()
+
 	existingGroup, err := uc.repo.GetByID(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the updated group with existing members
-	group := mappers.ToGroupEntityFromRequest(groupDTO)
-	group.ID = existingGroup.ID
-	group.Members = groupDTO.Members
+	now := uc.clock.Now()
+	group := &entities.Group{
+		ID:        existingGroup.ID,
+		Name:      groupDTO.Name,
+		Subgroups: existingGroup.Subgroups,
+		// A política de MFA só muda por SetGroupMFAPolicyUseCase
+		RequireMFA: existingGroup.RequireMFA,
+		CreatedAt:  existingGroup.CreatedAt,
+		CreatedBy:  existingGroup.CreatedBy,
+		UpdatedAt:  now,
+		UpdatedBy:  auth.Actor(ctx),
+	}
+
+	// Quem continua no grupo mantém o papel e a data de entrada; os demais entram como member
+	var changedRoles []entities.GroupRole
+	for _, userID := range groupDTO.Members {
+		if _, ok := group.Member(userID); ok {
+			continue
+		}
+		if member, ok := existingGroup.Member(userID); ok {
+			group.Members = append(group.Members, member)
+			continue
+		}
+		group.Members = append(group.Members, entities.GroupMember{UserID: userID, Role: entities.GroupRoleMember, JoinedAt: now})
+		changedRoles = append(changedRoles, entities.GroupRoleMember)
+	}
+	for _, member := range existingGroup.Members {
+		if _, ok := group.Member(member.UserID); !ok {
+			changedRoles = append(changedRoles, member.Role)
+		}
+	}
+
+	if len(changedRoles) > 0 {
+		if err := authorizeMembership(ctx, existingGroup, changedRoles...); err != nil {
+			return nil, err
+		}
+	}
+	if existingGroup.CountOwners() > 0 && group.CountOwners() == 0 {
+		return nil, entities.ErrLastOwner
+	}
 
 	err = uc.repo.Update(ctx, group)
 	if err != nil {
 		return nil, err
 	}
+	logger.FromContext(ctx).WithField("group_id", group.ID.Hex()).Info("Group updated")
 
 	return mappers.ToGroupResponseDTO(group), nil
 }
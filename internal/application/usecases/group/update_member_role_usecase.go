@@ -0,0 +1,70 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type UpdateMemberRoleUseCase struct {
+	groupRepo repositories.IGroupRepository
+	clock     services.IClock
+}
+
+func NewUpdateMemberRoleUseCase(groupRepo repositories.IGroupRepository, clock services.IClock) *UpdateMemberRoleUseCase {
+	return &UpdateMemberRoleUseCase{groupRepo: groupRepo, clock: clock}
+}
+
+// Execute altera o papel de um membro do grupo. É preciso poder gerenciar tanto o papel atual
+// quanto o novo, e o último owner não pode ser rebaixado
+func (uc *UpdateMemberRoleUseCase) Execute(ctx context.Context, groupID, userID string, role entities.GroupRole) (*dto.GroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UpdateMemberRoleUseCase.Execute")
+	defer span.End()
+
+	group, err := uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	member, ok := group.Member(userID)
+	if !ok {
+		return nil, ErrMemberNotFound
+	}
+	if err := authorizeMembership(ctx, group, member.Role, role); err != nil {
+		return nil, err
+	}
+	if member.Role == role {
+		return mappers.ToGroupResponseDTO(group), nil
+	}
+	if member.Role == entities.GroupRoleOwner && group.CountOwners() == 1 {
+		return nil, entities.ErrLastOwner
+	}
+
+	err = uc.groupRepo.UpdateMemberRole(ctx, groupID, userID, role, uc.clock.Now(), auth.Actor(ctx))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, entities.ErrLastOwner
+	}
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"group_id": groupID,
+		"user_id":  userID,
+		"from":     member.Role,
+		"to":       role,
+	}).Info("Group member role changed")
+
+	group, err = uc.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToGroupResponseDTO(group), nil
+}
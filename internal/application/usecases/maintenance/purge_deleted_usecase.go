@@ -0,0 +1,63 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PurgeDeletedUseCase struct {
+	userRepo       repositories.IUserRepository
+	groupRepo      repositories.IGroupRepository
+	enrollmentRepo repositories.IMFAEnrollmentRepository
+}
+
+func NewPurgeDeletedUseCase(userRepo repositories.IUserRepository, groupRepo repositories.IGroupRepository, enrollmentRepo repositories.IMFAEnrollmentRepository) *PurgeDeletedUseCase {
+	return &PurgeDeletedUseCase{userRepo: userRepo, groupRepo: groupRepo, enrollmentRepo: enrollmentRepo}
+}
+
+// Execute apaga definitivamente usuários e grupos removidos antes de deletedBefore, em todos os
+// tenants. Os usuários e grupos apagados também são retirados dos grupos, para não deixar
+// referências órfãs, e os cadastros de MFA dos usuários são apagados
+func (uc *PurgeDeletedUseCase) Execute(ctx context.Context, deletedBefore time.Time) (*dto.PurgeResultDTO, error) {
+	ctx, span := tracer.Start(ctx, "PurgeDeletedUseCase.Execute")
+	defer span.End()
+	ctx = tenancy.WithAllTenants(ctx)
+
+	result := &dto.PurgeResultDTO{DeletedBefore: deletedBefore}
+
+	userIDs, err := uc.userRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	result.UsersPurged = len(userIDs)
+	if err := uc.groupRepo.RemoveUsersFromAllGroups(ctx, userIDs); err != nil {
+		return nil, err
+	}
+	if err := uc.enrollmentRepo.DeleteByUserIDs(ctx, userIDs); err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := uc.groupRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	result.GroupsPurged = len(groupIDs)
+	if err := uc.groupRepo.RemoveSubgroupsFromAllGroups(ctx, groupIDs); err != nil {
+		return nil, err
+	}
+
+	if result.UsersPurged > 0 || result.GroupsPurged > 0 {
+		logger.FromContext(ctx).WithFields(logrus.Fields{
+			"users_purged":   result.UsersPurged,
+			"groups_purged":  result.GroupsPurged,
+			"deleted_before": deletedBefore,
+		}).Info("Deleted records purged")
+	}
+	return result, nil
+}
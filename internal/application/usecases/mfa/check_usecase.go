@@ -0,0 +1,48 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type CheckUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	groups      repositories.IGroupRepository
+}
+
+func NewCheckUseCase(enrollments repositories.IMFAEnrollmentRepository, groups repositories.IGroupRepository) *CheckUseCase {
+	return &CheckUseCase{enrollments: enrollments, groups: groups}
+}
+
+// Execute retorna ErrMFARequired se o principal do contexto for um usuário cujo token não foi
+// verificado com o segundo fator, mas que ativou o TOTP ou é membro de um grupo que exige MFA.
+// API keys e a CLI não têm segundo fator e não são afetadas
+func (uc *CheckUseCase) Execute(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "CheckUseCase.Execute")
+	defer span.End()
+
+	if principal, ok := auth.FromContext(ctx); !ok || principal.Type != auth.PrincipalUser || principal.MFA {
+		return nil
+	}
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := findEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return err
+	}
+	if enrollment != nil && enrollment.Enabled() {
+		return ErrMFARequired
+	}
+	policy, err := requiredByPolicy(ctx, uc.groups, principal.ID)
+	if err != nil {
+		return err
+	}
+	if policy {
+		return ErrMFARequired
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ConfirmUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	sessions    repositories.ISessionRepository
+	tokens      auth.TokenIssuer
+	clock       services.IClock
+}
+
+func NewConfirmUseCase(enrollments repositories.IMFAEnrollmentRepository, sessions repositories.ISessionRepository, tokens auth.TokenIssuer, clock services.IClock) *ConfirmUseCase {
+	return &ConfirmUseCase{enrollments: enrollments, sessions: sessions, tokens: tokens, clock: clock}
+}
+
+// Execute ativa o TOTP do usuário autenticado com um código do aplicativo e retorna os códigos de
+// recuperação, que não podem ser consultados depois, e um token já verificado com o segundo fator
+func (uc *ConfirmUseCase) Execute(ctx context.Context, input *dto.MFACodeRequestDTO) (*dto.ConfirmMFAResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := findEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, ErrNoPendingEnrollment
+	}
+	if enrollment.Enabled() {
+		return nil, entities.ErrMFAAlreadyEnabled
+	}
+	now := uc.clock.Now()
+	step, ok := matchCode(enrollment.Secret, input.Code, now)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.enrollments.Enable(ctx, principal.ID, hashes, step, now); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrMFAAlreadyEnabled
+		}
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("user_id", principal.ID).Info("MFA enabled")
+
+	token, err := issueVerified(ctx, uc.sessions, uc.tokens, principal)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConfirmMFAResponseDTO{RecoveryCodes: codes, MFATokenResponseDTO: *token}, nil
+}
+
+// issueVerified emite um novo token do principal marcado como verificado com o segundo fator. Se o
+// token veio de uma sessão de login, a sessão também é marcada, para que os access tokens obtidos
+// com o refresh token continuem verificados
+func issueVerified(ctx context.Context, sessions repositories.ISessionRepository, tokens auth.TokenIssuer, principal auth.Principal) (*dto.MFATokenResponseDTO, error) {
+	if principal.SessionID != "" {
+		if err := sessions.MarkMFA(ctx, principal.SessionID); err != nil {
+			return nil, err
+		}
+	}
+	principal.MFA = true
+	accessToken, expiresAt, err := tokens.Issue(principal)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MFATokenResponseDTO{AccessToken: accessToken, TokenType: "Bearer", ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,52 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type DisableUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	groups      repositories.IGroupRepository
+	clock       services.IClock
+}
+
+func NewDisableUseCase(enrollments repositories.IMFAEnrollmentRepository, groups repositories.IGroupRepository, clock services.IClock) *DisableUseCase {
+	return &DisableUseCase{enrollments: enrollments, groups: groups, clock: clock}
+}
+
+// Execute desativa o TOTP do usuário autenticado mediante um código atual. Membros de grupos que
+// exigem MFA não podem desativá-lo; quem perdeu o aplicativo e os códigos de recuperação depende
+// de um administrador (ResetUseCase)
+func (uc *DisableUseCase) Execute(ctx context.Context, input *dto.MFACodeRequestDTO) error {
+	ctx, span := tracer.Start(ctx, "DisableUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return err
+	}
+	enrollment, err := enabledEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return err
+	}
+	policy, err := requiredByPolicy(ctx, uc.groups, principal.ID)
+	if err != nil {
+		return err
+	}
+	if policy {
+		return ErrRequiredByPolicy
+	}
+	if err := useCode(ctx, uc.enrollments, enrollment, input.Code, uc.clock); err != nil {
+		return err
+	}
+
+	if err := uc.enrollments.Delete(ctx, principal.ID); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("user_id", principal.ID).Info("MFA disabled")
+	return nil
+}
@@ -0,0 +1,56 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type EnrollUseCase struct {
+	issuer      string
+	enrollments repositories.IMFAEnrollmentRepository
+	users       repositories.IUserRepository
+	clock       services.IClock
+}
+
+func NewEnrollUseCase(cfg *config.Config, enrollments repositories.IMFAEnrollmentRepository, users repositories.IUserRepository, clock services.IClock) *EnrollUseCase {
+	return &EnrollUseCase{issuer: cfg.MFAIssuer, enrollments: enrollments, users: users, clock: clock}
+}
+
+// Execute inicia o cadastro de TOTP do usuário autenticado, substituindo um cadastro pendente.
+// O segundo fator só passa a valer depois de confirmado com um código (ConfirmUseCase)
+func (uc *EnrollUseCase) Execute(ctx context.Context) (*dto.EnrollMFAResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "EnrollUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.users.GetByID(ctx, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	enrollment := &entities.MFAEnrollment{
+		UserID:    principal.ID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.enrollments.Create(ctx, enrollment); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithField("user_id", principal.ID).Info("MFA enrollment started")
+	return &dto.EnrollMFAResponseDTO{Secret: secret, OTPAuthURI: otpauthURI(uc.issuer, user.Email, secret)}, nil
+}
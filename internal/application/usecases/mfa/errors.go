@@ -0,0 +1,24 @@
+package mfa
+
+import "errors"
+
+var (
+	// ErrUserRequired é retornado quando as operações de MFA não são feitas por um usuário
+	// autenticado (API keys, a CLI e chamadas com AUTH_ENABLED=false não têm segundo fator)
+	ErrUserRequired = errors.New("multi-factor authentication requires an authenticated user")
+	// ErrMFARequired é retornado quando o usuário precisa de um token verificado com o segundo
+	// fator: ele ativou o TOTP ou pertence a um grupo que o exige
+	ErrMFARequired = errors.New("multi-factor authentication is required")
+	// ErrRequiredByPolicy é retornado ao desativar o TOTP de um membro de grupo que exige MFA
+	ErrRequiredByPolicy = errors.New("multi-factor authentication is required by a group policy")
+	// ErrNotEnabled é retornado ao verificar códigos de um usuário sem TOTP ativado
+	ErrNotEnabled = errors.New("multi-factor authentication is not enabled")
+	// ErrNoPendingEnrollment é retornado ao confirmar um cadastro que não foi iniciado
+	ErrNoPendingEnrollment = errors.New("no pending multi-factor enrollment")
+	// ErrInvalidCode é retornado para códigos TOTP errados ou já usados e códigos de
+	// recuperação inexistentes
+	ErrInvalidCode = errors.New("invalid verification code")
+	// ErrTooManyAttempts é retornado enquanto as verificações do usuário estão bloqueadas por
+	// códigos errados seguidos
+	ErrTooManyAttempts = errors.New("too many failed verification attempts, try again later")
+)
@@ -0,0 +1,47 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type GetStatusUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	groups      repositories.IGroupRepository
+}
+
+func NewGetStatusUseCase(enrollments repositories.IMFAEnrollmentRepository, groups repositories.IGroupRepository) *GetStatusUseCase {
+	return &GetStatusUseCase{enrollments: enrollments, groups: groups}
+}
+
+// Execute informa se o usuário autenticado tem o TOTP ativado e se o segundo fator é exigido dele
+func (uc *GetStatusUseCase) Execute(ctx context.Context) (*dto.MFAStatusResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetStatusUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := findEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := requiredByPolicy(ctx, uc.groups, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.MFAStatusResponseDTO{RequiredByPolicy: policy, Required: policy}
+	if enrollment != nil {
+		status.Enabled = enrollment.Enabled()
+		status.Pending = !enrollment.Enabled()
+		status.Required = policy || enrollment.Enabled()
+		status.EnabledAt = enrollment.EnabledAt
+		if enrollment.Enabled() {
+			status.RecoveryCodesRemaining = len(enrollment.RecoveryCodes)
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,135 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	// groupsPageSize é o tamanho das páginas de grupos efetivos lidas ao avaliar a política
+	groupsPageSize = 100
+	// maxFailedAttempts é quantas tentativas seguidas sem sucesso são aceitas antes do bloqueio
+	maxFailedAttempts = 5
+	lockoutBase       = time.Minute
+	lockoutMax        = time.Hour
+)
+
+// userContext exige um usuário autenticado e retorna o contexto no tenant dele: o segundo fator
+// pertence ao usuário, mesmo quando um administrador da plataforma opera em outro tenant
+func userContext(ctx context.Context) (context.Context, auth.Principal, error) {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Type != auth.PrincipalUser {
+		return ctx, principal, ErrUserRequired
+	}
+	tenantID := principal.TenantID
+	if tenantID == "" {
+		tenantID = tenancy.DefaultTenant
+	}
+	return tenancy.WithTenant(ctx, tenantID), principal, nil
+}
+
+// findEnrollment retorna o cadastro do usuário ou nil se ele nunca iniciou um
+func findEnrollment(ctx context.Context, enrollments repositories.IMFAEnrollmentRepository, userID string) (*entities.MFAEnrollment, error) {
+	enrollment, err := enrollments.GetByUserID(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return enrollment, err
+}
+
+// requiredByPolicy indica se algum dos grupos efetivos do usuário exige MFA
+func requiredByPolicy(ctx context.Context, groups repositories.IGroupRepository, userID string) (bool, error) {
+	for offset := int64(0); ; offset += groupsPageSize {
+		page, total, err := groups.ListEffectiveGroups(ctx, userID, offset, groupsPageSize)
+		if err != nil {
+			return false, err
+		}
+		for _, group := range page {
+			if group.RequireMFA {
+				return true, nil
+			}
+		}
+		if len(page) == 0 || offset+groupsPageSize >= total {
+			return false, nil
+		}
+	}
+}
+
+// useCode valida um código TOTP do cadastro ativado e o registra para que não seja aceito de novo
+func useCode(ctx context.Context, enrollments repositories.IMFAEnrollmentRepository, enrollment *entities.MFAEnrollment, code string, clock services.IClock) error {
+	now := clock.Now()
+	if err := recordAttempt(ctx, enrollments, enrollment, now); err != nil {
+		return err
+	}
+	step, ok := matchCode(enrollment.Secret, code, now)
+	if !ok {
+		return ErrInvalidCode
+	}
+	if err := enrollments.UseStep(ctx, enrollment.UserID, step); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrInvalidCode
+		}
+		return err
+	}
+	return nil
+}
+
+// useRecoveryCode consome um código de recuperação do cadastro ativado
+func useRecoveryCode(ctx context.Context, enrollments repositories.IMFAEnrollmentRepository, enrollment *entities.MFAEnrollment, code string, clock services.IClock) error {
+	now := clock.Now()
+	if err := recordAttempt(ctx, enrollments, enrollment, now); err != nil {
+		return err
+	}
+	err := enrollments.UseRecoveryCode(ctx, enrollment.UserID, hashRecoveryCode(code), now)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrInvalidCode
+	}
+	return err
+}
+
+// recordAttempt conta a tentativa antes de conferir o código, que zera o contador se for aceito.
+// A partir de maxFailedAttempts tentativas seguidas sem sucesso cada uma bloqueia as seguintes,
+// por lockoutBase dobrado a cada nova falha até lockoutMax; ErrTooManyAttempts enquanto durar
+func recordAttempt(ctx context.Context, enrollments repositories.IMFAEnrollmentRepository, enrollment *entities.MFAEnrollment, now time.Time) error {
+	if enrollment.Locked(now) {
+		return ErrTooManyAttempts
+	}
+	var lockedUntil *time.Time
+	if attempts := enrollment.FailedAttempts + 1; attempts >= maxFailedAttempts {
+		lockout := min(lockoutBase<<min(attempts-maxFailedAttempts, 10), lockoutMax)
+		until := now.Add(lockout)
+		lockedUntil = &until
+	}
+	err := enrollments.RecordAttempt(ctx, enrollment.UserID, enrollment.FailedAttempts, lockedUntil, now)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// outra tentativa foi contada ou bloqueou o cadastro depois da leitura
+		return ErrTooManyAttempts
+	}
+	if err == nil && lockedUntil != nil {
+		logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": enrollment.UserID, "locked_until": *lockedUntil}).
+			Warn("MFA verification locked after repeated failures")
+	}
+	return err
+}
+
+// enabledEnrollment retorna o cadastro ativado do usuário ou ErrNotEnabled
+func enabledEnrollment(ctx context.Context, enrollments repositories.IMFAEnrollmentRepository, userID string) (*entities.MFAEnrollment, error) {
+	enrollment, err := findEnrollment(ctx, enrollments, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || !enrollment.Enabled() {
+		return nil, ErrNotEnabled
+	}
+	return enrollment, nil
+}
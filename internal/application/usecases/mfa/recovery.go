@@ -0,0 +1,37 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"user-management/internal/application/secret"
+)
+
+const (
+	// recoveryCodeCount é quantos códigos de recuperação são gerados de cada vez
+	recoveryCodeCount = 10
+	// recoveryCodeSize dá 80 bits por código, o bastante para guardá-los com sha256 sem salt
+	recoveryCodeSize = 10
+)
+
+// generateRecoveryCodes gera os códigos mostrados uma única vez ao usuário e os hashes gravados
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	buf := make([]byte, recoveryCodeSize)
+	for range recoveryCodeCount {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		codes = append(codes, raw[0:4]+"-"+raw[4:8]+"-"+raw[8:12]+"-"+raw[12:16])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode ignora maiúsculas, hifens e espaços, que o usuário pode digitar ou não
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return secret.Hash(normalized)
+}
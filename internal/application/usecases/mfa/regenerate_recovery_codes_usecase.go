@@ -0,0 +1,47 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type RegenerateRecoveryCodesUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	clock       services.IClock
+}
+
+func NewRegenerateRecoveryCodesUseCase(enrollments repositories.IMFAEnrollmentRepository, clock services.IClock) *RegenerateRecoveryCodesUseCase {
+	return &RegenerateRecoveryCodesUseCase{enrollments: enrollments, clock: clock}
+}
+
+// Execute substitui todos os códigos de recuperação do usuário autenticado, mediante um código
+// TOTP atual; os códigos anteriores deixam de valer
+func (uc *RegenerateRecoveryCodesUseCase) Execute(ctx context.Context, input *dto.MFACodeRequestDTO) (*dto.RecoveryCodesResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RegenerateRecoveryCodesUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := enabledEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := useCode(ctx, uc.enrollments, enrollment, input.Code, uc.clock); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.enrollments.ReplaceRecoveryCodes(ctx, principal.ID, hashes, uc.clock.Now()); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("user_id", principal.ID).Info("MFA recovery codes regenerated")
+	return &dto.RecoveryCodesResponseDTO{RecoveryCodes: codes}, nil
+}
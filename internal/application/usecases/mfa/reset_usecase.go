@@ -0,0 +1,33 @@
+package mfa
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
+)
+
+type ResetUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+}
+
+func NewResetUseCase(enrollments repositories.IMFAEnrollmentRepository) *ResetUseCase {
+	return &ResetUseCase{enrollments: enrollments}
+}
+
+// Execute apaga o cadastro de TOTP de um usuário do tenant do contexto, para que ele cadastre um
+// novo aplicativo (apenas administradores). Se um grupo exigir MFA, o usuário só volta a acessar
+// a API depois de cadastrá-lo
+func (uc *ResetUseCase) Execute(ctx context.Context, userID string) error {
+	ctx, span := tracer.Start(ctx, "ResetUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return err
+	}
+	if err := uc.enrollments.Delete(ctx, userID); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("user_id", userID).Info("MFA reset")
+	return nil
+}
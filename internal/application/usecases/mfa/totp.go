@@ -0,0 +1,91 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parâmetros do TOTP (RFC 6238) nos valores padrão dos aplicativos autenticadores: HMAC-SHA1,
+// 6 dígitos e intervalos de 30s. Um intervalo antes e depois do atual também é aceito, para
+// tolerar a diferença entre os relógios
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	totpSkew   = 1
+	// secretSize é o tamanho recomendado pela RFC 4226 para chaves HMAC-SHA1
+	secretSize = 20
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateSecret gera o segredo compartilhado com o aplicativo, em base32 sem padding
+func generateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32NoPadding.EncodeToString(buf), nil
+}
+
+// GenerateCode calcula o código TOTP do segredo no instante at, como faz o aplicativo
+// autenticador
+func GenerateCode(secret string, at time.Time) (string, error) {
+	key, err := base32NoPadding.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, at.Unix()/totpPeriod), nil
+}
+
+// hotp é o HOTP (RFC 4226) do contador step com truncamento dinâmico
+func hotp(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// matchCode procura code nos intervalos aceitos em now e retorna o intervalo correspondente,
+// que o chamador registra para recusar o mesmo código depois
+func matchCode(secret, code string, now time.Time) (int64, bool) {
+	key, err := base32NoPadding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// otpauthURI monta o Key URI lido pelos aplicativos autenticadores; é o conteúdo do QR code
+// exibido no cadastro
+func otpauthURI(issuer, account, secret string) string {
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {strconv.Itoa(totpDigits)},
+		"period":    {strconv.Itoa(totpPeriod)},
+	}
+	// Os aplicativos esperam %20, não +, para espaços
+	return "otpauth://totp/" + url.PathEscape(issuer) + ":" + url.PathEscape(account) + "?" +
+		strings.ReplaceAll(query.Encode(), "+", "%20")
+}
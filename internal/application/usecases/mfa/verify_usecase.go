@@ -0,0 +1,62 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type VerifyUseCase struct {
+	enrollments repositories.IMFAEnrollmentRepository
+	sessions    repositories.ISessionRepository
+	tokens      auth.TokenIssuer
+	clock       services.IClock
+}
+
+func NewVerifyUseCase(enrollments repositories.IMFAEnrollmentRepository, sessions repositories.ISessionRepository, tokens auth.TokenIssuer, clock services.IClock) *VerifyUseCase {
+	return &VerifyUseCase{enrollments: enrollments, sessions: sessions, tokens: tokens, clock: clock}
+}
+
+// Execute é o segundo passo do login: troca o token do usuário autenticado, junto com um código
+// TOTP ou um código de recuperação (que deixa de valer), por um token verificado com o segundo
+// fator, aceito nas rotas que exigem MFA
+func (uc *VerifyUseCase) Execute(ctx context.Context, input *dto.VerifyMFARequestDTO) (*dto.MFATokenResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "VerifyUseCase.Execute")
+	defer span.End()
+
+	ctx, principal, err := userContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := enabledEnrollment(ctx, uc.enrollments, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	method := "totp"
+	if input.Code != "" {
+		err = useCode(ctx, uc.enrollments, enrollment, input.Code, uc.clock)
+	} else {
+		method = "recovery_code"
+		err = useRecoveryCode(ctx, uc.enrollments, enrollment, input.RecoveryCode, uc.clock)
+	}
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": principal.ID, "method": method})
+	if err != nil {
+		if errors.Is(err, ErrInvalidCode) || errors.Is(err, ErrTooManyAttempts) {
+			log.Warn("MFA verification failed")
+		}
+		return nil, err
+	}
+	if method == "recovery_code" {
+		log = log.WithField("recovery_codes_remaining", len(enrollment.RecoveryCodes)-1)
+	}
+	log.Info("MFA verified")
+
+	return issueVerified(ctx, uc.sessions, uc.tokens, principal)
+}
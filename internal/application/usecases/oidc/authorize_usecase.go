@@ -0,0 +1,135 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// pkcePattern é o formato de code_challenge e code_verifier (RFC 7636): 43 a 128 caracteres
+// não reservados
+var pkcePattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+type AuthorizeUseCase struct {
+	clients repositories.IOAuthClientRepository
+	codes   repositories.IAuthorizationCodeRepository
+	users   repositories.IUserRepository
+	codeTTL time.Duration
+	clock   services.IClock
+}
+
+func NewAuthorizeUseCase(cfg *config.Config, clients repositories.IOAuthClientRepository, codes repositories.IAuthorizationCodeRepository, users repositories.IUserRepository, clock services.IClock) *AuthorizeUseCase {
+	return &AuthorizeUseCase{clients: clients, codes: codes, users: users, codeTTL: cfg.OIDCCodeTTL, clock: clock}
+}
+
+// Execute emite um código de autorização para o usuário autenticado do contexto e retorna a
+// redirect_uri do cliente com code e state. Só o fluxo authorization code com PKCE (S256) é
+// aceito. Enquanto o cliente e a redirect_uri não forem confirmados os erros são retornados
+// (ErrInvalidClient, ErrInvalidRedirectURI); depois disso vão para a redirect_uri com error e
+// error_description, como manda o RFC 6749
+func (uc *AuthorizeUseCase) Execute(ctx context.Context, input *dto.AuthorizeRequestDTO) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthorizeUseCase.Execute")
+	defer span.End()
+
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.Type != auth.PrincipalUser {
+		return "", ErrUserRequired
+	}
+	client, err := uc.clients.GetByClientID(ctx, input.ClientID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", ErrInvalidClient
+	}
+	if err != nil {
+		return "", err
+	}
+	if !client.AllowsRedirect(input.RedirectURI) {
+		return "", ErrInvalidRedirectURI
+	}
+	redirect := func(params url.Values) (string, error) {
+		target, err := url.Parse(input.RedirectURI)
+		if err != nil {
+			return "", err
+		}
+		query := target.Query()
+		for name, values := range params {
+			query[name] = values
+		}
+		if input.State != "" {
+			query.Set("state", input.State)
+		}
+		target.RawQuery = query.Encode()
+		return target.String(), nil
+	}
+	fail := func(oauthErr *Error) (string, error) {
+		logger.FromContext(ctx).WithFields(logrus.Fields{
+			"client_id": client.ClientID,
+			"error":     oauthErr.Code,
+		}).Info("Authorization request rejected")
+		return redirect(url.Values{"error": {oauthErr.Code}, "error_description": {oauthErr.Description}})
+	}
+
+	if input.ResponseType != "code" {
+		return fail(oauthError(ErrorUnsupportedResponseType, "only response_type=code is supported"))
+	}
+	scopes := parseScopes(input.Scope)
+	if !slices.Contains(scopes, ScopeOpenID) {
+		return fail(oauthError(ErrorInvalidScope, "the openid scope is required"))
+	}
+	if input.CodeChallengeMethod != "S256" {
+		return fail(oauthError(ErrorInvalidRequest, "code_challenge_method must be S256"))
+	}
+	if !pkcePattern.MatchString(input.CodeChallenge) {
+		return fail(oauthError(ErrorInvalidRequest, "code_challenge is required"))
+	}
+
+	user, err := uc.users.GetByID(ctx, principal.ID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+		return fail(oauthError(ErrorAccessDenied, "user not found"))
+	}
+	if err != nil {
+		return "", err
+	}
+	if user.Status != entities.UserStatusActive {
+		return fail(oauthError(ErrorAccessDenied, "user is not active"))
+	}
+
+	code, err := secret.Random(32)
+	if err != nil {
+		return "", err
+	}
+	now := uc.clock.Now()
+	if err := uc.codes.Create(ctx, &entities.AuthorizationCode{
+		CodeHash:      secret.Hash(code),
+		ClientID:      client.ClientID,
+		UserID:        user.ID.Hex(),
+		RedirectURI:   input.RedirectURI,
+		Scopes:        scopes,
+		Nonce:         input.Nonce,
+		CodeChallenge: input.CodeChallenge,
+		ExpiresAt:     now.Add(uc.codeTTL),
+		CreatedAt:     now,
+	}); err != nil {
+		return "", err
+	}
+
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"client_id": client.ClientID,
+		"user_id":   user.ID.Hex(),
+	}).Info("Authorization code issued")
+	return redirect(url.Values{"code": {code}})
+}
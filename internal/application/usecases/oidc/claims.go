@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+// Escopos do provedor: openid é obrigatório; os demais liberam as claims correspondentes
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopeGroups  = "groups"
+)
+
+// Scopes lista os escopos suportados, na ordem da discovery
+var Scopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopeGroups}
+
+// Claims lista as claims que o provedor emite, na ordem da discovery
+var Claims = []string{"sub", "iss", "aud", "exp", "iat", "nonce", "azp", "tenant", "name", "email", "groups"}
+
+// groupsPageSize é o tamanho das páginas lidas ao montar a claim groups
+const groupsPageSize = 100
+
+// parseScopes separa o parâmetro scope, descartando repetições e escopos desconhecidos, que o
+// OpenID Connect manda ignorar
+func parseScopes(scope string) []string {
+	var scopes []string
+	for _, s := range strings.Fields(scope) {
+		if slices.Contains(Scopes, s) && !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// userInfo monta as claims do usuário liberadas pelos escopos. A claim groups traz os nomes dos
+// grupos efetivos do usuário (diretos e herdados pelo aninhamento), ordenados pelo nome
+func userInfo(ctx context.Context, groups repositories.IGroupRepository, user *entities.User, scopes []string) (*dto.UserInfoResponseDTO, error) {
+	info := &dto.UserInfoResponseDTO{Subject: user.ID.Hex(), Tenant: user.TenantID}
+	if slices.Contains(scopes, ScopeProfile) {
+		info.Name = user.Name
+	}
+	if slices.Contains(scopes, ScopeEmail) {
+		info.Email = user.Email
+	}
+	if slices.Contains(scopes, ScopeGroups) {
+		info.Groups = []string{}
+		for offset := int64(0); ; offset += groupsPageSize {
+			page, total, err := groups.ListEffectiveGroups(ctx, user.ID.Hex(), offset, groupsPageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range page {
+				info.Groups = append(info.Groups, group.Name)
+			}
+			if len(page) == 0 || offset+groupsPageSize >= total {
+				break
+			}
+		}
+	}
+	return info, nil
+}
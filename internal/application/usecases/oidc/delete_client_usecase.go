@@ -0,0 +1,32 @@
+package oidc
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
+)
+
+type DeleteClientUseCase struct {
+	repo repositories.IOAuthClientRepository
+}
+
+func NewDeleteClientUseCase(repo repositories.IOAuthClientRepository) *DeleteClientUseCase {
+	return &DeleteClientUseCase{repo: repo}
+}
+
+// Execute remove o cliente: novas autorizações e trocas de código passam a ser recusadas, mas os
+// tokens já emitidos valem até expirar
+func (uc *DeleteClientUseCase) Execute(ctx context.Context, clientID string) error {
+	ctx, span := tracer.Start(ctx, "DeleteClientUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, clientID); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("client_id", clientID).Info("OAuth client deleted")
+	return nil
+}
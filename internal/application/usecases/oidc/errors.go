@@ -0,0 +1,44 @@
+package oidc
+
+import "errors"
+
+var (
+	// ErrUserRequired é retornado quando a autorização não é pedida por um usuário autenticado
+	// (API keys, a CLI e chamadas com AUTH_ENABLED=false não representam um usuário)
+	ErrUserRequired = errors.New("authorization requires an authenticated user")
+	// ErrInvalidClient é retornado pelo endpoint de autorização para client_id desconhecidos;
+	// como a redirect_uri não pode ser confirmada, o erro não é enviado ao cliente
+	ErrInvalidClient = errors.New("unknown client_id")
+	// ErrInvalidRedirectURI é retornado pelo endpoint de autorização para redirect_uri não
+	// registradas para o cliente
+	ErrInvalidRedirectURI = errors.New("redirect_uri is not registered for this client")
+	// ErrMalformedRedirectURI é retornado ao registrar redirect_uris relativas ou com fragmento
+	ErrMalformedRedirectURI = errors.New("redirect_uris must be absolute URLs without fragment")
+)
+
+// Códigos de erro do OAuth 2.0 (RFC 6749, RFC 6750) usados pelo provedor
+const (
+	ErrorInvalidRequest          = "invalid_request"
+	ErrorInvalidClient           = "invalid_client"
+	ErrorInvalidGrant            = "invalid_grant"
+	ErrorInvalidScope            = "invalid_scope"
+	ErrorInvalidToken            = "invalid_token"
+	ErrorAccessDenied            = "access_denied"
+	ErrorUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorUnsupportedResponseType = "unsupported_response_type"
+)
+
+// Error é um erro do protocolo OAuth 2.0, devolvido ao cliente com o código padronizado em
+// error e o detalhe em error_description
+type Error struct {
+	Code        string
+	Description string
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func oauthError(code, description string) *Error {
+	return &Error{Code: code, Description: description}
+}
@@ -0,0 +1,164 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ExchangeCodeUseCase struct {
+	clients  repositories.IOAuthClientRepository
+	codes    repositories.IAuthorizationCodeRepository
+	users    repositories.IUserRepository
+	groups   repositories.IGroupRepository
+	keys     services.ISigningKeys
+	issuer   string
+	tokenTTL time.Duration
+	clock    services.IClock
+}
+
+func NewExchangeCodeUseCase(cfg *config.Config, clients repositories.IOAuthClientRepository, codes repositories.IAuthorizationCodeRepository, users repositories.IUserRepository, groups repositories.IGroupRepository, keys services.ISigningKeys, clock services.IClock) *ExchangeCodeUseCase {
+	return &ExchangeCodeUseCase{
+		clients:  clients,
+		codes:    codes,
+		users:    users,
+		groups:   groups,
+		keys:     keys,
+		issuer:   cfg.OIDCIssuer,
+		tokenTTL: cfg.OIDCTokenTTL,
+		clock:    clock,
+	}
+}
+
+// Execute troca um código de autorização por um access token e um ID token. O código é apagado
+// na primeira tentativa, mesmo que ela falhe, e define o tenant da troca. Clientes confidenciais
+// precisam do segredo; todos precisam do code_verifier correspondente ao code_challenge.
+// Os erros do protocolo são retornados como *Error
+func (uc *ExchangeCodeUseCase) Execute(ctx context.Context, input *dto.TokenRequestDTO) (*dto.TokenResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ExchangeCodeUseCase.Execute")
+	defer span.End()
+
+	if input.GrantType != "authorization_code" {
+		return nil, oauthError(ErrorUnsupportedGrantType, "only grant_type=authorization_code is supported")
+	}
+	if input.Code == "" || input.ClientID == "" {
+		return nil, oauthError(ErrorInvalidRequest, "code and client_id are required")
+	}
+	code, err := uc.codes.Consume(ctx, secret.Hash(input.Code))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, oauthError(ErrorInvalidGrant, "invalid or already used authorization code")
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenancy.WithTenant(ctx, code.TenantID)
+	ctx = logger.WithEntry(ctx, logger.FromContext(ctx).WithFields(logrus.Fields{"tenant": code.TenantID, "client_id": input.ClientID}))
+
+	client, err := uc.clients.GetByClientID(ctx, input.ClientID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, oauthError(ErrorInvalidClient, "unknown client")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !client.Public() && subtle.ConstantTimeCompare([]byte(secret.Hash(input.ClientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError(ErrorInvalidClient, "invalid client credentials")
+	}
+
+	now := uc.clock.Now()
+	switch {
+	case code.ClientID != client.ClientID:
+		return nil, oauthError(ErrorInvalidGrant, "authorization code was issued to another client")
+	case code.Expired(now):
+		return nil, oauthError(ErrorInvalidGrant, "authorization code has expired")
+	case input.RedirectURI != code.RedirectURI:
+		return nil, oauthError(ErrorInvalidGrant, "redirect_uri does not match the authorization request")
+	case !pkcePattern.MatchString(input.CodeVerifier) ||
+		subtle.ConstantTimeCompare([]byte(s256Challenge(input.CodeVerifier)), []byte(code.CodeChallenge)) != 1:
+		return nil, oauthError(ErrorInvalidGrant, "code_verifier does not match the code_challenge")
+	}
+
+	user, err := uc.users.GetByID(ctx, code.UserID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+		return nil, oauthError(ErrorInvalidGrant, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != entities.UserStatusActive {
+		return nil, oauthError(ErrorInvalidGrant, "user is not active")
+	}
+
+	info, err := userInfo(ctx, uc.groups, user, code.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	jti, err := secret.Random(16)
+	if err != nil {
+		return nil, err
+	}
+	scope := strings.Join(code.Scopes, " ")
+	expiresAt := now.Add(uc.tokenTTL)
+
+	// O access token tem o próprio emissor como audiência e só é aceito pelo userinfo
+	accessToken, err := uc.keys.Sign(ctx, map[string]interface{}{
+		"sub":       info.Subject,
+		"aud":       uc.issuer,
+		"client_id": client.ClientID,
+		"scope":     scope,
+		"tenant":    info.Tenant,
+		"jti":       jti,
+		"iat":       now.Unix(),
+		"exp":       expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	idClaims := map[string]interface{}{
+		"sub":    info.Subject,
+		"aud":    client.ClientID,
+		"azp":    client.ClientID,
+		"tenant": info.Tenant,
+		"iat":    now.Unix(),
+		"exp":    expiresAt.Unix(),
+	}
+	if code.Nonce != "" {
+		idClaims["nonce"] = code.Nonce
+	}
+	if info.Name != "" {
+		idClaims["name"] = info.Name
+	}
+	if info.Email != "" {
+		idClaims["email"] = info.Email
+	}
+	if info.Groups != nil {
+		idClaims["groups"] = info.Groups
+	}
+	idToken, err := uc.keys.Sign(ctx, idClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithField("user_id", info.Subject).Info("Authorization code exchanged for tokens")
+	return &dto.TokenResponseDTO{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(uc.tokenTTL / time.Second),
+		IDToken:     idToken,
+		Scope:       scope,
+	}, nil
+}
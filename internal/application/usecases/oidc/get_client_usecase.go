@@ -0,0 +1,31 @@
+package oidc
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type GetClientUseCase struct {
+	repo repositories.IOAuthClientRepository
+}
+
+func NewGetClientUseCase(repo repositories.IOAuthClientRepository) *GetClientUseCase {
+	return &GetClientUseCase{repo: repo}
+}
+
+func (uc *GetClientUseCase) Execute(ctx context.Context, clientID string) (*dto.OAuthClientResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetClientUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	client, err := uc.repo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToOAuthClientResponseDTO(client), nil
+}
@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListClientsUseCase struct {
+	repo repositories.IOAuthClientRepository
+}
+
+func NewListClientsUseCase(repo repositories.IOAuthClientRepository) *ListClientsUseCase {
+	return &ListClientsUseCase{repo: repo}
+}
+
+// Execute lista os clientes do tenant, dos mais novos para os mais antigos; input.Page já vem
+// baseado em 0
+func (uc *ListClientsUseCase) Execute(ctx context.Context, input *dto.PageQueryParam) (*dto.ListOAuthClientResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListClientsUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	clients, err := uc.repo.List(ctx, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.repo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToListOAuthClientResponseDTO(clients, total, input.Page, input.PerPage), nil
+}
@@ -0,0 +1,80 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/application/secret"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type RegisterClientUseCase struct {
+	repo  repositories.IOAuthClientRepository
+	clock services.IClock
+}
+
+func NewRegisterClientUseCase(repo repositories.IOAuthClientRepository, clock services.IClock) *RegisterClientUseCase {
+	return &RegisterClientUseCase{repo: repo, clock: clock}
+}
+
+// Execute registra um cliente no tenant do contexto e, para clientes confidenciais, retorna o
+// segredo, que não pode ser consultado depois. Exige um administrador
+func (uc *RegisterClientUseCase) Execute(ctx context.Context, input *dto.CreateOAuthClientRequestDTO) (*dto.CreateOAuthClientResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RegisterClientUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	redirectURIs := make([]string, 0, len(input.RedirectURIs))
+	for _, uri := range input.RedirectURIs {
+		// O RFC 6749 proíbe fragmentos, pois o código é acrescentado à query
+		if parsed, err := url.Parse(uri); err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+			return nil, ErrMalformedRedirectURI
+		}
+		if !slices.Contains(redirectURIs, uri) {
+			redirectURIs = append(redirectURIs, uri)
+		}
+	}
+
+	clientID, err := secret.Random(16)
+	if err != nil {
+		return nil, err
+	}
+	var clientSecret string
+	if !input.Public {
+		if clientSecret, err = secret.Random(32); err != nil {
+			return nil, err
+		}
+	}
+	now := uc.clock.Now()
+	client := &entities.OAuthClient{
+		ClientID:     clientID,
+		Name:         input.Name,
+		RedirectURIs: redirectURIs,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		CreatedBy:    auth.Actor(ctx),
+		UpdatedBy:    auth.Actor(ctx),
+	}
+	if clientSecret != "" {
+		client.SecretHash = secret.Hash(clientSecret)
+	}
+	if err := uc.repo.Create(ctx, client); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"client_id": clientID,
+		"public":    input.Public,
+	}).Info("OAuth client registered")
+	return &dto.CreateOAuthClientResponseDTO{OAuthClientResponseDTO: *mappers.ToOAuthClientResponseDTO(client), ClientSecret: clientSecret}, nil
+}
@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type UserInfoUseCase struct {
+	users  repositories.IUserRepository
+	groups repositories.IGroupRepository
+	keys   services.ISigningKeys
+	issuer string
+}
+
+func NewUserInfoUseCase(cfg *config.Config, users repositories.IUserRepository, groups repositories.IGroupRepository, keys services.ISigningKeys) *UserInfoUseCase {
+	return &UserInfoUseCase{users: users, groups: groups, keys: keys, issuer: cfg.OIDCIssuer}
+}
+
+// Execute valida um access token emitido pelo provedor e retorna as claims atuais do usuário,
+// limitadas aos escopos do token. Tokens inválidos e usuários removidos ou inativos retornam um
+// *Error invalid_token
+func (uc *UserInfoUseCase) Execute(ctx context.Context, accessToken string) (*dto.UserInfoResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UserInfoUseCase.Execute")
+	defer span.End()
+
+	claims, err := uc.keys.Verify(ctx, accessToken, uc.issuer)
+	if errors.Is(err, services.ErrInvalidToken) {
+		return nil, oauthError(ErrorInvalidToken, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	subject, _ := claims["sub"].(string)
+	tenantID, _ := claims["tenant"].(string)
+	scope, _ := claims["scope"].(string)
+	scopes := parseScopes(scope)
+	if subject == "" || tenantID == "" || len(scopes) == 0 {
+		return nil, oauthError(ErrorInvalidToken, "token is not an access token")
+	}
+
+	ctx = tenancy.WithTenant(ctx, tenantID)
+	user, err := uc.users.GetByID(ctx, subject)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+		return nil, oauthError(ErrorInvalidToken, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != entities.UserStatusActive {
+		return nil, oauthError(ErrorInvalidToken, "user is not active")
+	}
+	return userInfo(ctx, uc.groups, user, scopes)
+}
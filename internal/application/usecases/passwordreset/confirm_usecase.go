@@ -0,0 +1,100 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ConfirmUseCase struct {
+	users    repositories.IUserRepository
+	resets   repositories.IPasswordResetRepository
+	sessions repositories.ISessionRepository
+	audit    repositories.IAuditEventRepository
+	hasher   services.IPasswordHasher
+	clock    services.IClock
+}
+
+func NewConfirmUseCase(users repositories.IUserRepository, resets repositories.IPasswordResetRepository, sessions repositories.ISessionRepository, audit repositories.IAuditEventRepository, hasher services.IPasswordHasher, clock services.IClock) *ConfirmUseCase {
+	return &ConfirmUseCase{users: users, resets: resets, sessions: sessions, audit: audit, hasher: hasher, clock: clock}
+}
+
+// Execute consome o token e grava a nova senha. As sessões do usuário são revogadas (os access
+// e refresh tokens emitidos antes deixam de valer), os demais links de redefinição são invalidados e a
+// operação é registrada no log de auditoria. Não exige autenticação: o token é a credencial, e o
+// tenant é o do pedido
+func (uc *ConfirmUseCase) Execute(ctx context.Context, input *dto.ConfirmPasswordResetRequestDTO, client dto.ClientInfo) error {
+	ctx, span := tracer.Start(ctx, "ConfirmUseCase.Execute")
+	defer span.End()
+
+	reset, err := uc.resets.GetByHash(ctx, secret.Hash(input.Token))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	now := uc.clock.Now()
+	if !reset.Usable(now) {
+		return ErrInvalidToken
+	}
+
+	ctx = tenancy.WithTenant(ctx, reset.TenantID)
+	user, err := uc.users.GetByID(ctx, reset.UserID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != entities.UserStatusActive {
+		return ErrInvalidToken
+	}
+
+	// O hash é calculado antes de consumir o token para que uma senha recusada não o invalide
+	passwordHash, err := uc.hasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+	if err := uc.resets.Use(ctx, reset.ID.Hex(), now); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+
+	user.PasswordHash = passwordHash
+	user.SessionsRevokedAt = &now
+	user.UpdatedAt = now
+	user.UpdatedBy = reset.UserID
+	if err := uc.users.UpdatePassword(ctx, user); err != nil {
+		return err
+	}
+	if _, err := uc.sessions.RevokeAll(ctx, reset.UserID, now); err != nil {
+		return err
+	}
+	if err := uc.resets.DeleteUnused(ctx, reset.UserID); err != nil {
+		return err
+	}
+	if err := uc.audit.Create(ctx, &entities.AuditEvent{
+		Action:    entities.AuditPasswordReset,
+		ActorID:   reset.UserID,
+		UserID:    reset.UserID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).WithField("user_id", reset.UserID).Info("Password reset")
+	return nil
+}
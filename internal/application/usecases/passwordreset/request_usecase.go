@@ -0,0 +1,89 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type RequestUseCase struct {
+	cfg    *config.Config
+	users  repositories.IUserRepository
+	resets repositories.IPasswordResetRepository
+	mailer services.IMailer
+	clock  services.IClock
+}
+
+func NewRequestUseCase(cfg *config.Config, users repositories.IUserRepository, resets repositories.IPasswordResetRepository, mailer services.IMailer, clock services.IClock) *RequestUseCase {
+	return &RequestUseCase{cfg: cfg, users: users, resets: resets, mailer: mailer, clock: clock}
+}
+
+// Execute envia o link de redefinição ao usuário ativo com o email informado, no tenant do
+// contexto. Emails desconhecidos, usuários que não estão ativos e pedidos acima de
+// PASSWORD_RESET_MAX_REQUESTS dentro de PASSWORD_RESET_TTL são ignorados sem erro, para que quem
+// chama não consiga descobrir quais emails estão cadastrados
+func (uc *RequestUseCase) Execute(ctx context.Context, input *dto.PasswordResetRequestDTO) error {
+	ctx, span := tracer.Start(ctx, "RequestUseCase.Execute")
+	defer span.End()
+
+	user, err := uc.users.GetByEmail(ctx, input.Email)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		logger.FromContext(ctx).Debug("Password reset requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log := logger.FromContext(ctx).WithField("user_id", user.ID.Hex())
+	if user.Status != entities.UserStatusActive {
+		log.Debug("Password reset requested for inactive user")
+		return nil
+	}
+
+	now := uc.clock.Now()
+	count, err := uc.resets.CountSince(ctx, user.ID.Hex(), now.Add(-uc.cfg.PasswordResetTTL))
+	if err != nil {
+		return err
+	}
+	if count >= int64(uc.cfg.PasswordResetMaxRequests) {
+		log.Warn("Password reset rate limit reached")
+		return nil
+	}
+
+	token, err := secret.Random(tokenSize)
+	if err != nil {
+		return err
+	}
+	reset := &entities.PasswordReset{
+		UserID:    user.ID.Hex(),
+		TokenHash: secret.Hash(token),
+		ExpiresAt: now.Add(uc.cfg.PasswordResetTTL),
+		CreatedAt: now,
+	}
+	if err := uc.resets.Create(ctx, reset); err != nil {
+		return err
+	}
+
+	link := strings.ReplaceAll(uc.cfg.PasswordResetURL, "{token}", token)
+	if err := uc.mailer.Send(ctx, services.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new one:\n\n%s\n\nThe link can be used once and expires on %s. If you did not ask for a new password, you can ignore this email.\n",
+			user.Name, link, reset.ExpiresAt.Format("Jan 2, 2006 15:04 MST")),
+	}); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	log.Info("Password reset requested")
+	return nil
+}
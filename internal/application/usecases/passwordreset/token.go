@@ -0,0 +1,10 @@
+package passwordreset
+
+import "errors"
+
+// ErrInvalidToken é o único erro da confirmação para tokens desconhecidos, expirados, já usados ou
+// de usuários que não estão mais ativos, para não revelar qual é o caso
+var ErrInvalidToken = errors.New("invalid or expired password reset token")
+
+// tokenSize é o tamanho do token enviado no link
+const tokenSize = 32
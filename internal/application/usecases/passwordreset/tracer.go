@@ -0,0 +1,5 @@
+package passwordreset
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("user-management/internal/application/usecases/passwordreset")
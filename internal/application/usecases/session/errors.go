@@ -0,0 +1,16 @@
+package session
+
+import "errors"
+
+var (
+	// ErrSessionRevoked é retornado para access tokens de sessões revogadas ou expiradas e para os
+	// emitidos antes da revogação das sessões do usuário
+	ErrSessionRevoked = errors.New("session has been revoked")
+	// ErrInvalidCredentials é o único erro do login para emails desconhecidos, senhas erradas e
+	// usuários sem senha ou que não estão ativos, para não revelar qual é o caso
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrInvalidRefreshToken é retornado para refresh tokens desconhecidos, já usados ou de sessões
+	// que não valem mais
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
+)
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+)
+
+type ListUseCase struct {
+	users    repositories.IUserRepository
+	sessions repositories.ISessionRepository
+	clock    services.IClock
+}
+
+func NewListUseCase(users repositories.IUserRepository, sessions repositories.ISessionRepository, clock services.IClock) *ListUseCase {
+	return &ListUseCase{users: users, sessions: sessions, clock: clock}
+}
+
+// Execute lista as sessões ativas do usuário (o próprio usuário ou um administrador), marcando a
+// sessão do token da requisição
+func (uc *ListUseCase) Execute(ctx context.Context, userID string) (*dto.ListSessionsResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireSelfOrAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	sessions, err := uc.sessions.ListActive(ctx, userID, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	principal, _ := auth.FromContext(ctx)
+	return mappers.ToListSessionsResponseDTO(sessions, principal.SessionID), nil
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type LoginUseCase struct {
+	users         repositories.IUserRepository
+	sessions      repositories.ISessionRepository
+	refreshTokens repositories.IRefreshTokenRepository
+	hasher        services.IPasswordHasher
+	tokens        auth.TokenIssuer
+	sessionTTL    time.Duration
+	clock         services.IClock
+
+	// dummyHash é conferido quando o usuário não existe ou não tem senha, para que o tempo de
+	// resposta não revele quais emails estão cadastrados
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+func NewLoginUseCase(cfg *config.Config, users repositories.IUserRepository, sessions repositories.ISessionRepository, refreshTokens repositories.IRefreshTokenRepository, hasher services.IPasswordHasher, tokens auth.TokenIssuer, clock services.IClock) *LoginUseCase {
+	return &LoginUseCase{
+		users:         users,
+		sessions:      sessions,
+		refreshTokens: refreshTokens,
+		hasher:        hasher,
+		tokens:        tokens,
+		sessionTTL:    cfg.SessionTTL,
+		clock:         clock,
+	}
+}
+
+// Execute confere o email e a senha de um usuário ativo do tenant do contexto e abre uma sessão,
+// que dura até SESSION_TTL. Usuários que exigem o segundo fator ainda precisam trocar o access
+// token em /auth/mfa/verify; a confirmação fica registrada na sessão
+func (uc *LoginUseCase) Execute(ctx context.Context, input *dto.LoginRequestDTO, client dto.ClientInfo) (*dto.SessionTokenResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "LoginUseCase.Execute")
+	defer span.End()
+
+	user, err := uc.users.GetByEmail(ctx, input.Email)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if user == nil || user.PasswordHash == "" {
+		uc.dummyOnce.Do(func() {
+			uc.dummyHash, _ = uc.hasher.Hash("dummy password for missing users")
+		})
+		_ = uc.hasher.Compare(uc.dummyHash, input.Password)
+		return nil, ErrInvalidCredentials
+	}
+
+	log := logger.FromContext(ctx).WithField("user_id", user.ID.Hex())
+	if err := uc.hasher.Compare(user.PasswordHash, input.Password); err != nil {
+		if errors.Is(err, services.ErrPasswordMismatch) {
+			log.Warn("Login failed: wrong password")
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if user.Status != entities.UserStatusActive {
+		log.WithField("status", user.Status).Warn("Login failed: user is not active")
+		return nil, ErrInvalidCredentials
+	}
+
+	now := uc.clock.Now()
+	session := &entities.Session{
+		UserID:     user.ID.Hex(),
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(uc.sessionTTL),
+	}
+	if err := uc.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	response, err := issueTokens(ctx, uc.tokens, uc.refreshTokens, session, now)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("session_id", response.SessionID).Info("User logged in")
+	return response, nil
+}
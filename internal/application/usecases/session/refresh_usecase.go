@@ -0,0 +1,100 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type RefreshUseCase struct {
+	users         repositories.IUserRepository
+	sessions      repositories.ISessionRepository
+	refreshTokens repositories.IRefreshTokenRepository
+	tokens        auth.TokenIssuer
+	clock         services.IClock
+}
+
+func NewRefreshUseCase(users repositories.IUserRepository, sessions repositories.ISessionRepository, refreshTokens repositories.IRefreshTokenRepository, tokens auth.TokenIssuer, clock services.IClock) *RefreshUseCase {
+	return &RefreshUseCase{users: users, sessions: sessions, refreshTokens: refreshTokens, tokens: tokens, clock: clock}
+}
+
+// Execute consome o refresh token e devolve um novo par de tokens da mesma sessão (rotação). Um
+// refresh token já usado indica que ele vazou: a sessão inteira é revogada, derrubando tanto quem
+// o roubou quanto o cliente legítimo. Não exige autenticação: o token é a credencial, e o tenant é
+// o da sessão
+func (uc *RefreshUseCase) Execute(ctx context.Context, input *dto.RefreshSessionRequestDTO, client dto.ClientInfo) (*dto.SessionTokenResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RefreshUseCase.Execute")
+	defer span.End()
+
+	token, err := uc.refreshTokens.GetByHash(ctx, secret.Hash(input.RefreshToken))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenancy.WithTenant(ctx, token.TenantID)
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": token.UserID, "session_id": token.SessionID})
+
+	now := uc.clock.Now()
+	if token.UsedAt != nil {
+		return nil, uc.revokeReused(ctx, log, token)
+	}
+	session, err := uc.sessions.GetByID(ctx, token.SessionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !session.Active(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	user, err := uc.users.GetByID(ctx, session.UserID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if user == nil || user.Status != entities.UserStatusActive {
+		log.Warn("Session refresh refused: user is not active")
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// O uso é condicional: se duas renovações concorrentes apresentarem o mesmo token, a segunda
+	// é tratada como reuso
+	if err := uc.refreshTokens.Use(ctx, token.ID.Hex(), now); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, uc.revokeReused(ctx, log, token)
+		}
+		return nil, err
+	}
+	if err := uc.sessions.Touch(ctx, session.ID.Hex(), client.IP, client.UserAgent, now); err != nil {
+		return nil, err
+	}
+	response, err := issueTokens(ctx, uc.tokens, uc.refreshTokens, session, now)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Session refreshed")
+	return response, nil
+}
+
+// revokeReused revoga a sessão de um refresh token apresentado mais de uma vez
+func (uc *RefreshUseCase) revokeReused(ctx context.Context, log *logrus.Entry, token *entities.RefreshToken) error {
+	err := uc.sessions.Revoke(ctx, token.UserID, token.SessionID, uc.clock.Now())
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	log.Warn("Refresh token reuse detected; session revoked")
+	return ErrInvalidRefreshToken
+}
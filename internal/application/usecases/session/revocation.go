@@ -0,0 +1,18 @@
+package session
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+// RevokeUserSessions revoga as sessões do usuário e marca sessions_revoked_at, para que também os
+// access tokens emitidos fora das sessões (sem a claim sid) antes de now deixem de valer. É usado
+// pela revogação pedida na API e pelos casos de uso que removem ou desativam o usuário. Retorna
+// quantas sessões foram revogadas, ou mongo.ErrNoDocuments se o usuário não existir
+func RevokeUserSessions(ctx context.Context, users repositories.IUserRepository, sessions repositories.ISessionRepository, userID string, now time.Time) (int64, error) {
+	if err := users.RevokeSessions(ctx, userID, now); err != nil {
+		return 0, err
+	}
+	return sessions.RevokeAll(ctx, userID, now)
+}
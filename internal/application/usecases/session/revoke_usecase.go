@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type RevokeUseCase struct {
+	users    repositories.IUserRepository
+	sessions repositories.ISessionRepository
+	clock    services.IClock
+}
+
+func NewRevokeUseCase(users repositories.IUserRepository, sessions repositories.ISessionRepository, clock services.IClock) *RevokeUseCase {
+	return &RevokeUseCase{users: users, sessions: sessions, clock: clock}
+}
+
+// Execute revoga uma sessão do usuário (o próprio usuário, ex.: logout, ou um administrador). Os
+// access tokens e o refresh token da sessão deixam de valer imediatamente
+func (uc *RevokeUseCase) Execute(ctx context.Context, userID string, sessionID string) error {
+	ctx, span := tracer.Start(ctx, "RevokeUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireSelfOrAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if _, err := uc.users.GetByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.sessions.Revoke(ctx, userID, sessionID, uc.clock.Now()); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": userID, "session_id": sessionID}).Info("Session revoked")
+	return nil
+}
+
+type RevokeAllUseCase struct {
+	users    repositories.IUserRepository
+	sessions repositories.ISessionRepository
+	clock    services.IClock
+}
+
+func NewRevokeAllUseCase(users repositories.IUserRepository, sessions repositories.ISessionRepository, clock services.IClock) *RevokeAllUseCase {
+	return &RevokeAllUseCase{users: users, sessions: sessions, clock: clock}
+}
+
+// Execute revoga todas as sessões do usuário (o próprio usuário ou um administrador) e também os
+// access tokens emitidos fora delas até agora, e retorna quantas sessões foram revogadas
+func (uc *RevokeAllUseCase) Execute(ctx context.Context, userID string) (int64, error) {
+	ctx, span := tracer.Start(ctx, "RevokeAllUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireSelfOrAdmin(ctx, userID); err != nil {
+		return 0, err
+	}
+	revoked, err := RevokeUserSessions(ctx, uc.users, uc.sessions, userID, uc.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": userID, "revoked": revoked}).Info("All sessions revoked")
+	return revoked, nil
+}
@@ -0,0 +1,51 @@
+package session
+
+import (
+	"context"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/secret"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+// refreshTokenSize é o tamanho do refresh token entregue ao cliente
+const refreshTokenSize = 32
+
+// issueTokens grava um novo refresh token da sessão, válido até o fim dela, e emite um access token
+// com o ID da sessão. O segundo fator já confirmado na sessão vale para os novos access tokens
+func issueTokens(ctx context.Context, tokens auth.TokenIssuer, refreshTokens repositories.IRefreshTokenRepository, session *entities.Session, now time.Time) (*dto.SessionTokenResponseDTO, error) {
+	raw, err := secret.Random(refreshTokenSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := refreshTokens.Create(ctx, &entities.RefreshToken{
+		SessionID: session.ID.Hex(),
+		UserID:    session.UserID,
+		TokenHash: secret.Hash(raw),
+		ExpiresAt: session.ExpiresAt,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := tokens.Issue(auth.Principal{
+		ID:        session.UserID,
+		Type:      auth.PrincipalUser,
+		TenantID:  session.TenantID,
+		MFA:       session.MFA,
+		SessionID: session.ID.Hex(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SessionTokenResponseDTO{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             expiresAt,
+		RefreshToken:          raw,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+		SessionID:             session.ID.Hex(),
+	}, nil
+}
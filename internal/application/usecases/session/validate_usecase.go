@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ValidateUseCase struct {
+	users    repositories.IUserRepository
+	sessions repositories.ISessionRepository
+	clock    services.IClock
+}
+
+func NewValidateUseCase(users repositories.IUserRepository, sessions repositories.ISessionRepository, clock services.IClock) *ValidateUseCase {
+	return &ValidateUseCase{users: users, sessions: sessions, clock: clock}
+}
+
+// Execute rejeita o access token de uma sessão revogada ou expirada, de um usuário removido ou,
+// para tokens emitidos fora de uma sessão (sem a claim sid), de um usuário cujas sessões foram
+// revogadas depois da emissão (ex.: ao redefinir a senha ou ser suspenso); as revogações também
+// revogam as sessões, que cobrem os demais tokens. Custa até duas leituras por requisição
+// autenticada com token de usuário. Tokens de principais que não são usuários do serviço (ex.:
+// administradores do front end) não são afetados. O iat do JWT tem precisão de segundos, então
+// tokens sem sessão emitidos no mesmo segundo da revogação continuam valendo
+func (uc *ValidateUseCase) Execute(ctx context.Context, principal auth.Principal) error {
+	ctx, span := tracer.Start(ctx, "ValidateUseCase.Execute")
+	defer span.End()
+
+	if principal.Type != auth.PrincipalUser {
+		return nil
+	}
+	tenantID := principal.TenantID
+	if tenantID == "" {
+		tenantID = tenancy.DefaultTenant
+	}
+	ctx = tenancy.WithTenant(ctx, tenantID)
+
+	if principal.SessionID != "" {
+		session, err := uc.sessions.GetByID(ctx, principal.SessionID)
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return ErrSessionRevoked
+		}
+		if err != nil {
+			return err
+		}
+		if session.UserID != principal.ID || !session.Active(uc.clock.Now()) {
+			return ErrSessionRevoked
+		}
+	}
+
+	user, err := uc.users.GetByIDIncludingDeleted(ctx, principal.ID)
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DeletedAt != nil {
+		return ErrSessionRevoked
+	}
+	if principal.SessionID == "" && user.SessionsRevokedAt != nil && principal.IssuedAt.Before(user.SessionsRevokedAt.Truncate(time.Second)) {
+		return ErrSessionRevoked
+	}
+	return nil
+}
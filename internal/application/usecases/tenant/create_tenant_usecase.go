@@ -0,0 +1,50 @@
+package tenant
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type CreateTenantUseCase struct {
+	repo  repositories.ITenantRepository
+	clock services.IClock
+}
+
+func NewCreateTenantUseCase(repo repositories.ITenantRepository, clock services.IClock) *CreateTenantUseCase {
+	return &CreateTenantUseCase{repo: repo, clock: clock}
+}
+
+// Execute cadastra um tenant; apenas administradores da plataforma gerenciam tenants
+func (uc *CreateTenantUseCase) Execute(ctx context.Context, input *dto.CreateTenantRequestDTO) (*dto.TenantResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "CreateTenantUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequirePlatformAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if !tenancy.ValidSlug(input.Slug) {
+		return nil, tenancy.ErrInvalidSlug
+	}
+
+	now := uc.clock.Now()
+	tenant := &entities.Tenant{
+		Slug:      input.Slug,
+		Name:      input.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+		CreatedBy: auth.Actor(ctx),
+		UpdatedBy: auth.Actor(ctx),
+	}
+	if err := uc.repo.Create(ctx, tenant); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("tenant", tenant.Slug).Info("Tenant created")
+	return mappers.ToTenantResponseDTO(tenant), nil
+}
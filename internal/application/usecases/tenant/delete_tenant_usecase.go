@@ -0,0 +1,57 @@
+package tenant
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
+)
+
+type DeleteTenantUseCase struct {
+	repo      repositories.ITenantRepository
+	userRepo  repositories.IUserRepository
+	groupRepo repositories.IGroupRepository
+}
+
+func NewDeleteTenantUseCase(repo repositories.ITenantRepository, userRepo repositories.IUserRepository, groupRepo repositories.IGroupRepository) *DeleteTenantUseCase {
+	return &DeleteTenantUseCase{repo: repo, userRepo: userRepo, groupRepo: groupRepo}
+}
+
+// Execute remove o cadastro do tenant. Só tenants sem usuários nem grupos (inclusive removidos
+// e ainda não expurgados) podem ser removidos, e o tenant padrão nunca
+func (uc *DeleteTenantUseCase) Execute(ctx context.Context, slug string) error {
+	ctx, span := tracer.Start(ctx, "DeleteTenantUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequirePlatformAdmin(ctx); err != nil {
+		return err
+	}
+	if slug == tenancy.DefaultTenant {
+		return entities.ErrDefaultTenant
+	}
+	if _, err := uc.repo.GetBySlug(ctx, slug); err != nil {
+		return err
+	}
+
+	tenantCtx := tenancy.WithTenant(ctx, slug)
+	all := repositories.ListFilter{IncludeDeleted: true}
+	users, err := uc.userRepo.Count(tenantCtx, all)
+	if err != nil {
+		return err
+	}
+	groups, err := uc.groupRepo.Count(tenantCtx, all)
+	if err != nil {
+		return err
+	}
+	if users > 0 || groups > 0 {
+		return entities.ErrTenantNotEmpty
+	}
+
+	if err := uc.repo.Delete(ctx, slug); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("tenant", slug).Info("Tenant deleted")
+	return nil
+}
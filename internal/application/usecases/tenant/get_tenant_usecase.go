@@ -0,0 +1,31 @@
+package tenant
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type GetTenantUseCase struct {
+	repo repositories.ITenantRepository
+}
+
+func NewGetTenantUseCase(repo repositories.ITenantRepository) *GetTenantUseCase {
+	return &GetTenantUseCase{repo: repo}
+}
+
+func (uc *GetTenantUseCase) Execute(ctx context.Context, slug string) (*dto.TenantResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetTenantUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequirePlatformAdmin(ctx); err != nil {
+		return nil, err
+	}
+	tenant, err := uc.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToTenantResponseDTO(tenant), nil
+}
@@ -0,0 +1,36 @@
+package tenant
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListTenantsUseCase struct {
+	repo repositories.ITenantRepository
+}
+
+func NewListTenantsUseCase(repo repositories.ITenantRepository) *ListTenantsUseCase {
+	return &ListTenantsUseCase{repo: repo}
+}
+
+// Execute lista os tenants ordenados pelo slug; input.Page já vem baseado em 0
+func (uc *ListTenantsUseCase) Execute(ctx context.Context, input *dto.PageQueryParam) (*dto.ListTenantResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListTenantsUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequirePlatformAdmin(ctx); err != nil {
+		return nil, err
+	}
+	tenants, err := uc.repo.List(ctx, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.repo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToListTenantResponseDTO(tenants, total, input.Page, input.PerPage), nil
+}
@@ -0,0 +1,42 @@
+package tenant
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type UpdateTenantUseCase struct {
+	repo  repositories.ITenantRepository
+	clock services.IClock
+}
+
+func NewUpdateTenantUseCase(repo repositories.ITenantRepository, clock services.IClock) *UpdateTenantUseCase {
+	return &UpdateTenantUseCase{repo: repo, clock: clock}
+}
+
+// Execute altera o nome do tenant; o slug é imutável
+func (uc *UpdateTenantUseCase) Execute(ctx context.Context, slug string, input *dto.UpdateTenantRequestDTO) (*dto.TenantResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UpdateTenantUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequirePlatformAdmin(ctx); err != nil {
+		return nil, err
+	}
+	tenant, err := uc.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	tenant.Name = input.Name
+	tenant.UpdatedAt = uc.clock.Now()
+	tenant.UpdatedBy = auth.Actor(ctx)
+	if err := uc.repo.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("tenant", slug).Info("Tenant updated")
+	return mappers.ToTenantResponseDTO(tenant), nil
+}
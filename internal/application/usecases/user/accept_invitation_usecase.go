@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/application/secret"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type AcceptInvitationUseCase struct {
+	cfg         *config.Config
+	userRepo    repositories.IUserRepository
+	invitations repositories.IInvitationRepository
+	hasher      services.IPasswordHasher
+	clock       services.IClock
+}
+
+func NewAcceptInvitationUseCase(cfg *config.Config, userRepo repositories.IUserRepository, invitations repositories.IInvitationRepository, hasher services.IPasswordHasher, clock services.IClock) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{cfg: cfg, userRepo: userRepo, invitations: invitations, hasher: hasher, clock: clock}
+}
+
+// Execute consome o convite e ativa o usuário, marcando o email como verificado e, se informada,
+// definindo a senha. Não exige autenticação: o token é a credencial, e o tenant é o do convite
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, token string, input *dto.AcceptInvitationRequestDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "AcceptInvitationUseCase.Execute")
+	defer span.End()
+
+	invitation, err := uc.invitations.GetByHash(ctx, secret.Sign(uc.cfg.AuthTokenSecret, token))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	if err := invitation.Usable(now); err != nil {
+		return nil, err
+	}
+
+	ctx = tenancy.WithTenant(ctx, invitation.TenantID)
+	user, err := uc.userRepo.GetByID(ctx, invitation.UserID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != invitation.Email {
+		return nil, ErrInvitationNotFound
+	}
+	if user.Status != entities.UserStatusPending {
+		return nil, ErrUserNotPending
+	}
+
+	// O hash é calculado antes de consumir o convite para que uma senha recusada não o invalide
+	if input.Password != "" {
+		if user.PasswordHash, err = uc.hasher.Hash(input.Password); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.invitations.Accept(ctx, invitation.ID.Hex(), now); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, entities.ErrInvitationAccepted
+		}
+		return nil, err
+	}
+
+	user.Status = entities.UserStatusActive
+	user.StatusReason = "invitation accepted"
+	user.StatusChangedAt = &now
+	user.StatusChangedBy = invitation.UserID
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	user.UpdatedBy = invitation.UserID
+	if err := uc.userRepo.AcceptInvitation(ctx, user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrUserNotPending
+		}
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithField("user_id", invitation.UserID).Info("Invitation accepted")
+	return mappers.ToUserResponseDTO(user), nil
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrStatusReasonRequired = errors.New("a reason is required to suspend or deprovision a user")
+
+type ChangeUserStatusUseCase struct {
+	userRepo    repositories.IUserRepository
+	groupRepo   repositories.IGroupRepository
+	sessionRepo repositories.ISessionRepository
+	clock       services.IClock
+}
+
+func NewChangeUserStatusUseCase(userRepo repositories.IUserRepository, groupRepo repositories.IGroupRepository, sessionRepo repositories.ISessionRepository, clock services.IClock) *ChangeUserStatusUseCase {
+	return &ChangeUserStatusUseCase{userRepo: userRepo, groupRepo: groupRepo, sessionRepo: sessionRepo, clock: clock}
+}
+
+// Execute move o usuário para o status informado (apenas administradores), validando a transição
+// e registrando o motivo. Suspender e desligar exigem um motivo e revogam as sessões do usuário; ao
+// desligar, ele também é removido de todos os grupos
+func (uc *ChangeUserStatusUseCase) Execute(ctx context.Context, id string, status entities.UserStatus, input *dto.ChangeUserStatusRequestDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ChangeUserStatusUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if input.Reason == "" && (status == entities.UserStatusSuspended || status == entities.UserStatusDeprovisioned) {
+		return nil, ErrStatusReasonRequired
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from := user.Status
+	if err := from.CheckTransition(status); err != nil {
+		return nil, err
+	}
+
+	// As sessões e as participações são removidas antes da troca de status para que, em caso de
+	// falha, a operação possa ser repetida (deprovisioned é final e não aceita nova transição)
+	now := uc.clock.Now()
+	if status == entities.UserStatusSuspended || status == entities.UserStatusDeprovisioned {
+		revoked, err := session.RevokeUserSessions(ctx, uc.userRepo, uc.sessionRepo, id, now)
+		if err != nil {
+			return nil, err
+		}
+		logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": id, "revoked": revoked}).Info("User sessions revoked")
+	}
+	if status == entities.UserStatusDeprovisioned {
+		if err := uc.groupRepo.RemoveUsersFromAllGroups(ctx, []string{id}); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Status = status
+	user.StatusReason = input.Reason
+	user.StatusChangedAt = &now
+	user.StatusChangedBy = auth.Actor(ctx)
+	user.UpdatedAt = now
+	user.UpdatedBy = user.StatusChangedBy
+	if err := uc.userRepo.UpdateStatus(ctx, user, from); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"user_id": id,
+		"from":    from,
+		"to":      status,
+		"reason":  input.Reason,
+	}).Info("User status changed")
+	return mappers.ToUserResponseDTO(user), nil
+}
@@ -2,24 +2,52 @@ package user
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
 )
 
 type CreateUserUseCase struct {
-	repo repositories.IUserRepository
+	repo    repositories.IUserRepository
+	invites *invitationSender
+	clock   services.IClock
 }
 
-func NewCreateUserUseCase(repo repositories.IUserRepository) *CreateUserUseCase {
-	return &CreateUserUseCase{repo: repo}
+func NewCreateUserUseCase(cfg *config.Config, repo repositories.IUserRepository, invitations repositories.IInvitationRepository, mailer services.IMailer, clock services.IClock) *CreateUserUseCase {
+	return &CreateUserUseCase{
+		repo:    repo,
+		invites: &invitationSender{cfg: cfg, invitations: invitations, mailer: mailer, clock: clock},
+		clock:   clock,
+	}
 }
 
+// Execute cria o usuário e, se ele for criado como pending, envia o convite por email (a menos
+// que send_invitation seja false). Uma falha no convite não desfaz a criação: ela é registrada
+// no log e o convite pode ser reenviado por ResendInvitationUseCase
 func (uc *CreateUserUseCase) Execute(ctx context.Context, userDTO *dto.CreateUserRequestDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "CreateUserUseCase.Execute")
+	defer span.End()
+
 	user := mappers.ToUserEntityFromRequest(userDTO)
+	user.CreatedAt = uc.clock.Now()
+	user.UpdatedAt = user.CreatedAt
+	user.CreatedBy = auth.Actor(ctx)
+	user.UpdatedBy = user.CreatedBy
 	err := uc.repo.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
+	logger.FromContext(ctx).WithField("user_id", user.ID.Hex()).Info("User created")
+
+	if user.Status == entities.UserStatusPending && (userDTO.SendInvitation == nil || *userDTO.SendInvitation) {
+		if _, err := uc.invites.send(ctx, user); err != nil {
+			logger.FromContext(ctx).WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to invite user")
+		}
+	}
 	return mappers.ToUserResponseDTO(user), nil
 }
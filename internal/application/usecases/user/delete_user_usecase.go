@@ -2,17 +2,46 @@ package user
 
 import (
 	"context"
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/usecases/session"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type DeleteUserUseCase struct {
-	repo repositories.IUserRepository
+	repo     repositories.IUserRepository
+	sessions repositories.ISessionRepository
+	clock    services.IClock
 }
 
-func NewDeleteUserUseCase(repo repositories.IUserRepository) *DeleteUserUseCase {
-	return &DeleteUserUseCase{repo: repo}
+func NewDeleteUserUseCase(repo repositories.IUserRepository, sessions repositories.ISessionRepository, clock services.IClock) *DeleteUserUseCase {
+	return &DeleteUserUseCase{repo: repo, sessions: sessions, clock: clock}
 }
 
+// Execute remove o usuário e revoga as suas sessões; a revogação vem antes para que, em caso de
+// falha, a operação possa ser repetida. Um usuário já removido não tem o que revogar, e a remoção
+// continua idempotente
 func (uc *DeleteUserUseCase) Execute(ctx context.Context, id string) error {
-	return uc.repo.Delete(ctx, id)
+	ctx, span := tracer.Start(ctx, "DeleteUserUseCase.Execute")
+	defer span.End()
+
+	now := uc.clock.Now()
+	revoked, err := session.RevokeUserSessions(ctx, uc.repo, uc.sessions, id, now)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if revoked > 0 {
+		logger.FromContext(ctx).WithFields(logrus.Fields{"user_id": id, "revoked": revoked}).Info("User sessions revoked")
+	}
+	// Soft delete: o registro pode ser restaurado até ser expurgado pelo job de retenção
+	if err := uc.repo.Delete(ctx, id, now, auth.Actor(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("user_id", id).Info("User deleted")
+	return nil
 }
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/interfaces/repositories"
@@ -15,8 +16,20 @@ func NewGetUserUseCase(repo repositories.IUserRepository) *GetUserUseCase {
 	return &GetUserUseCase{repo: repo}
 }
 
-func (uc *GetUserUseCase) Execute(ctx context.Context, id string) (*dto.UserResponseDTO, error) {
-	user, err := uc.repo.GetByID(ctx, id)
+// Execute busca o usuário; com includeDeleted (apenas administradores) também retorna removidos
+func (uc *GetUserUseCase) Execute(ctx context.Context, id string, includeDeleted bool) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "GetUserUseCase.Execute")
+	defer span.End()
+
+	get := uc.repo.GetByID
+	if includeDeleted {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+		get = uc.repo.GetByIDIncludingDeleted
+	}
+
+	user, err := get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/secret"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+var (
+	// ErrInvitationNotFound é retornado para tokens desconhecidos ou emitidos para um email que o
+	// usuário não usa mais
+	ErrInvitationNotFound = errors.New("invitation not found")
+	// ErrUserNotPending é retornado ao convidar ou ativar por convite um usuário que não está pending
+	ErrUserNotPending = errors.New("user is not pending")
+)
+
+// invitationTokenSize é o tamanho do token enviado no link
+const invitationTokenSize = 32
+
+// invitationSender cria o convite e envia o email; é compartilhado pela criação de usuários e
+// pelo reenvio do convite
+type invitationSender struct {
+	cfg         *config.Config
+	invitations repositories.IInvitationRepository
+	mailer      services.IMailer
+	clock       services.IClock
+}
+
+// send grava um novo convite para o usuário e envia o link por email
+func (s *invitationSender) send(ctx context.Context, user *entities.User) (*entities.Invitation, error) {
+	token, err := secret.Random(invitationTokenSize)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	invitation := &entities.Invitation{
+		UserID:    user.ID.Hex(),
+		Email:     user.Email,
+		TokenHash: secret.Sign(s.cfg.AuthTokenSecret, token),
+		ExpiresAt: now.Add(s.cfg.InvitationTTL),
+		CreatedAt: now,
+		CreatedBy: auth.Actor(ctx),
+	}
+	if err := s.invitations.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	link := strings.ReplaceAll(s.cfg.InvitationURL, "{token}", token)
+	if err := s.mailer.Send(ctx, services.Message{
+		To:      user.Email,
+		Subject: "You have been invited",
+		Body: fmt.Sprintf("Hi %s,\n\nAn account has been created for you. Open the link below to activate it:\n\n%s\n\nThe link can be used once and expires on %s.\n",
+			user.Name, link, invitation.ExpiresAt.Format("Jan 2, 2006 15:04 MST")),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	logger.FromContext(ctx).WithField("user_id", invitation.UserID).Info("Invitation sent")
+	return invitation, nil
+}
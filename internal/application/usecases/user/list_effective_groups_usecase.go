@@ -0,0 +1,32 @@
+package user
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListEffectiveGroupsUseCase struct {
+	userRepo  repositories.IUserRepository
+	groupRepo repositories.IGroupRepository
+}
+
+func NewListEffectiveGroupsUseCase(userRepo repositories.IUserRepository, groupRepo repositories.IGroupRepository) *ListEffectiveGroupsUseCase {
+	return &ListEffectiveGroupsUseCase{userRepo: userRepo, groupRepo: groupRepo}
+}
+
+// Execute lista os grupos dos quais o usuário é membro, diretamente ou por meio de um subgrupo
+func (uc *ListEffectiveGroupsUseCase) Execute(ctx context.Context, userID string, input *dto.PageQueryParam) (*dto.ListGroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListEffectiveGroupsUseCase.Execute")
+	defer span.End()
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	groups, total, err := uc.groupRepo.ListEffectiveGroups(ctx, userID, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToListGroupResponseDTO(groups, total, input.Page, input.PerPage), nil
+}
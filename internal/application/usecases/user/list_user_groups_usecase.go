@@ -0,0 +1,33 @@
+package user
+
+import (
+	"context"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+)
+
+type ListUserGroupsUseCase struct {
+	userRepo  repositories.IUserRepository
+	groupRepo repositories.IGroupRepository
+}
+
+func NewListUserGroupsUseCase(userRepo repositories.IUserRepository, groupRepo repositories.IGroupRepository) *ListUserGroupsUseCase {
+	return &ListUserGroupsUseCase{userRepo: userRepo, groupRepo: groupRepo}
+}
+
+// Execute lista os grupos dos quais o usuário é membro direto; os herdados de subgrupos ficam em
+// ListEffectiveGroupsUseCase
+func (uc *ListUserGroupsUseCase) Execute(ctx context.Context, userID string, input *dto.SearchPageQueryParam) (*dto.ListGroupResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListUserGroupsUseCase.Execute")
+	defer span.End()
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	groups, total, err := uc.groupRepo.ListUserGroups(ctx, userID, input.Search, input.Page*input.PerPage, input.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToListGroupResponseDTO(groups, total, input.Page, input.PerPage), nil
+}
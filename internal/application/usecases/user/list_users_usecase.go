@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/entities"
@@ -17,29 +18,43 @@ func NewListUsersUseCase(repo repositories.IUserRepository) *ListUsersUseCase {
 }
 
 func (uc *ListUsersUseCase) Execute(ctx context.Context, input *dto.ListUserQueryParam) (*dto.UserListResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ListUsersUseCase.Execute")
+	defer span.End()
+
+	// Apenas administradores podem listar usuários removidos
+	if input.IncludeDeleted {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := mappers.ToListFilter(input.Sort, input.IncludeDeleted, input.ListFilterQueryParam)
+	if err != nil {
+		return nil, err
+	}
+
 	var users []*entities.User
 	var total int64
-	var err error
 
 	// Se há um termo de busca, usa a busca filtrada
 	if input.Search != "" {
-		users, err = uc.repo.Search(ctx, input.Search, input.Page, input.PerPage)
+		users, err = uc.repo.Search(ctx, input.Search, input.Page, input.PerPage, filter)
 		if err != nil {
 			return nil, err
 		}
 
-		total, err = uc.repo.CountSearch(ctx, input.Search)
+		total, err = uc.repo.CountSearch(ctx, input.Search, filter)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		// Caso contrário, lista todos os usuários
-		users, err = uc.repo.List(ctx, input.Page, input.PerPage)
+		users, err = uc.repo.List(ctx, input.Page, input.PerPage, filter)
 		if err != nil {
 			return nil, err
 		}
 
-		total, err = uc.repo.Count(ctx)
+		total, err = uc.repo.Count(ctx, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+)
+
+type ResendInvitationUseCase struct {
+	userRepo    repositories.IUserRepository
+	invitations repositories.IInvitationRepository
+	invites     *invitationSender
+}
+
+func NewResendInvitationUseCase(cfg *config.Config, userRepo repositories.IUserRepository, invitations repositories.IInvitationRepository, mailer services.IMailer, clock services.IClock) *ResendInvitationUseCase {
+	return &ResendInvitationUseCase{
+		userRepo:    userRepo,
+		invitations: invitations,
+		invites:     &invitationSender{cfg: cfg, invitations: invitations, mailer: mailer, clock: clock},
+	}
+}
+
+// Execute envia um novo convite a um usuário pending (apenas administradores), invalidando os
+// links enviados antes
+func (uc *ResendInvitationUseCase) Execute(ctx context.Context, userID string) (*dto.InvitationResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "ResendInvitationUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != entities.UserStatusPending {
+		return nil, ErrUserNotPending
+	}
+
+	if err := uc.invitations.DeletePending(ctx, userID); err != nil {
+		return nil, err
+	}
+	invitation, err := uc.invites.send(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.InvitationResponseDTO{UserID: invitation.UserID, Email: invitation.Email, ExpiresAt: invitation.ExpiresAt}, nil
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/mappers"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+type RestoreUserUseCase struct {
+	repo  repositories.IUserRepository
+	clock services.IClock
+}
+
+func NewRestoreUserUseCase(repo repositories.IUserRepository, clock services.IClock) *RestoreUserUseCase {
+	return &RestoreUserUseCase{repo: repo, clock: clock}
+}
+
+// Execute desfaz a remoção de um usuário (apenas administradores)
+func (uc *RestoreUserUseCase) Execute(ctx context.Context, id string) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "RestoreUserUseCase.Execute")
+	defer span.End()
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Restore(ctx, id, uc.clock.Now(), auth.Actor(ctx)); err != nil {
+		return nil, err
+	}
+	user, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).WithField("user_id", id).Info("User restored")
+	return mappers.ToUserResponseDTO(user), nil
+}
@@ -2,30 +2,45 @@ package user
 
 import (
 	"context"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/mappers"
 	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
 )
 
 type UpdateUserUseCase struct {
-	repo repositories.IUserRepository
+	repo  repositories.IUserRepository
+	clock services.IClock
 }
 
-func NewUpdateUserUseCase(repo repositories.IUserRepository) *UpdateUserUseCase {
-	return &UpdateUserUseCase{repo: repo}
+func NewUpdateUserUseCase(repo repositories.IUserRepository, clock services.IClock) *UpdateUserUseCase {
+	return &UpdateUserUseCase{repo: repo, clock: clock}
 }
 
-func (uc *UpdateUserUseCase) Execute(ctx context.Context, userID string, userDTO *dto.CreateUserRequestDTO) (*dto.UserResponseDTO, error) {
-	existingUser, err := uc.repo.GetByID(ctx, userID)
+// Execute altera nome e email; o status só muda pelo ChangeUserStatusUseCase. Um novo email
+// volta a ser não verificado
+func (uc *UpdateUserUseCase) Execute(ctx context.Context, userID string, userDTO *dto.UpdateUserRequestDTO) (*dto.UserResponseDTO, error) {
+	ctx, span := tracer.Start(ctx, "UpdateUserUseCase.Execute")
+	defer span.End()
+
+	user, err := uc.repo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	user := mappers.ToUserEntityFromRequest(userDTO)
-	user.ID = existingUser.ID
+	user.Name = userDTO.Name
+	if user.Email != userDTO.Email {
+		user.EmailVerifiedAt = nil
+	}
+	user.Email = userDTO.Email
+	user.UpdatedAt = uc.clock.Now()
+	user.UpdatedBy = auth.Actor(ctx)
 	errUpdate := uc.repo.Update(ctx, user)
 	if errUpdate != nil {
 		return nil, errUpdate
 	}
+	logger.FromContext(ctx).WithField("user_id", user.ID.Hex()).Info("User updated")
 	return mappers.ToUserResponseDTO(user), nil
 }
@@ -1,41 +1,230 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
 )
 
+// ConfigFileEnv é a variável de ambiente alternativa à flag --config
+const ConfigFileEnv = "CONFIG_FILE"
+
 type Config struct {
-	MongoURI     string
-	MongoDB      string
-	Port         string
-	DDSource     string
-	DDService    string
-	DDTags       string
-	DatabaseType string
+	MongoURI            string
+	MongoDB             string
+	MongoConnectTimeout time.Duration
+	MongoMaxPoolSize    uint64
+	MongoMinPoolSize    uint64
+	Port                string
+	DDSource            string
+	DDService           string
+	DDTags              string
+	DatabaseType        string
+	MigrateOnStart      bool // aplica as migrations pendentes ao iniciar o servidor
+
+	// Servidor HTTP
+	ServerReadTimeout  time.Duration
+	ServerWriteTimeout time.Duration
+	ServerIdleTimeout  time.Duration
+	ShutdownTimeout    time.Duration
+
+	// Health checks e desligamento
+	HealthCheckTimeout time.Duration
+	ShutdownDelay      time.Duration // tempo entre a readiness falhar e o Fiber parar de aceitar conexões
+
+	// Soft delete
+	SoftDeleteRetention time.Duration // 0 mantém os registros removidos indefinidamente
+	PurgeInterval       time.Duration
+
+	// CORS
+	CORSAllowOrigins     []string
+	CORSAllowMethods     []string
+	CORSAllowHeaders     []string
+	CORSAllowCredentials bool
+
+	// Rate limiting (token bucket por API key, usuário ou IP)
+	RateLimitEnabled  bool
+	RateLimitRequests uint64          // tamanho do balde: requisições seguidas aceitas de um cliente
+	RateLimitPeriod   time.Duration   // tempo para um balde vazio encher de novo
+	RateLimitRoutes   []RateLimitRule // cotas próprias de rotas específicas, no lugar da padrão
+	RateLimitStore    string          // memory
+
+	// Idempotência dos POSTs com o cabeçalho Idempotency-Key
+	IdempotencyTTL time.Duration // por quanto tempo a resposta é repetida nas retentativas com a mesma chave
+
+	// Autenticação
+	AuthEnabled     bool
+	AuthTokenSecret string
+	AuthTokenIssuer string // claim iss dos access tokens; trocá-la invalida os tokens já emitidos
+	AuthTokenTTL    time.Duration
+	SessionTTL      time.Duration // duração máxima de uma sessão de login, renovada por refresh tokens
+
+	// Provedor OpenID Connect
+	OIDCIssuer              string // URL pública do serviço; é a claim iss e a base dos endpoints da discovery
+	OIDCCodeTTL             time.Duration
+	OIDCTokenTTL            time.Duration
+	OIDCKeyRotationInterval time.Duration // as chaves antigas continuam no JWKS até os tokens assinados com elas expirarem
+	OIDCKeyEncryptionKey    string        // 32 bytes em base64; cifra as chaves privadas gravadas no banco
+
+	// Autenticação multifator
+	MFAIssuer string // nome exibido pelos aplicativos autenticadores (parâmetro issuer do otpauth URI)
+
+	// Convites
+	InvitationTTL time.Duration
+	InvitationURL string // link enviado no email; {token} é substituído pelo token do convite
+
+	// Redefinição de senha
+	PasswordResetTTL         time.Duration // validade do token e janela do limite de pedidos
+	PasswordResetURL         string        // link enviado no email; {token} é substituído pelo token
+	PasswordResetMaxRequests uint64        // emails de redefinição por usuário dentro de PasswordResetTTL
+
+	// Envio de emails
+	Mailer        string // smtp, file ou log
+	MailFrom      string
+	MailerFileDir string // diretório dos arquivos .eml quando MAILER=file
+	SMTPHost      string
+	SMTPPort      string
+	SMTPUsername  string
+	SMTPPassword  string
+
+	// Multi-tenancy
+	TenantBaseDomain string // domínio cujos subdomínios identificam o tenant (acme.example.com); vazio desabilita
+
+	// Logging
+	LogLevel  string
+	LogFormat string // json ou text
+
+	// Tracing (OpenTelemetry)
+	TracingExporter    string // otlp, stdout ou none
+	OTLPEndpoint       string
+	OTLPInsecure       bool
+	TracingSampleRatio float64
+}
+
+// LoadOptions define as fontes opcionais da configuração
+type LoadOptions struct {
+	// File é o caminho de um arquivo YAML ou TOML; se vazio, usa CONFIG_FILE
+	File string
+	// Flags contém as flags registradas com RegisterFlags; apenas as alteradas são aplicadas
+	Flags *pflag.FlagSet
 }
 
+// NewConfig carrega a configuração sem flags de linha de comando
 func NewConfig() (*Config, error) {
-	if err := godotenv.Load(); err != nil {
-		return nil, err
-	}
-
-	return &Config{
-		MongoURI:     os.Getenv("MONGO_URI"),
-		MongoDB:      os.Getenv("MONGO_DB"),
-		Port:         os.Getenv("PORT"),
-		DDSource:     os.Getenv("DD_SOURCE"),
-		DDService:    os.Getenv("DD_SERVICE"),
-		DDTags:       os.Getenv("DD_TAGS"),
-		DatabaseType: getEnvOrDefault("DATABASE_TYPE", "mongodb"),
-	}, nil
+	return Load(LoadOptions{})
+}
+
+// Load monta a configuração em camadas, cada uma sobrescrevendo a anterior:
+// valores padrão < arquivo YAML/TOML < variáveis de ambiente (incluindo .env) < flags.
+// Todos os valores inválidos ou ausentes são reportados juntos em um *ValidationError
+func Load(opts LoadOptions) (*Config, error) {
+	// O .env é opcional: em containers as variáveis costumam ser injetadas diretamente
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.key] = s.defaultValue
+	}
+
+	var problems []string
+
+	file := opts.File
+	if file == "" {
+		file = os.Getenv(ConfigFileEnv)
+	}
+	if file != "" {
+		fileValues, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			if _, ok := values[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown setting in %s", key, file))
+				continue
+			}
+			values[key] = value
+		}
+	}
+
+	for _, s := range settings {
+		if value := os.Getenv(s.key); value != "" {
+			values[s.key] = value
+		}
+	}
+
+	if opts.Flags != nil {
+		for _, s := range settings {
+			if flag := opts.Flags.Lookup(s.flagName()); flag != nil && flag.Changed {
+				values[s.key] = flag.Value.String()
+			}
+		}
+	}
+
+	cfg := &Config{}
+	for _, s := range settings {
+		if err := s.apply(cfg, values[s.key]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", s.key, err))
+		}
+	}
+	problems = append(problems, cfg.validate()...)
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// RegisterFlags registra uma flag (ex.: --mongo-uri) para cada configuração
+func RegisterFlags(flags *pflag.FlagSet) {
+	for _, s := range settings {
+		flags.String(s.flagName(), "", fmt.Sprintf("%s (env %s, default %q)", s.usage, s.key, s.defaultValue))
+	}
+}
+
+// readConfigFile lê um arquivo YAML ou TOML com chaves planas (ex.: mongo_uri: ...)
+func readConfigFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		values[strings.ToUpper(key)] = stringifyFileValue(value)
+	}
+	return values, nil
 }
 
-// getEnvOrDefault returns environment variable value or default if not set
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// stringifyFileValue converte valores do arquivo para o mesmo formato das variáveis de ambiente
+func stringifyFileValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
 	}
-	return defaultValue
+	return fmt.Sprint(value)
 }
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitRule dá uma cota própria às requisições para Path com o método Method (qualquer
+// método, se vazio). Path aceita segmentos :param e um * final, que casa com qualquer sufixo
+type RateLimitRule struct {
+	Method   string
+	Path     string
+	Requests uint64
+	Period   time.Duration
+}
+
+// parseRateLimitRules lê regras no formato "[MÉTODO] /caminho=requisições/período", por exemplo
+// "GET /api/v1/users=60/1m" ou "/api/v1/auth/*=20/1m"
+func parseRateLimitRules(items []string) ([]RateLimitRule, error) {
+	var rules []RateLimitRule
+	for _, item := range items {
+		route, quota, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule %q (use [METHOD] /path=requests/period)", item)
+		}
+
+		var rule RateLimitRule
+		switch fields := strings.Fields(route); len(fields) {
+		case 1:
+			rule.Path = fields[0]
+		case 2:
+			rule.Method, rule.Path = strings.ToUpper(fields[0]), fields[1]
+		default:
+			return nil, fmt.Errorf("invalid route %q in rule %q", strings.TrimSpace(route), item)
+		}
+		if !strings.HasPrefix(rule.Path, "/") {
+			return nil, fmt.Errorf("path of rule %q must start with /", item)
+		}
+
+		requests, period, ok := strings.Cut(strings.TrimSpace(quota), "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid quota %q in rule %q (use requests/period, e.g. 60/1m)", quota, item)
+		}
+		var err error
+		if rule.Requests, err = strconv.ParseUint(requests, 10, 64); err != nil || rule.Requests == 0 {
+			return nil, fmt.Errorf("invalid request count %q in rule %q", requests, item)
+		}
+		if rule.Period, err = time.ParseDuration(period); err != nil || rule.Period <= 0 {
+			return nil, fmt.Errorf("invalid period %q in rule %q", period, item)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Matches indica se a regra se aplica à requisição
+func (r RateLimitRule) Matches(method, path string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+	pattern := strings.Split(strings.Trim(r.Path, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pattern {
+		if part == "*" && i == len(pattern)-1 {
+			return true
+		}
+		if i >= len(segments) || (!strings.HasPrefix(part, ":") && part != segments[i]) || (strings.HasPrefix(part, ":") && segments[i] == "") {
+			return false
+		}
+	}
+	return len(pattern) == len(segments)
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting associa uma chave de configuração (nome da variável de ambiente) ao campo de Config
+type setting struct {
+	key          string
+	defaultValue string
+	usage        string
+	field        func(cfg *Config) interface{}
+}
+
+// settings lista todas as configurações suportadas; a chave no arquivo é o nome em
+// minúsculas (mongo_uri) e a flag é o nome em kebab-case (--mongo-uri)
+var settings = []setting{
+	{"MONGO_URI", "", "MongoDB connection URI", func(c *Config) interface{} { return &c.MongoURI }},
+	{"MONGO_DB", "", "MongoDB database name", func(c *Config) interface{} { return &c.MongoDB }},
+	{"MONGO_CONNECT_TIMEOUT", "10s", "MongoDB connection timeout", func(c *Config) interface{} { return &c.MongoConnectTimeout }},
+	{"MONGO_MAX_POOL_SIZE", "100", "MongoDB maximum connection pool size", func(c *Config) interface{} { return &c.MongoMaxPoolSize }},
+	{"MONGO_MIN_POOL_SIZE", "0", "MongoDB minimum connection pool size", func(c *Config) interface{} { return &c.MongoMinPoolSize }},
+	{"MIGRATE_ON_START", "false", "Apply pending database migrations when the server starts", func(c *Config) interface{} { return &c.MigrateOnStart }},
+	{"DATABASE_TYPE", "mongodb", "Database backend", func(c *Config) interface{} { return &c.DatabaseType }},
+	{"PORT", ":8080", "HTTP listen address", func(c *Config) interface{} { return &c.Port }},
+	{"SERVER_READ_TIMEOUT", "10s", "HTTP read timeout", func(c *Config) interface{} { return &c.ServerReadTimeout }},
+	{"SERVER_WRITE_TIMEOUT", "10s", "HTTP write timeout", func(c *Config) interface{} { return &c.ServerWriteTimeout }},
+	{"SERVER_IDLE_TIMEOUT", "60s", "HTTP keep-alive idle timeout", func(c *Config) interface{} { return &c.ServerIdleTimeout }},
+	{"SHUTDOWN_TIMEOUT", "30s", "Graceful shutdown timeout", func(c *Config) interface{} { return &c.ShutdownTimeout }},
+	{"SHUTDOWN_DELAY", "5s", "Delay between failing readiness and stopping the listener", func(c *Config) interface{} { return &c.ShutdownDelay }},
+	{"HEALTH_CHECK_TIMEOUT", "2s", "Timeout of each readiness check", func(c *Config) interface{} { return &c.HealthCheckTimeout }},
+	{"SOFT_DELETE_RETENTION", "720h", "How long deleted users and groups are kept before being purged (0 disables purging)", func(c *Config) interface{} { return &c.SoftDeleteRetention }},
+	{"PURGE_INTERVAL", "1h", "Interval between purge runs", func(c *Config) interface{} { return &c.PurgeInterval }},
+	{"CORS_ALLOW_ORIGINS", "*", "Comma-separated CORS allowed origins", func(c *Config) interface{} { return &c.CORSAllowOrigins }},
+	{"CORS_ALLOW_METHODS", "GET,POST,PUT,DELETE,OPTIONS", "Comma-separated CORS allowed methods", func(c *Config) interface{} { return &c.CORSAllowMethods }},
+	{"CORS_ALLOW_HEADERS", "Origin,Content-Type,Accept,Authorization,X-Request-ID,X-Tenant-ID,X-API-Key,Idempotency-Key", "Comma-separated CORS allowed headers", func(c *Config) interface{} { return &c.CORSAllowHeaders }},
+	{"CORS_ALLOW_CREDENTIALS", "false", "Allow credentials in CORS requests", func(c *Config) interface{} { return &c.CORSAllowCredentials }},
+	{"RATE_LIMIT_ENABLED", "true", "Limit the request rate of each client (API key, user or IP address)", func(c *Config) interface{} { return &c.RateLimitEnabled }},
+	{"RATE_LIMIT_REQUESTS", "300", "Requests a client can make in a burst before being limited", func(c *Config) interface{} { return &c.RateLimitRequests }},
+	{"RATE_LIMIT_PERIOD", "1m", "Time for a client's exhausted quota of RATE_LIMIT_REQUESTS to refill", func(c *Config) interface{} { return &c.RateLimitPeriod }},
+	{"RATE_LIMIT_ROUTES", "", "Comma-separated per-route quotas replacing the default one (e.g. GET /api/v1/users=60/1m,POST /api/v1/auth/login=10/1m)", func(c *Config) interface{} { return &c.RateLimitRoutes }},
+	{"RATE_LIMIT_STORE", "memory", "Rate limit counter store (memory)", func(c *Config) interface{} { return &c.RateLimitStore }},
+	{"IDEMPOTENCY_TTL", "24h", "How long responses to POST requests with an Idempotency-Key header are replayed to retries", func(c *Config) interface{} { return &c.IdempotencyTTL }},
+	{"AUTH_ENABLED", "false", "Require authentication on the API", func(c *Config) interface{} { return &c.AuthEnabled }},
+	{"AUTH_TOKEN_SECRET", "", "Secret used to sign access tokens", func(c *Config) interface{} { return &c.AuthTokenSecret }},
+	{"AUTH_TOKEN_ISSUER", "user-management", "Issuer (iss claim) of access tokens", func(c *Config) interface{} { return &c.AuthTokenIssuer }},
+	{"AUTH_TOKEN_TTL", "15m", "Access token lifetime", func(c *Config) interface{} { return &c.AuthTokenTTL }},
+	{"SESSION_TTL", "720h", "Maximum lifetime of a login session and of its refresh tokens", func(c *Config) interface{} { return &c.SessionTTL }},
+	{"OIDC_ISSUER", "http://localhost:8080", "Public base URL of the service, used as the OpenID Connect issuer", func(c *Config) interface{} { return &c.OIDCIssuer }},
+	{"OIDC_CODE_TTL", "1m", "Authorization code lifetime", func(c *Config) interface{} { return &c.OIDCCodeTTL }},
+	{"OIDC_TOKEN_TTL", "1h", "Lifetime of the access and ID tokens issued by the OpenID Connect provider", func(c *Config) interface{} { return &c.OIDCTokenTTL }},
+	{"OIDC_KEY_ENCRYPTION_KEY", "", "Base64-encoded 32-byte key that encrypts the OpenID Connect signing keys stored in the database (required)", func(c *Config) interface{} { return &c.OIDCKeyEncryptionKey }},
+	{"OIDC_KEY_ROTATION_INTERVAL", "720h", "Interval after which a new OpenID Connect signing key is generated", func(c *Config) interface{} { return &c.OIDCKeyRotationInterval }},
+	{"MFA_ISSUER", "User Management", "Issuer name shown by authenticator apps for TOTP enrollments", func(c *Config) interface{} { return &c.MFAIssuer }},
+	{"INVITATION_TTL", "72h", "Lifetime of the invitation sent to users created as pending", func(c *Config) interface{} { return &c.InvitationTTL }},
+	{"INVITATION_URL", "http://localhost:3000/invitations/{token}", "Link sent in invitation emails; {token} is replaced by the invitation token", func(c *Config) interface{} { return &c.InvitationURL }},
+	{"PASSWORD_RESET_TTL", "1h", "Lifetime of password reset tokens and window of PASSWORD_RESET_MAX_REQUESTS", func(c *Config) interface{} { return &c.PasswordResetTTL }},
+	{"PASSWORD_RESET_URL", "http://localhost:3000/password-reset/{token}", "Link sent in password reset emails; {token} is replaced by the reset token", func(c *Config) interface{} { return &c.PasswordResetURL }},
+	{"PASSWORD_RESET_MAX_REQUESTS", "3", "Maximum password reset emails sent to a user within PASSWORD_RESET_TTL", func(c *Config) interface{} { return &c.PasswordResetMaxRequests }},
+	{"MAILER", "", "Email delivery (smtp, file or log); required", func(c *Config) interface{} { return &c.Mailer }},
+	{"MAIL_FROM", "User Management <no-reply@localhost>", "Sender address of outgoing emails", func(c *Config) interface{} { return &c.MailFrom }},
+	{"MAILER_FILE_DIR", "mail", "Directory where emails are written as .eml files when MAILER=file", func(c *Config) interface{} { return &c.MailerFileDir }},
+	{"SMTP_HOST", "", "SMTP server host (required when MAILER=smtp)", func(c *Config) interface{} { return &c.SMTPHost }},
+	{"SMTP_PORT", "587", "SMTP server port", func(c *Config) interface{} { return &c.SMTPPort }},
+	{"SMTP_USERNAME", "", "SMTP username; empty disables authentication", func(c *Config) interface{} { return &c.SMTPUsername }},
+	{"SMTP_PASSWORD", "", "SMTP password", func(c *Config) interface{} { return &c.SMTPPassword }},
+	{"TENANT_BASE_DOMAIN", "", "Base domain whose subdomains select the tenant (e.g. example.com); empty disables subdomain resolution", func(c *Config) interface{} { return &c.TenantBaseDomain }},
+	{"LOG_LEVEL", "info", "Log level (trace, debug, info, warn, error)", func(c *Config) interface{} { return &c.LogLevel }},
+	{"LOG_FORMAT", "json", "Log format (json or text)", func(c *Config) interface{} { return &c.LogFormat }},
+	{"DD_SOURCE", "go", "Datadog log source", func(c *Config) interface{} { return &c.DDSource }},
+	{"DD_SERVICE", "user-management", "Datadog service name", func(c *Config) interface{} { return &c.DDService }},
+	{"DD_TAGS", "", "Datadog tags", func(c *Config) interface{} { return &c.DDTags }},
+	{"OTEL_TRACES_EXPORTER", "none", "Trace exporter (otlp, stdout or none)", func(c *Config) interface{} { return &c.TracingExporter }},
+	{"OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4318", "OTLP/HTTP endpoint", func(c *Config) interface{} { return &c.OTLPEndpoint }},
+	{"OTEL_EXPORTER_OTLP_INSECURE", "true", "Disable TLS for the OTLP exporter", func(c *Config) interface{} { return &c.OTLPInsecure }},
+	{"OTEL_TRACES_SAMPLER_ARG", "1.0", "Fraction of traces sampled (0.0 to 1.0)", func(c *Config) interface{} { return &c.TracingSampleRatio }},
+}
+
+func (s setting) flagName() string {
+	return strings.ReplaceAll(strings.ToLower(s.key), "_", "-")
+}
+
+// apply converte o valor textual para o tipo do campo correspondente
+func (s setting) apply(cfg *Config, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch field := s.field(cfg).(type) {
+	case *string:
+		*field = value
+	case *bool:
+		if value == "" {
+			*field = false
+			return nil
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		*field = parsed
+	case *uint64:
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid non-negative integer %q", value)
+		}
+		*field = parsed
+	case *float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		*field = parsed
+	case *time.Duration:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use values such as 500ms, 10s, 1m)", value)
+		}
+		*field = parsed
+	case *[]string:
+		*field = splitList(value)
+	case *[]RateLimitRule:
+		rules, err := parseRateLimitRules(splitList(value))
+		if err != nil {
+			return err
+		}
+		*field = rules
+	default:
+		return fmt.Errorf("unsupported setting type %T", field)
+	}
+	return nil
+}
+
+// splitList separa listas no formato "a,b,c", ignorando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
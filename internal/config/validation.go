@@ -0,0 +1,214 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	minAuthTokenSecretLength = 32
+	oidcKeyEncryptionKeySize = 32
+)
+
+// ValidationError lista todas as configurações inválidas ou ausentes encontradas na carga
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validate verifica as regras que dependem do valor final de cada configuração
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.MongoURI == "" {
+		add("MONGO_URI: is required")
+	} else if !strings.HasPrefix(c.MongoURI, "mongodb://") && !strings.HasPrefix(c.MongoURI, "mongodb+srv://") {
+		add("MONGO_URI: must start with mongodb:// or mongodb+srv://")
+	}
+	if c.MongoDB == "" {
+		add("MONGO_DB: is required")
+	}
+	if c.MongoConnectTimeout <= 0 {
+		add("MONGO_CONNECT_TIMEOUT: must be greater than zero")
+	}
+	if c.MongoMaxPoolSize > 0 && c.MongoMinPoolSize > c.MongoMaxPoolSize {
+		add("MONGO_MIN_POOL_SIZE: must not be greater than MONGO_MAX_POOL_SIZE (%d)", c.MongoMaxPoolSize)
+	}
+	if c.DatabaseType != "mongodb" {
+		add("DATABASE_TYPE: unsupported database %q", c.DatabaseType)
+	}
+
+	if c.Port == "" {
+		add("PORT: is required")
+	} else if _, port, err := net.SplitHostPort(c.Port); err != nil {
+		add("PORT: must be in the form [host]:port, e.g. :8080")
+	} else if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		add("PORT: invalid port %q", port)
+	}
+	for _, timeout := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", c.ServerReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.ServerWriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.ServerIdleTimeout},
+		{"SHUTDOWN_DELAY", c.ShutdownDelay},
+	} {
+		if timeout.value < 0 {
+			add("%s: must not be negative", timeout.key)
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		add("SHUTDOWN_TIMEOUT: must be greater than zero")
+	}
+	if c.HealthCheckTimeout <= 0 {
+		add("HEALTH_CHECK_TIMEOUT: must be greater than zero")
+	}
+
+	if c.SoftDeleteRetention < 0 {
+		add("SOFT_DELETE_RETENTION: must not be negative")
+	}
+	if c.PurgeInterval <= 0 {
+		add("PURGE_INTERVAL: must be greater than zero")
+	}
+
+	if c.CORSAllowCredentials {
+		for _, origin := range c.CORSAllowOrigins {
+			if origin == "*" {
+				add("CORS_ALLOW_ORIGINS: wildcard origin cannot be used when CORS_ALLOW_CREDENTIALS is true")
+			}
+		}
+	}
+
+	if c.RateLimitEnabled {
+		if c.RateLimitRequests == 0 {
+			add("RATE_LIMIT_REQUESTS: must be greater than zero when RATE_LIMIT_ENABLED is true")
+		}
+		if c.RateLimitPeriod <= 0 {
+			add("RATE_LIMIT_PERIOD: must be greater than zero when RATE_LIMIT_ENABLED is true")
+		}
+	}
+	if c.RateLimitStore != "memory" {
+		add("RATE_LIMIT_STORE: unsupported store %q (use memory)", c.RateLimitStore)
+	}
+	if c.IdempotencyTTL <= 0 {
+		add("IDEMPOTENCY_TTL: must be greater than zero")
+	}
+
+	if c.AuthEnabled && len(c.AuthTokenSecret) < minAuthTokenSecretLength {
+		add("AUTH_TOKEN_SECRET: must have at least %d characters when AUTH_ENABLED is true", minAuthTokenSecretLength)
+	}
+	if strings.TrimSpace(c.AuthTokenIssuer) == "" {
+		add("AUTH_TOKEN_ISSUER: is required")
+	}
+	if c.AuthTokenTTL <= 0 {
+		add("AUTH_TOKEN_TTL: must be greater than zero")
+	}
+	if c.SessionTTL < c.AuthTokenTTL {
+		add("SESSION_TTL: must be at least AUTH_TOKEN_TTL")
+	}
+
+	if issuer, err := url.Parse(c.OIDCIssuer); err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" ||
+		issuer.RawQuery != "" || issuer.Fragment != "" || strings.HasSuffix(c.OIDCIssuer, "/") {
+		add("OIDC_ISSUER: must be an absolute http(s) URL without query, fragment or trailing slash")
+	}
+	for _, ttl := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"OIDC_CODE_TTL", c.OIDCCodeTTL},
+		{"OIDC_TOKEN_TTL", c.OIDCTokenTTL},
+		{"OIDC_KEY_ROTATION_INTERVAL", c.OIDCKeyRotationInterval},
+	} {
+		if ttl.value <= 0 {
+			add("%s: must be greater than zero", ttl.key)
+		}
+	}
+
+	if key, err := base64.StdEncoding.DecodeString(c.OIDCKeyEncryptionKey); c.OIDCKeyEncryptionKey == "" || err != nil || len(key) != oidcKeyEncryptionKeySize {
+		add("OIDC_KEY_ENCRYPTION_KEY: must be %d random bytes encoded in base64 (e.g. openssl rand -base64 32)", oidcKeyEncryptionKeySize)
+	}
+
+	if strings.TrimSpace(c.MFAIssuer) == "" || strings.Contains(c.MFAIssuer, ":") {
+		add("MFA_ISSUER: must not be empty or contain a colon")
+	}
+
+	if c.InvitationTTL <= 0 {
+		add("INVITATION_TTL: must be greater than zero")
+	}
+	for _, link := range []struct {
+		key   string
+		value string
+	}{
+		{"INVITATION_URL", c.InvitationURL},
+		{"PASSWORD_RESET_URL", c.PasswordResetURL},
+	} {
+		if parsed, err := url.Parse(link.value); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" ||
+			!strings.Contains(link.value, "{token}") {
+			add("%s: must be an absolute http(s) URL containing {token}", link.key)
+		}
+	}
+	if c.PasswordResetTTL <= 0 {
+		add("PASSWORD_RESET_TTL: must be greater than zero")
+	}
+	if c.PasswordResetMaxRequests == 0 {
+		add("PASSWORD_RESET_MAX_REQUESTS: must be greater than zero")
+	}
+	if _, err := mail.ParseAddress(c.MailFrom); err != nil {
+		add("MAIL_FROM: invalid address %q", c.MailFrom)
+	}
+	switch c.Mailer {
+	case "smtp":
+		if c.SMTPHost == "" {
+			add("SMTP_HOST: is required when MAILER is smtp")
+		}
+		if n, err := strconv.Atoi(c.SMTPPort); err != nil || n < 1 || n > 65535 {
+			add("SMTP_PORT: invalid port %q", c.SMTPPort)
+		}
+	case "file":
+		if c.MailerFileDir == "" {
+			add("MAILER_FILE_DIR: is required when MAILER is file")
+		}
+	case "log":
+	case "":
+		add("MAILER: is required (smtp, file or log)")
+	default:
+		add("MAILER: must be smtp, file or log")
+	}
+
+	if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+		add("LOG_LEVEL: invalid level %q", c.LogLevel)
+	}
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		add("LOG_FORMAT: must be json or text")
+	}
+
+	switch c.TracingExporter {
+	case "otlp":
+		if c.OTLPEndpoint == "" {
+			add("OTEL_EXPORTER_OTLP_ENDPOINT: is required when OTEL_TRACES_EXPORTER is otlp")
+		}
+	case "stdout", "none":
+	default:
+		add("OTEL_TRACES_EXPORTER: must be otlp, stdout or none")
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		add("OTEL_TRACES_SAMPLER_ARG: must be between 0.0 and 1.0")
+	}
+
+	return problems
+}
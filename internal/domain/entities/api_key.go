@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	// ErrAPIKeyRevoked é retornado ao usar ou revogar uma API key já revogada
+	ErrAPIKeyRevoked = errors.New("API key has been revoked")
+	// ErrAPIKeyExpired é retornado ao usar uma API key depois de expires_at
+	ErrAPIKeyExpired = errors.New("API key has expired")
+)
+
+// APIKey é uma credencial de longa duração para integrações entre serviços. O segredo só é
+// mostrado na criação; o banco guarda apenas o hash (KeyHash) e o início do segredo (Prefix),
+// que permite reconhecer a chave em listagens e logs
+type APIKey struct {
+	ID         bson.ObjectID `bson:"_id,omitempty"`
+	TenantID   string        `bson:"tenant_id"`
+	Name       string        `bson:"name"`
+	Prefix     string        `bson:"prefix"`
+	KeyHash    string        `bson:"key_hash"`
+	Scopes     []string      `bson:"scopes"`
+	ExpiresAt  *time.Time    `bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `bson:"revoked_at,omitempty"`
+	RevokedBy  string        `bson:"revoked_by,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+}
+
+// SetTenantID grava o tenant ao qual a chave pertence
+func (k *APIKey) SetTenantID(tenantID string) {
+	k.TenantID = tenantID
+}
+
+// Usable retorna ErrAPIKeyRevoked ou ErrAPIKeyExpired se a chave não puder mais autenticar em now
+func (k *APIKey) Usable(now time.Time) error {
+	if k.RevokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
+
+// Revoke invalida a chave definitivamente; revogar duas vezes retorna ErrAPIKeyRevoked
+func (k *APIKey) Revoke(at time.Time, by string) error {
+	if k.RevokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+	k.RevokedAt = &at
+	k.RevokedBy = by
+	k.UpdatedAt = at
+	k.UpdatedBy = by
+	return nil
+}
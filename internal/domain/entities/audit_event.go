@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Ações registradas no log de auditoria
+const (
+	AuditPasswordReset = "user.password_reset"
+)
+
+// AuditEvent registra uma operação sensível: quem a executou, sobre qual usuário e de onde
+type AuditEvent struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	TenantID  string        `bson:"tenant_id"`
+	Action    string        `bson:"action"`
+	ActorID   string        `bson:"actor_id"`
+	UserID    string        `bson:"user_id,omitempty"`
+	IP        string        `bson:"ip,omitempty"`
+	UserAgent string        `bson:"user_agent,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+// SetTenantID grava o tenant em que a operação aconteceu
+func (e *AuditEvent) SetTenantID(tenantID string) {
+	e.TenantID = tenantID
+}
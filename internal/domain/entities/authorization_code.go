@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuthorizationCode é o código emitido pelo endpoint de autorização e trocado uma única vez por
+// tokens. Guarda tudo o que a troca precisa conferir: cliente, redirect_uri e o code_challenge
+// do PKCE. Como nas API keys, apenas o hash do código é gravado
+type AuthorizationCode struct {
+	ID            bson.ObjectID `bson:"_id,omitempty"`
+	TenantID      string        `bson:"tenant_id"`
+	CodeHash      string        `bson:"code_hash"`
+	ClientID      string        `bson:"client_id"`
+	UserID        string        `bson:"user_id"`
+	RedirectURI   string        `bson:"redirect_uri"`
+	Scopes        []string      `bson:"scopes"`
+	Nonce         string        `bson:"nonce,omitempty"`
+	CodeChallenge string        `bson:"code_challenge"`
+	ExpiresAt     time.Time     `bson:"expires_at"`
+	CreatedAt     time.Time     `bson:"created_at"`
+}
+
+// SetTenantID grava o tenant em que o código foi emitido
+func (c *AuthorizationCode) SetTenantID(tenantID string) {
+	c.TenantID = tenantID
+}
+
+// Expired indica se o código não pode mais ser trocado em now
+func (c *AuthorizationCode) Expired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
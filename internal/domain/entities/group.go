@@ -1,9 +1,40 @@
 package entities
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrGroupModified é retornado quando o grupo continua sendo alterado por outras requisições
+// durante uma operação que depende do estado lido
+var ErrGroupModified = errors.New("group was modified by another request, try again")
 
 type Group struct {
-	ID      bson.ObjectID `bson:"_id,omitempty"`
-	Name    string        `bson:"name"`
-	Members []string      `bson:"members"`
+	ID bson.ObjectID `bson:"_id,omitempty"`
+	// TenantID é preenchido pelo repositório; membros e subgrupos são sempre do mesmo tenant
+	TenantID string        `bson:"tenant_id"`
+	Name     string        `bson:"name"`
+	Members  []GroupMember `bson:"members"`
+	// Subgroups são os grupos aninhados; seus membros também são membros efetivos deste grupo.
+	// Ficam como ObjectID para que o $graphLookup os ligue ao _id dos grupos
+	Subgroups []bson.ObjectID `bson:"subgroups"`
+	// RequireMFA obriga os membros efetivos do grupo a usar o segundo fator (TOTP)
+	RequireMFA bool `bson:"require_mfa,omitempty"`
+
+	// Auditoria: preenchidos pelos casos de uso com o relógio injetado e o principal da requisição
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+
+	// Soft delete: documentos com DeletedAt preenchido são ignorados pelas leituras
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty"`
+}
+
+// SetTenantID associa o grupo ao tenant em que será gravado
+func (g *Group) SetTenantID(tenantID string) {
+	g.TenantID = tenantID
 }
@@ -0,0 +1,46 @@
+package entities
+
+import "errors"
+
+// ErrGroupCycle é retornado ao aninhar um grupo dentro de si mesmo ou de um de seus subgrupos
+var ErrGroupCycle = errors.New("group cannot contain itself, directly or through its subgroups")
+
+// ExpandGroups percorre a hierarquia em largura a partir de start, seguindo next para obter os
+// vizinhos de cada grupo (subgrupos para descer, grupos pais para subir), e retorna os IDs
+// alcançados em ordem de descoberta, sem repetições. Os grupos de start só aparecem no
+// resultado se forem alcançados de novo, o que indica um ciclo.
+//
+// É o equivalente em memória do $graphLookup usado pelo repositório MongoDB, para backends sem
+// travessia de grafo nativa; o conjunto de visitados garante o término mesmo com ciclos
+func ExpandGroups(start []string, next func(groupID string) []string) []string {
+	visited := make(map[string]bool)
+	var reached []string
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range next(current) {
+			if visited[neighbor] {
+				continue
+			}
+			visited[neighbor] = true
+			reached = append(reached, neighbor)
+			queue = append(queue, neighbor)
+		}
+	}
+	return reached
+}
+
+// CheckSubgroup retorna ErrGroupCycle se aninhar subgroupID em groupID criaria um ciclo, dados os
+// subgrupos que já descendem de subgroupID
+func CheckSubgroup(groupID, subgroupID string, descendantsOfSubgroup []string) error {
+	if groupID == subgroupID {
+		return ErrGroupCycle
+	}
+	for _, id := range descendantsOfSubgroup {
+		if id == groupID {
+			return ErrGroupCycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// GroupRole é o papel de um usuário dentro de um grupo
+type GroupRole string
+
+const (
+	// GroupRoleOwner gerencia todos os membros e papéis do grupo
+	GroupRoleOwner GroupRole = "owner"
+	// GroupRoleManager adiciona e remove membros comuns, mas não altera papéis
+	GroupRoleManager GroupRole = "manager"
+	GroupRoleMember  GroupRole = "member"
+)
+
+// ErrLastOwner é retornado quando uma alteração deixaria o grupo sem nenhum owner
+var ErrLastOwner = errors.New("group must keep at least one owner")
+
+// GroupMember é a participação de um usuário em um grupo
+type GroupMember struct {
+	UserID   string    `bson:"user_id"`
+	Role     GroupRole `bson:"role"`
+	JoinedAt time.Time `bson:"joined_at"`
+}
+
+// IsValid indica se o papel é um dos papéis conhecidos
+func (r GroupRole) IsValid() bool {
+	switch r {
+	case GroupRoleOwner, GroupRoleManager, GroupRoleMember:
+		return true
+	}
+	return false
+}
+
+// CanManage indica se quem tem o papel r pode adicionar, remover ou atribuir um membro com o
+// papel target: owners gerenciam qualquer papel e managers apenas membros comuns
+func (r GroupRole) CanManage(target GroupRole) bool {
+	switch r {
+	case GroupRoleOwner:
+		return true
+	case GroupRoleManager:
+		return target == GroupRoleMember
+	}
+	return false
+}
+
+// Member retorna a participação do usuário no grupo, se ele for membro direto
+func (g *Group) Member(userID string) (GroupMember, bool) {
+	for _, member := range g.Members {
+		if member.UserID == userID {
+			return member, true
+		}
+	}
+	return GroupMember{}, false
+}
+
+// AddMembers acrescenta os usuários que ainda não são membros diretos, com o papel informado
+func (g *Group) AddMembers(userIDs []string, role GroupRole, joinedAt time.Time) {
+	for _, userID := range userIDs {
+		if _, ok := g.Member(userID); ok {
+			continue
+		}
+		g.Members = append(g.Members, GroupMember{UserID: userID, Role: role, JoinedAt: joinedAt})
+	}
+}
+
+// MemberIDs retorna os IDs dos membros diretos do grupo
+func (g *Group) MemberIDs() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		ids = append(ids, member.UserID)
+	}
+	return ids
+}
+
+// CountOwners retorna quantos membros do grupo são owners
+func (g *Group) CountOwners() int {
+	count := 0
+	for _, member := range g.Members {
+		if member.Role == GroupRoleOwner {
+			count++
+		}
+	}
+	return count
+}
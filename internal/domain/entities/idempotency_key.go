@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var ErrIdempotencyKeyInUse = errors.New("idempotency key already in use")
+
+// IdempotencyKey guarda a primeira requisição feita com um cabeçalho Idempotency-Key. Key combina
+// o cliente com a chave enviada, então clientes diferentes podem usar a mesma chave. Fingerprint é
+// o hash da requisição; a resposta (StatusCode, ContentType e Body) é gravada quando ela termina,
+// em CompletedAt, e repetida nas retentativas até ExpiresAt
+type IdempotencyKey struct {
+	ID          bson.ObjectID `bson:"_id,omitempty"`
+	Key         string        `bson:"key"`
+	Fingerprint string        `bson:"fingerprint"`
+	StatusCode  int           `bson:"status_code,omitempty"`
+	ContentType string        `bson:"content_type,omitempty"`
+	Body        []byte        `bson:"body,omitempty"`
+	CreatedAt   time.Time     `bson:"created_at"`
+	CompletedAt *time.Time    `bson:"completed_at,omitempty"`
+	ExpiresAt   time.Time     `bson:"expires_at"`
+}
+
+// Completed indica se a resposta da requisição já foi gravada
+func (k *IdempotencyKey) Completed() bool {
+	return k.CompletedAt != nil
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	// ErrInvitationExpired é retornado ao aceitar um convite depois de expires_at
+	ErrInvitationExpired = errors.New("invitation has expired")
+	// ErrInvitationAccepted é retornado ao aceitar um convite já usado
+	ErrInvitationAccepted = errors.New("invitation has already been accepted")
+)
+
+// Invitation é o convite enviado por email a um usuário criado como pending. O token só existe
+// no email; o banco guarda apenas a assinatura dele (TokenHash). O convite vale uma única vez
+// e até ExpiresAt
+type Invitation struct {
+	ID         bson.ObjectID `bson:"_id,omitempty"`
+	TenantID   string        `bson:"tenant_id"`
+	UserID     string        `bson:"user_id"`
+	Email      string        `bson:"email"`
+	TokenHash  string        `bson:"token_hash"`
+	ExpiresAt  time.Time     `bson:"expires_at"`
+	AcceptedAt *time.Time    `bson:"accepted_at,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+}
+
+// SetTenantID grava o tenant do usuário convidado
+func (i *Invitation) SetTenantID(tenantID string) {
+	i.TenantID = tenantID
+}
+
+// Usable retorna ErrInvitationAccepted ou ErrInvitationExpired se o convite não puder mais ser
+// aceito em now
+func (i *Invitation) Usable(now time.Time) error {
+	if i.AcceptedAt != nil {
+		return ErrInvitationAccepted
+	}
+	if !now.Before(i.ExpiresAt) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrMFAAlreadyEnabled é retornado ao iniciar um cadastro de TOTP para um usuário que já o ativou
+var ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+
+// MFAEnrollment é o segundo fator (TOTP, RFC 6238) de um usuário. O cadastro começa pendente e só
+// passa a valer quando o usuário confirma um código gerado pelo aplicativo (EnabledAt).
+// O segredo precisa ser lido para validar os códigos; os códigos de recuperação, de uso único,
+// são guardados apenas como hash. LastUsedStep é o último intervalo de 30s aceito e impede que
+// o mesmo código seja usado duas vezes. FailedAttempts conta as tentativas sem sucesso desde o
+// último código aceito; depois de algumas, LockedUntil bloqueia novas tentativas
+type MFAEnrollment struct {
+	ID             bson.ObjectID `bson:"_id,omitempty"`
+	TenantID       string        `bson:"tenant_id"`
+	UserID         string        `bson:"user_id"`
+	Secret         string        `bson:"secret"`
+	RecoveryCodes  []string      `bson:"recovery_codes"`
+	LastUsedStep   int64         `bson:"last_used_step"`
+	FailedAttempts int           `bson:"failed_attempts"`
+	LockedUntil    *time.Time    `bson:"locked_until,omitempty"`
+	EnabledAt      *time.Time    `bson:"enabled_at,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// SetTenantID grava o tenant do usuário dono do cadastro
+func (e *MFAEnrollment) SetTenantID(tenantID string) {
+	e.TenantID = tenantID
+}
+
+// Enabled indica se o cadastro foi confirmado e o segundo fator é exigido
+func (e *MFAEnrollment) Enabled() bool {
+	return e.EnabledAt != nil
+}
+
+// Locked indica se as tentativas de verificação estão bloqueadas em now
+func (e *MFAEnrollment) Locked(now time.Time) bool {
+	return e.LockedUntil != nil && now.Before(*e.LockedUntil)
+}
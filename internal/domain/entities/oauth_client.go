@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// OAuthClient é uma aplicação registrada no provedor OpenID Connect. Clientes confidenciais
+// (com SecretHash) se autenticam no endpoint de token com o segredo; clientes públicos (SPAs,
+// apps nativos) dependem apenas do PKCE. Assim como nas API keys, o banco guarda só o hash do segredo
+type OAuthClient struct {
+	ID           bson.ObjectID `bson:"_id,omitempty"`
+	TenantID     string        `bson:"tenant_id"`
+	ClientID     string        `bson:"client_id"`
+	Name         string        `bson:"name"`
+	SecretHash   string        `bson:"secret_hash,omitempty"`
+	RedirectURIs []string      `bson:"redirect_uris"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+}
+
+// SetTenantID grava o tenant ao qual o cliente pertence
+func (c *OAuthClient) SetTenantID(tenantID string) {
+	c.TenantID = tenantID
+}
+
+// Public indica se o cliente não tem segredo
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+// AllowsRedirect indica se uri é exatamente uma das redirect_uris registradas
+func (c *OAuthClient) AllowsRedirect(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// PasswordReset é um pedido de redefinição de senha. O token só existe no email; o banco guarda
+// apenas o hash (TokenHash). O token vale uma única vez e até ExpiresAt
+type PasswordReset struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	TenantID  string        `bson:"tenant_id"`
+	UserID    string        `bson:"user_id"`
+	TokenHash string        `bson:"token_hash"`
+	ExpiresAt time.Time     `bson:"expires_at"`
+	UsedAt    *time.Time    `bson:"used_at,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+// SetTenantID grava o tenant do usuário
+func (r *PasswordReset) SetTenantID(tenantID string) {
+	r.TenantID = tenantID
+}
+
+// Usable indica se o token ainda pode ser usado em now
+func (r *PasswordReset) Usable(now time.Time) bool {
+	return r.UsedAt == nil && now.Before(r.ExpiresAt)
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RefreshToken é um refresh token de uma sessão. O token só é entregue ao cliente; o banco guarda
+// apenas o hash (TokenHash). Cada token vale uma única vez: o uso emite o próximo, e apresentar um
+// token já usado revoga a sessão inteira (detecção de reuso)
+type RefreshToken struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	TenantID  string        `bson:"tenant_id"`
+	SessionID string        `bson:"session_id"`
+	UserID    string        `bson:"user_id"`
+	TokenHash string        `bson:"token_hash"`
+	ExpiresAt time.Time     `bson:"expires_at"`
+	UsedAt    *time.Time    `bson:"used_at,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+// SetTenantID grava o tenant da sessão
+func (t *RefreshToken) SetTenantID(tenantID string) {
+	t.TenantID = tenantID
+}
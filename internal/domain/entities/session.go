@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Session é uma sessão de login de um usuário. Os access tokens emitidos para ela carregam o seu
+// ID (claim sid) e deixam de valer quando ela é revogada ou expira; a sessão é renovada com
+// refresh tokens (RefreshToken). IP e UserAgent são os do último uso
+type Session struct {
+	ID         bson.ObjectID `bson:"_id,omitempty"`
+	TenantID   string        `bson:"tenant_id"`
+	UserID     string        `bson:"user_id"`
+	IP         string        `bson:"ip,omitempty"`
+	UserAgent  string        `bson:"user_agent,omitempty"`
+	MFA        bool          `bson:"mfa"`
+	CreatedAt  time.Time     `bson:"created_at"`
+	LastUsedAt time.Time     `bson:"last_used_at"`
+	ExpiresAt  time.Time     `bson:"expires_at"`
+	RevokedAt  *time.Time    `bson:"revoked_at,omitempty"`
+}
+
+// SetTenantID grava o tenant do usuário
+func (s *Session) SetTenantID(tenantID string) {
+	s.TenantID = tenantID
+}
+
+// Active indica se a sessão ainda vale em now
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SigningKey é um par de chaves usado para assinar os tokens do provedor OpenID Connect. A chave
+// mais recente assina; as anteriores só são publicadas no JWKS enquanto houver tokens válidos
+// assinados com elas. PrivateKey é a chave privada em PEM (PKCS#8), cifrada pelo KeyManager
+type SigningKey struct {
+	ID         bson.ObjectID `bson:"_id,omitempty"`
+	KID        string        `bson:"kid"`
+	Algorithm  string        `bson:"algorithm"`
+	PrivateKey string        `bson:"private_key"`
+	CreatedAt  time.Time     `bson:"created_at"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	// ErrTenantNotEmpty é retornado ao remover um tenant que ainda tem usuários ou grupos
+	ErrTenantNotEmpty = errors.New("tenant still has users or groups")
+	// ErrDefaultTenant é retornado ao remover o tenant padrão
+	ErrDefaultTenant = errors.New("the default tenant cannot be deleted")
+	// ErrTenantExists é retornado ao criar um tenant com um slug já usado
+	ErrTenantExists = errors.New("tenant already exists")
+)
+
+// Tenant é um cliente isolado na mesma instalação. O Slug é o valor gravado em tenant_id nos
+// usuários e grupos, usado no cabeçalho X-Tenant-ID, no subdomínio e na claim tenant do token;
+// por isso não pode ser alterado
+type Tenant struct {
+	ID   bson.ObjectID `bson:"_id,omitempty"`
+	Slug string        `bson:"slug"`
+	Name string        `bson:"name"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+}
@@ -1,10 +1,48 @@
 package entities
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrEmailTaken é retornado ao gravar um email já usado por outro usuário do mesmo tenant
+var ErrEmailTaken = errors.New("email already in use")
 
 type User struct {
-	ID       bson.ObjectID `bson:"_id,omitempty"`
-	Name     string        `bson:"name"`
-	Email    string        `bson:"email"`
-	IsActive bool          `bson:"is_active"`
+	ID bson.ObjectID `bson:"_id,omitempty"`
+	// TenantID é preenchido pelo repositório com o tenant da operação; o email é único por tenant
+	TenantID string `bson:"tenant_id"`
+	Name     string `bson:"name"`
+	Email    string `bson:"email"`
+
+	// PasswordHash é o hash bcrypt da senha, definida ao aceitar o convite ou ao redefini-la; vazio
+	// se o usuário não tiver senha. EmailVerifiedAt é preenchido quando o usuário comprova o email aceitando o convite
+	PasswordHash    string     `bson:"password_hash,omitempty"`
+	EmailVerifiedAt *time.Time `bson:"email_verified_at,omitempty"`
+	// SessionsRevokedAt invalida os access tokens do usuário emitidos antes dele (ex.: ao redefinir
+	// a senha)
+	SessionsRevokedAt *time.Time `bson:"sessions_revoked_at,omitempty"`
+
+	// Status e os dados da última transição (motivo, quando e por quem)
+	Status          UserStatus `bson:"status"`
+	StatusReason    string     `bson:"status_reason,omitempty"`
+	StatusChangedAt *time.Time `bson:"status_changed_at,omitempty"`
+	StatusChangedBy string     `bson:"status_changed_by,omitempty"`
+
+	// Auditoria: preenchidos pelos casos de uso com o relógio injetado e o principal da requisição
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+	CreatedBy string    `bson:"created_by,omitempty"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+
+	// Soft delete: documentos com DeletedAt preenchido são ignorados pelas leituras
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+	DeletedBy string     `bson:"deleted_by,omitempty"`
+}
+
+// SetTenantID associa o usuário ao tenant em que será gravado
+func (u *User) SetTenantID(tenantID string) {
+	u.TenantID = tenantID
 }
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UserStatus é o estado do ciclo de vida de um usuário
+type UserStatus string
+
+const (
+	// UserStatusPending é o estado inicial de contas criadas mas ainda não ativadas
+	UserStatusPending UserStatus = "pending"
+	UserStatusActive  UserStatus = "active"
+	// UserStatusSuspended bloqueia temporariamente o acesso; a conta pode ser reativada
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusDeprovisioned é final: a conta foi desligada e removida de todos os grupos
+	UserStatusDeprovisioned UserStatus = "deprovisioned"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// userStatusTransitions lista, para cada estado, os estados para os quais ele pode ir:
+//
+//	pending → active → suspended → active
+//	active/suspended → deprovisioned
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	UserStatusPending:   {UserStatusActive},
+	UserStatusActive:    {UserStatusSuspended, UserStatusDeprovisioned},
+	UserStatusSuspended: {UserStatusActive, UserStatusDeprovisioned},
+}
+
+// IsValid indica se o status é um dos estados conhecidos
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusPending, UserStatusActive, UserStatusSuspended, UserStatusDeprovisioned:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo indica se a transição de s para next é permitida
+func (s UserStatus) CanTransitionTo(next UserStatus) bool {
+	for _, allowed := range userStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransition retorna ErrInvalidStatusTransition (com os estados envolvidos) se a
+// transição de s para next não for permitida
+func (s UserStatus) CheckTransition(next UserStatus) error {
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, s, next)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IAPIKeyRepository guarda as API keys, que pertencem a um tenant como usuários e grupos: todas
+// as operações são restritas ao tenant do contexto, exceto GetByHash
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, id string) (*entities.APIKey, error)
+	// GetByHash busca a chave em qualquer tenant; é usado na autenticação, que acontece antes de
+	// o tenant da requisição ser conhecido e define esse tenant a partir da própria chave
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	List(ctx context.Context, offset int64, limit int64) ([]*entities.APIKey, error)
+	Count(ctx context.Context) (int64, error)
+	// Revoke grava revoked_at/revoked_by; retorna mongo.ErrNoDocuments se a chave não existir ou
+	// já estiver revogada
+	Revoke(ctx context.Context, key *entities.APIKey) error
+	// TouchLastUsed registra o uso em last_used_at, no máximo uma vez a cada resolution, para não
+	// gravar a cada requisição
+	TouchLastUsed(ctx context.Context, key *entities.APIKey, usedAt time.Time, resolution time.Duration) error
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"user-management/internal/domain/entities"
+)
+
+// IAuditEventRepository grava o log de auditoria no tenant do contexto
+type IAuditEventRepository interface {
+	Create(ctx context.Context, event *entities.AuditEvent) error
+}
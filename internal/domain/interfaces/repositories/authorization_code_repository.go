@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"user-management/internal/domain/entities"
+)
+
+// IAuthorizationCodeRepository guarda os códigos de autorização pendentes
+type IAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *entities.AuthorizationCode) error
+	// Consume busca e apaga o código em uma única operação, em qualquer tenant: a troca não é
+	// autenticada por tenant, e o código define o tenant dos tokens. Retorna mongo.ErrNoDocuments
+	// se o código não existir ou já tiver sido usado
+	Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error)
+}
@@ -2,16 +2,67 @@ package repositories
 
 import (
 	"context"
+	"time"
 	"user-management/internal/domain/entities"
 )
 
+// IGroupRepository ignora grupos removidos (soft delete) nas leituras, exceto quando
+// filter.IncludeDeleted é true ou no método GetByIDIncludingDeleted.
+// As alterações de membros e subgrupos também atualizam updated_at/updated_by do grupo.
+//
+// Grupos podem conter outros grupos (Subgroups). Os membros efetivos de um grupo são os seus e os
+// de todos os subgrupos alcançáveis; os grupos efetivos de um usuário são os grupos dos quais ele
+// é membro direto e todos os seus ancestrais. Grupos removidos interrompem a travessia.
+// Implementações sem travessia de grafo nativa podem usar entities.ExpandGroups.
+//
+// Assim como em IUserRepository, as operações (inclusive as travessias e a resolução dos membros)
+// valem apenas no tenant do contexto
 type IGroupRepository interface {
 	Create(ctx context.Context, group *entities.Group) error
 	GetByID(ctx context.Context, id string) (*entities.Group, error)
-	List(ctx context.Context, offset int64, limit int64) ([]*entities.Group, error)
-	Count(ctx context.Context) (int64, error)
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*entities.Group, error)
+	List(ctx context.Context, offset int64, limit int64, filter ListFilter) ([]*entities.Group, error)
+	Count(ctx context.Context, filter ListFilter) (int64, error)
 	Update(ctx context.Context, group *entities.Group) error
-	Delete(ctx context.Context, id string) error
-	AddUserToGroup(ctx context.Context, groupID, userID string) error
-	RemoveUserFromGroup(ctx context.Context, groupID, userID string) error
+	// SetRequireMFA altera a política de MFA do grupo; retorna mongo.ErrNoDocuments se ele não existir
+	SetRequireMFA(ctx context.Context, groupID string, required bool, updatedAt time.Time, updatedBy string) error
+	Delete(ctx context.Context, id string, deletedAt time.Time, deletedBy string) error
+	Restore(ctx context.Context, id string, restoredAt time.Time, restoredBy string) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	// AddUserToGroup não altera o grupo se o usuário já for membro
+	AddUserToGroup(ctx context.Context, groupID string, member entities.GroupMember, updatedAt time.Time, updatedBy string) error
+	// RemoveUserFromGroup e UpdateMemberRole retornam mongo.ErrNoDocuments se o usuário não for
+	// membro ou se a alteração deixaria o grupo sem owner; a verificação é atômica
+	RemoveUserFromGroup(ctx context.Context, groupID, userID string, updatedAt time.Time, updatedBy string) error
+	UpdateMemberRole(ctx context.Context, groupID, userID string, role entities.GroupRole, updatedAt time.Time, updatedBy string) error
+	// AddMembers acrescenta em um único update os membros que ainda não estão no grupo
+	AddMembers(ctx context.Context, groupID string, members []entities.GroupMember, updatedAt time.Time, updatedBy string) error
+	// RemoveMembers retorna mongo.ErrNoDocuments se o grupo não existir ou se remover os usuários
+	// deixaria o grupo sem owner
+	RemoveMembers(ctx context.Context, groupID string, userIDs []string, updatedAt time.Time, updatedBy string) error
+	// ReplaceMembers grava members no lugar dos membros de current somente se o grupo ainda estiver
+	// como foi lido (mesmo updated_at e mesmos membros); caso contrário, ou se o grupo não existir
+	// mais, retorna mongo.ErrNoDocuments
+	ReplaceMembers(ctx context.Context, current *entities.Group, members []entities.GroupMember, updatedAt time.Time, updatedBy string) error
+	RemoveUsersFromAllGroups(ctx context.Context, userIDs []string) error
+	AddSubgroup(ctx context.Context, groupID, subgroupID string, updatedAt time.Time, updatedBy string) error
+	RemoveSubgroup(ctx context.Context, groupID, subgroupID string, updatedAt time.Time, updatedBy string) error
+	// RemoveSubgroupsFromAllGroups desfaz o aninhamento dos grupos em todos os pais, inclusive os
+	// removidos (usado quando os grupos são apagados definitivamente)
+	RemoveSubgroupsFromAllGroups(ctx context.Context, groupIDs []string) error
+	// GetDescendantIDs retorna os IDs de todos os subgrupos alcançáveis a partir do grupo,
+	// inclusive os removidos, para que restaurar um grupo nunca crie um ciclo
+	GetDescendantIDs(ctx context.Context, groupID string) ([]string, error)
+	// ListMembers retorna os membros diretos do grupo como usuários não removidos, filtrados
+	// por nome ou email quando search não é vazio, ordenados pelo nome
+	ListMembers(ctx context.Context, groupID, search string, offset int64, limit int64) ([]*entities.User, int64, error)
+	// ListUserGroups retorna os grupos não removidos dos quais o usuário é membro direto,
+	// filtrados pelo nome quando search não é vazio
+	ListUserGroups(ctx context.Context, userID, search string, offset int64, limit int64) ([]*entities.Group, int64, error)
+	// ListEffectiveMembers retorna os usuários não removidos que são membros do grupo ou de
+	// algum de seus subgrupos, sem repetições, ordenados pelo ID
+	ListEffectiveMembers(ctx context.Context, groupID string, offset int64, limit int64) ([]*entities.User, int64, error)
+	// ListEffectiveGroups retorna os grupos dos quais o usuário é membro direto ou indireto,
+	// ordenados pelo nome
+	ListEffectiveGroups(ctx context.Context, userID string, offset int64, limit int64) ([]*entities.Group, int64, error)
 }
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IIdempotencyKeyRepository guarda as requisições feitas com Idempotency-Key. A chave já inclui o
+// cliente (e o tenant dele), então as operações não são restritas ao tenant do contexto
+type IIdempotencyKeyRepository interface {
+	// Create grava a chave como em andamento; retorna entities.ErrIdempotencyKeyInUse se ela já
+	// existir
+	Create(ctx context.Context, key *entities.IdempotencyKey) error
+	GetByKey(ctx context.Context, key string) (*entities.IdempotencyKey, error)
+	// Complete grava a resposta da chave em andamento; caso contrário retorna mongo.ErrNoDocuments
+	Complete(ctx context.Context, id string, statusCode int, contentType string, body []byte, completedAt time.Time) error
+	// Delete libera a chave, para que a requisição possa ser repetida
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IInvitationRepository guarda os convites dos usuários pending. As operações são restritas ao
+// tenant do contexto, exceto GetByHash
+type IInvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.Invitation) error
+	// GetByHash busca o convite em qualquer tenant; é usado ao aceitar o convite, em uma rota
+	// pública em que o tenant vem do próprio convite
+	GetByHash(ctx context.Context, tokenHash string) (*entities.Invitation, error)
+	// Accept grava accepted_at somente se o convite ainda não tiver sido aceito nem expirado em
+	// acceptedAt; caso contrário retorna mongo.ErrNoDocuments
+	Accept(ctx context.Context, id string, acceptedAt time.Time) error
+	// DeletePending remove os convites ainda não aceitos do usuário, invalidando os links enviados
+	DeletePending(ctx context.Context, userID string) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import "time"
+
+// Campos aceitos em ListFilter.SortBy
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByName      = "name"
+	SortByEmail     = "email" // apenas usuários
+)
+
+// ListFilter reúne os filtros e a ordenação comuns às listagens. Os limites de data são
+// opcionais (nil ignora o filtro); "after" é inclusivo e "before" é exclusivo
+type ListFilter struct {
+	IncludeDeleted bool
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	// SortBy vazio mantém a ordem de inserção
+	SortBy   string
+	SortDesc bool
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IMFAEnrollmentRepository guarda no máximo um cadastro de TOTP por usuário, no tenant do
+// contexto. As alterações condicionais retornam mongo.ErrNoDocuments quando o usuário não tem
+// cadastro ou a condição não é satisfeita
+type IMFAEnrollmentRepository interface {
+	// Create substitui um cadastro pendente do usuário; retorna entities.ErrMFAAlreadyEnabled se
+	// ele já tiver um cadastro confirmado
+	Create(ctx context.Context, enrollment *entities.MFAEnrollment) error
+	GetByUserID(ctx context.Context, userID string) (*entities.MFAEnrollment, error)
+	// Enable confirma o cadastro pendente, gravando os hashes dos códigos de recuperação e o
+	// intervalo do código usado na confirmação
+	Enable(ctx context.Context, userID string, recoveryCodes []string, step int64, enabledAt time.Time) error
+	// RecordAttempt conta uma tentativa de verificação e grava o bloqueio lockedUntil (nil para
+	// nenhum), somente se o cadastro ainda tiver failedAttempts tentativas e não estiver bloqueado
+	// em now; assim tentativas simultâneas não escapam do limite
+	RecordAttempt(ctx context.Context, userID string, failedAttempts int, lockedUntil *time.Time, now time.Time) error
+	// UseStep registra o intervalo de um código aceito somente se for posterior ao último usado e
+	// zera as tentativas e o bloqueio
+	UseStep(ctx context.Context, userID string, step int64) error
+	// UseRecoveryCode remove o hash do código de recuperação somente se ele ainda estiver
+	// disponível e zera as tentativas e o bloqueio
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, usedAt time.Time) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodes []string, updatedAt time.Time) error
+	Delete(ctx context.Context, userID string) error
+	// DeleteByUserIDs apaga os cadastros dos usuários expurgados
+	DeleteByUserIDs(ctx context.Context, userIDs []string) error
+}
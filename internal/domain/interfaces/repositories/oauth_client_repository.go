@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"user-management/internal/domain/entities"
+)
+
+// IOAuthClientRepository guarda os clientes do provedor OpenID Connect; como as API keys, as
+// operações são restritas ao tenant do contexto. client_id é único entre todos os tenants
+type IOAuthClientRepository interface {
+	Create(ctx context.Context, client *entities.OAuthClient) error
+	GetByClientID(ctx context.Context, clientID string) (*entities.OAuthClient, error)
+	List(ctx context.Context, offset int64, limit int64) ([]*entities.OAuthClient, error)
+	Count(ctx context.Context) (int64, error)
+	// Delete retorna mongo.ErrNoDocuments se o cliente não existir no tenant
+	Delete(ctx context.Context, clientID string) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IPasswordResetRepository guarda os pedidos de redefinição de senha. As operações são restritas
+// ao tenant do contexto, exceto GetByHash
+type IPasswordResetRepository interface {
+	Create(ctx context.Context, reset *entities.PasswordReset) error
+	// GetByHash busca o pedido em qualquer tenant; a confirmação é pública e o tenant vem do pedido
+	GetByHash(ctx context.Context, tokenHash string) (*entities.PasswordReset, error)
+	// Use grava used_at somente se o token ainda não tiver sido usado nem expirado em usedAt; caso
+	// contrário retorna mongo.ErrNoDocuments
+	Use(ctx context.Context, id string, usedAt time.Time) error
+	// CountSince conta os pedidos do usuário criados a partir de since
+	CountSince(ctx context.Context, userID string, since time.Time) (int64, error)
+	// DeleteUnused remove os pedidos ainda não usados do usuário
+	DeleteUnused(ctx context.Context, userID string) error
+}
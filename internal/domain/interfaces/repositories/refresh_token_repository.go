@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// IRefreshTokenRepository guarda os refresh tokens das sessões. As operações são restritas ao
+// tenant do contexto, exceto GetByHash
+type IRefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	// GetByHash busca o token em qualquer tenant, inclusive já usado; a renovação é pública e o
+	// tenant vem do token
+	GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// Use grava used_at somente se o token ainda não tiver sido usado; caso contrário retorna
+	// mongo.ErrNoDocuments
+	Use(ctx context.Context, id string, usedAt time.Time) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// ISessionRepository guarda as sessões de login. As operações são restritas ao tenant do contexto
+type ISessionRepository interface {
+	Create(ctx context.Context, session *entities.Session) error
+	GetByID(ctx context.Context, id string) (*entities.Session, error)
+	// ListActive retorna as sessões do usuário não revogadas e não expiradas em now, das usadas
+	// mais recentemente para as mais antigas
+	ListActive(ctx context.Context, userID string, now time.Time) ([]*entities.Session, error)
+	// Touch grava o último uso da sessão e o IP e o User-Agent de onde ela foi usada
+	Touch(ctx context.Context, id string, ip string, userAgent string, usedAt time.Time) error
+	// MarkMFA registra que o usuário confirmou o segundo fator na sessão
+	MarkMFA(ctx context.Context, id string) error
+	// Revoke revoga a sessão do usuário; retorna mongo.ErrNoDocuments se ela não existir ou já
+	// estiver revogada
+	Revoke(ctx context.Context, userID string, id string, revokedAt time.Time) error
+	// RevokeAll revoga todas as sessões ainda não revogadas do usuário e retorna quantas foram
+	// revogadas
+	RevokeAll(ctx context.Context, userID string, revokedAt time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-management/internal/domain/entities"
+)
+
+// ISigningKeyRepository guarda as chaves de assinatura, que valem para todos os tenants
+type ISigningKeyRepository interface {
+	Create(ctx context.Context, key *entities.SigningKey) error
+	// ListCreatedAfter retorna as chaves criadas depois de after, das mais novas para as mais antigas
+	ListCreatedAfter(ctx context.Context, after time.Time) ([]*entities.SigningKey, error)
+	// DeleteCreatedBefore apaga as chaves que não são mais publicadas
+	DeleteCreatedBefore(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"user-management/internal/domain/entities"
+)
+
+// ITenantRepository guarda o cadastro de tenants, que é global (não pertence a nenhum tenant).
+// Os tenants são identificados pelo slug; slugs inexistentes retornam mongo.ErrNoDocuments
+type ITenantRepository interface {
+	// Create retorna entities.ErrTenantExists se o slug já estiver em uso
+	Create(ctx context.Context, tenant *entities.Tenant) error
+	GetBySlug(ctx context.Context, slug string) (*entities.Tenant, error)
+	List(ctx context.Context, offset int64, limit int64) ([]*entities.Tenant, error)
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, tenant *entities.Tenant) error
+	Delete(ctx context.Context, slug string) error
+}
@@ -2,16 +2,45 @@ package repositories
 
 import (
 	"context"
+	"time"
 	"user-management/internal/domain/entities"
 )
 
+// IUserRepository ignora usuários removidos (soft delete) nas leituras, exceto quando
+// filter.IncludeDeleted é true ou no método GetByIDIncludingDeleted.
+//
+// Todas as operações valem apenas no tenant do contexto (tenancy.WithTenant) e falham com
+// tenancy.ErrTenantRequired sem ele. O email é único por tenant: Create e Update retornam
+// entities.ErrEmailTaken quando outro usuário do tenant já o usa
 type IUserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	GetByID(ctx context.Context, id string) (*entities.User, error)
-	List(ctx context.Context, offset int64, limit int64) ([]*entities.User, error)
-	Search(ctx context.Context, searchTerm string, offset int64, limit int64) ([]*entities.User, error)
-	Count(ctx context.Context) (int64, error)
-	CountSearch(ctx context.Context, searchTerm string) (int64, error)
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*entities.User, error)
+	// GetByEmail busca o usuário não removido com exatamente esse email
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	List(ctx context.Context, offset int64, limit int64, filter ListFilter) ([]*entities.User, error)
+	// ListByIDs retorna os usuários não removidos entre os IDs informados, em qualquer ordem;
+	// IDs inexistentes ou malformados são ignorados
+	ListByIDs(ctx context.Context, ids []string) ([]*entities.User, error)
+	Search(ctx context.Context, searchTerm string, offset int64, limit int64, filter ListFilter) ([]*entities.User, error)
+	Count(ctx context.Context, filter ListFilter) (int64, error)
+	CountSearch(ctx context.Context, searchTerm string, filter ListFilter) (int64, error)
+	// Update grava nome e email; remove email_verified_at quando user.EmailVerifiedAt é nil
 	Update(ctx context.Context, user *entities.User) error
-	Delete(ctx context.Context, id string) error
+	// UpdateStatus grava o status e os dados da transição do usuário somente se o status atual
+	// ainda for from; caso contrário (ou se o usuário não existir) retorna mongo.ErrNoDocuments
+	UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error
+	// AcceptInvitation ativa o usuário convidado em uma única escrita: grava o status active e os
+	// dados da transição, email_verified_at e, se preenchido, password_hash. Retorna
+	// mongo.ErrNoDocuments se o usuário não existir ou não estiver mais pending
+	AcceptInvitation(ctx context.Context, user *entities.User) error
+	// UpdatePassword grava password_hash e sessions_revoked_at; retorna mongo.ErrNoDocuments se o
+	// usuário não existir
+	UpdatePassword(ctx context.Context, user *entities.User) error
+	// RevokeSessions grava sessions_revoked_at: os access tokens do usuário emitidos antes de
+	// revokedAt deixam de valer. Retorna mongo.ErrNoDocuments se o usuário não existir
+	RevokeSessions(ctx context.Context, id string, revokedAt time.Time) error
+	Delete(ctx context.Context, id string, deletedAt time.Time, deletedBy string) error
+	Restore(ctx context.Context, id string, restoredAt time.Time, restoredBy string) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error)
 }
@@ -0,0 +1,9 @@
+package services
+
+import "time"
+
+// IClock é a fonte de data/hora usada pelos casos de uso para preencher os campos de auditoria.
+// Injetá-lo permite que os testes controlem o tempo de forma determinística
+type IClock interface {
+	Now() time.Time
+}
@@ -0,0 +1,16 @@
+package services
+
+import "context"
+
+// Message é um email em texto simples
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// IMailer envia os emails transacionais do serviço (convites). A implementação é escolhida pela
+// configuração MAILER: SMTP em produção, log ou arquivos .eml em desenvolvimento e testes
+type IMailer interface {
+	Send(ctx context.Context, message Message) error
+}
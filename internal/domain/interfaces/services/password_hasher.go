@@ -0,0 +1,18 @@
+package services
+
+import "errors"
+
+var (
+	// ErrPasswordMismatch é retornado por IPasswordHasher.Compare quando a senha não confere
+	ErrPasswordMismatch = errors.New("password does not match")
+	// ErrPasswordTooLong é retornado por IPasswordHasher.Hash para senhas maiores que o
+	// algoritmo suporta
+	ErrPasswordTooLong = errors.New("password is too long")
+)
+
+// IPasswordHasher gera e confere os hashes das senhas dos usuários
+type IPasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare retorna ErrPasswordMismatch se password não corresponder a hash
+	Compare(hash, password string) error
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit é a cota de um balde: até Requests requisições seguidas, repostas aos poucos até o
+// balde vazio encher de novo em Period
+type RateLimit struct {
+	Requests uint64
+	Period   time.Duration
+}
+
+// RateLimitResult é o estado do balde depois de uma requisição. ResetAfter é o tempo até o balde
+// encher de novo e RetryAfter, quando a requisição é recusada, o tempo até a próxima ficha
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  uint64
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// IRateLimitStore guarda os baldes do rate limiting. A implementação é escolhida pela
+// configuração RATE_LIMIT_STORE; com várias réplicas, um store compartilhado aplica a mesma cota
+// em todas elas
+type IRateLimitStore interface {
+	// Take consome uma ficha do balde key, se houver, e retorna o estado dele
+	Take(ctx context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+}
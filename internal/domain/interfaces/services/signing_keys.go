@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidToken é retornado por ISigningKeys.Verify para tokens mal formados, com assinatura
+// inválida, expirados ou de outro emissor ou audiência
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// ISigningKeys assina e valida os tokens emitidos pelo provedor OpenID Connect. As chaves são
+// trocadas periodicamente; a implementação escolhe a chave de assinatura e preenche iss
+type ISigningKeys interface {
+	// Sign assina as claims com a chave atual, acrescentando a claim iss
+	Sign(ctx context.Context, claims map[string]interface{}) (string, error)
+	// Verify valida a assinatura com qualquer chave publicada, o emissor, a expiração e a
+	// audiência, e retorna as claims do token
+	Verify(ctx context.Context, token string, audience string) (map[string]interface{}, error)
+}
@@ -0,0 +1,18 @@
+package clock
+
+import (
+	"time"
+	"user-management/internal/domain/interfaces/services"
+)
+
+// SystemClock usa o relógio do sistema em UTC, truncado em milissegundos (a precisão das
+// datas no BSON), para que o valor retornado pela API seja o mesmo lido depois do banco
+type SystemClock struct{}
+
+func NewSystemClock() services.IClock {
+	return SystemClock{}
+}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// createUsersCollection portado de scripts/mongo-init.js. O _id é validado como objectId,
+// que é o tipo usado por entities.User (o script antigo exigia string)
+var createUsersCollection = Migration{
+	Version:     1,
+	Description: "create users collection with schema validator and indexes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "users", usersValidatorV1()); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("users").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetName("email_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name_1")},
+		})
+		return err
+	},
+	// Down não apaga a coleção para não perder dados; apenas remove o validator e os índices
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "users", "email_1", "name_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "users")
+	},
+}
+
+// usersValidatorV1 é o schema da coleção users antes do campo status (migration 005)
+func usersValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "email"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"email": bson.M{
+					"bsonType":    "string",
+					"pattern":     `^.+@.+\..+$`,
+					"description": "must be a valid email address and is required",
+				},
+				"is_active": bson.M{
+					"bsonType":    "bool",
+					"description": "must be a boolean",
+				},
+			},
+		},
+	}
+}
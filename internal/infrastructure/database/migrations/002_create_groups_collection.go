@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// createGroupsCollection portado de scripts/mongo-init.js
+var createGroupsCollection = Migration{
+	Version:     2,
+	Description: "create groups collection with schema validator and indexes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV1()); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("groups").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name_1")},
+			{Keys: bson.D{{Key: "members", Value: 1}}, Options: options.Index().SetName("members_1")},
+		})
+		return err
+	},
+	// Down não apaga a coleção para não perder dados; apenas remove o validator e os índices
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "groups", "name_1", "members_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "groups")
+	},
+}
+
+// groupsValidatorV1 é o schema da coleção groups antes dos subgrupos (migration 006)
+func groupsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "members"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"members": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "string"},
+					"description": "must be an array of strings and is required",
+				},
+			},
+		},
+	}
+}
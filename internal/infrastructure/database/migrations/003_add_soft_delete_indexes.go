@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addSoftDeleteIndexes indexa deleted_at, usado pelas listagens (deleted_at inexistente)
+// e pelo job de expurgo (deleted_at anterior ao período de retenção)
+var addSoftDeleteIndexes = Migration{
+	Version:     3,
+	Description: "add deleted_at indexes for soft delete",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		for _, collection := range []string{"users", "groups"} {
+			_, err := db.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+				Options: options.Index().SetName("deleted_at_1"),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		for _, collection := range []string{"users", "groups"} {
+			if err := dropIndexes(ctx, db, collection, "deleted_at_1"); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
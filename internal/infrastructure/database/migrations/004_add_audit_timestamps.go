@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addAuditTimestamps preenche created_at/updated_at dos documentos anteriores aos campos de
+// auditoria com a data embutida no ObjectID e cria os índices usados em filtros e ordenação
+var addAuditTimestamps = Migration{
+	Version:     4,
+	Description: "backfill created_at/updated_at and add audit indexes",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		for _, collection := range []string{"users", "groups"} {
+			// Pipeline de update: o segundo estágio já enxerga o created_at preenchido pelo primeiro
+			_, err := db.Collection(collection).UpdateMany(ctx,
+				bson.M{"$or": bson.A{
+					bson.M{"created_at": bson.M{"$exists": false}},
+					bson.M{"updated_at": bson.M{"$exists": false}},
+				}},
+				bson.A{
+					bson.M{"$set": bson.M{"created_at": bson.M{"$ifNull": bson.A{"$created_at", bson.M{"$toDate": "$_id"}}}}},
+					bson.M{"$set": bson.M{"updated_at": bson.M{"$ifNull": bson.A{"$updated_at", "$created_at"}}}},
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			_, err = db.Collection(collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+				{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetName("created_at_1")},
+				{Keys: bson.D{{Key: "updated_at", Value: 1}}, Options: options.Index().SetName("updated_at_1")},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	// Down mantém os valores preenchidos; apenas remove os índices
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		for _, collection := range []string{"users", "groups"} {
+			if err := dropIndexes(ctx, db, collection, "created_at_1", "updated_at_1"); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// statusMigrationReason é o motivo registrado nos usuários inativos convertidos para suspended
+const statusMigrationReason = "inactive before the status migration"
+
+// replaceIsActiveWithStatus converte o booleano is_active no status do ciclo de vida:
+// usuários ativos viram active e os inativos viram suspended (podendo ser reativados)
+var replaceIsActiveWithStatus = Migration{
+	Version:     5,
+	Description: "replace users is_active with lifecycle status",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		users := db.Collection("users")
+		if _, err := users.UpdateMany(ctx,
+			bson.M{"status": bson.M{"$exists": false}, "is_active": true},
+			bson.M{"$set": bson.M{"status": "active"}},
+		); err != nil {
+			return err
+		}
+		if _, err := users.UpdateMany(ctx,
+			bson.M{"status": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"status": "suspended", "status_reason": statusMigrationReason}},
+		); err != nil {
+			return err
+		}
+		if _, err := users.UpdateMany(ctx, bson.M{"is_active": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"is_active": ""}}); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "users", usersValidatorV2()); err != nil {
+			return err
+		}
+		_, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().SetName("status_1"),
+		})
+		return err
+	},
+	// Down volta ao is_active (true apenas para active) e descarta os dados de status
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "users", "status_1"); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "users", usersValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("users").UpdateMany(ctx, bson.M{"status": bson.M{"$exists": true}}, bson.A{
+			bson.M{"$set": bson.M{"is_active": bson.M{"$eq": bson.A{"$status", "active"}}}},
+			bson.M{"$unset": bson.A{"status", "status_reason", "status_changed_at", "status_changed_by"}},
+		})
+		return err
+	},
+}
+
+// usersValidatorV2 substitui is_active por status, obrigatório e restrito aos estados conhecidos
+func usersValidatorV2() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "email", "status"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"email": bson.M{
+					"bsonType":    "string",
+					"pattern":     `^.+@.+\..+$`,
+					"description": "must be a valid email address and is required",
+				},
+				"status": bson.M{
+					"enum":        bson.A{"pending", "active", "suspended", "deprovisioned"},
+					"description": "must be one of pending, active, suspended or deprovisioned and is required",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addNestedGroups permite grupos dentro de grupos. O índice em subgroups atende a subida pelos
+// grupos pais no $graphLookup dos grupos efetivos de um usuário
+var addNestedGroups = Migration{
+	Version:     6,
+	Description: "add groups subgroups for nested groups",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		groups := db.Collection("groups")
+		if _, err := groups.UpdateMany(ctx,
+			bson.M{"subgroups": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"subgroups": bson.A{}}},
+		); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV2()); err != nil {
+			return err
+		}
+		_, err := groups.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "subgroups", Value: 1}},
+			Options: options.Index().SetName("subgroups_1"),
+		})
+		return err
+	},
+	// Down desfaz todos os aninhamentos; os membros diretos são mantidos
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "groups", "subgroups_1"); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("groups").UpdateMany(ctx, bson.M{"subgroups": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"subgroups": ""}})
+		return err
+	},
+}
+
+// groupsValidatorV2 acrescenta subgroups, a lista de _id dos grupos aninhados
+func groupsValidatorV2() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "members"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"members": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "string"},
+					"description": "must be an array of strings and is required",
+				},
+				"subgroups": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "objectId"},
+					"description": "must be an array of group objectIds",
+				},
+			},
+		},
+	}
+}
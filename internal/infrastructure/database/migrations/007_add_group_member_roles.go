@@ -0,0 +1,109 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addGroupMemberRoles troca a lista de IDs em members por subdocumentos com papel e data de entrada.
+// Os membros existentes viram member; grupos antigos ficam sem owner e só um admin os gerencia
+var addGroupMemberRoles = Migration{
+	Version:     7,
+	Description: "convert groups members to documents with role and joined_at",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		// O validator novo vem antes da conversão, pois o anterior rejeita membros em subdocumento
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV3()); err != nil {
+			return err
+		}
+		groups := db.Collection("groups")
+		if _, err := groups.UpdateMany(ctx, bson.M{"members": bson.M{"$type": "string"}}, bson.A{
+			bson.M{"$set": bson.M{"members": bson.M{"$map": bson.M{
+				"input": "$members",
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$type": "$$this"}, "string"}},
+					bson.M{
+						"user_id":   "$$this",
+						"role":      "member",
+						"joined_at": bson.M{"$ifNull": bson.A{"$created_at", "$$NOW"}},
+					},
+					"$$this",
+				}},
+			}}}},
+		}); err != nil {
+			return err
+		}
+
+		if err := dropIndexes(ctx, db, "groups", "members_1"); err != nil {
+			return err
+		}
+		_, err := groups.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "members.user_id", Value: 1}},
+			Options: options.Index().SetName("members.user_id_1"),
+		})
+		return err
+	},
+	// Down volta para a lista de IDs e descarta papéis e datas de entrada
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "groups", "members.user_id_1"); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV2()); err != nil {
+			return err
+		}
+		groups := db.Collection("groups")
+		if _, err := groups.UpdateMany(ctx, bson.M{"members": bson.M{"$type": "object"}}, bson.A{
+			bson.M{"$set": bson.M{"members": bson.M{"$map": bson.M{
+				"input": "$members",
+				"in":    "$$this.user_id",
+			}}}},
+		}); err != nil {
+			return err
+		}
+		_, err := groups.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "members", Value: 1}},
+			Options: options.Index().SetName("members_1"),
+		})
+		return err
+	},
+}
+
+// groupsValidatorV3 troca members por subdocumentos com user_id, role e joined_at
+func groupsValidatorV3() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "members"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"members": bson.M{
+					"bsonType": "array",
+					"items": bson.M{
+						"bsonType": "object",
+						"required": bson.A{"user_id", "role", "joined_at"},
+						"properties": bson.M{
+							"user_id":   bson.M{"bsonType": "string"},
+							"role":      bson.M{"enum": bson.A{"owner", "manager", "member"}},
+							"joined_at": bson.M{"bsonType": "date"},
+						},
+					},
+					"description": "must be an array of memberships and is required",
+				},
+				"subgroups": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "objectId"},
+					"description": "must be an array of group objectIds",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,211 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// defaultTenant é o tenant atribuído aos usuários e grupos anteriores à multi-tenancy
+const defaultTenant = "default"
+
+// addTenants cria o cadastro de tenants e grava tenant_id em usuários e grupos. Os dados
+// existentes passam ao tenant padrão e o email deixa de ser único globalmente para ser único
+// dentro de cada tenant
+var addTenants = Migration{
+	Version:     8,
+	Description: "add tenants and scope users and groups by tenant_id",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		missing := bson.M{"tenant_id": bson.M{"$exists": false}}
+		backfill := bson.M{"$set": bson.M{"tenant_id": defaultTenant}}
+		users := db.Collection("users")
+		if _, err := users.UpdateMany(ctx, missing, backfill); err != nil {
+			return err
+		}
+		groups := db.Collection("groups")
+		if _, err := groups.UpdateMany(ctx, missing, backfill); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "users", usersValidatorV3()); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV4()); err != nil {
+			return err
+		}
+
+		// O índice composto é criado antes de remover o global para que o email nunca fique sem unicidade
+		if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "email", Value: 1}},
+			Options: options.Index().SetName("tenant_id_1_email_1").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "users", "email_1"); err != nil {
+			return err
+		}
+		if _, err := groups.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}},
+			Options: options.Index().SetName("tenant_id_1"),
+		}); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "tenants", tenantsValidatorV1()); err != nil {
+			return err
+		}
+		tenants := db.Collection("tenants")
+		if _, err := tenants.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetName("slug_1").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		_, err := tenants.UpdateOne(ctx,
+			bson.M{"slug": defaultTenant},
+			bson.M{"$setOnInsert": bson.M{"slug": defaultTenant, "name": "Default", "created_at": now, "updated_at": now}},
+			options.UpdateOne().SetUpsert(true),
+		)
+		return err
+	},
+	// Down volta ao email único global, o que falha se o mesmo email existir em mais de um tenant;
+	// nesse caso os duplicados precisam ser resolvidos antes. Os documentos de tenants são mantidos
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		users := db.Collection("users")
+		if _, err := users.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("email_1").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "users", "tenant_id_1_email_1"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "groups", "tenant_id_1"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "tenants", "slug_1"); err != nil {
+			return err
+		}
+		if err := removeValidator(ctx, db, "tenants"); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "users", usersValidatorV2()); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV3()); err != nil {
+			return err
+		}
+		unset := bson.M{"$unset": bson.M{"tenant_id": ""}}
+		if _, err := users.UpdateMany(ctx, bson.M{"tenant_id": bson.M{"$exists": true}}, unset); err != nil {
+			return err
+		}
+		_, err := db.Collection("groups").UpdateMany(ctx, bson.M{"tenant_id": bson.M{"$exists": true}}, unset)
+		return err
+	},
+}
+
+// usersValidatorV3 acrescenta tenant_id, obrigatório
+func usersValidatorV3() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "name", "email", "status"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"email": bson.M{
+					"bsonType":    "string",
+					"pattern":     `^.+@.+\..+$`,
+					"description": "must be a valid email address and is required",
+				},
+				"status": bson.M{
+					"enum":        bson.A{"pending", "active", "suspended", "deprovisioned"},
+					"description": "must be one of pending, active, suspended or deprovisioned and is required",
+				},
+			},
+		},
+	}
+}
+
+// groupsValidatorV4 acrescenta tenant_id, obrigatório
+func groupsValidatorV4() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "name", "members"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"members": bson.M{
+					"bsonType": "array",
+					"items": bson.M{
+						"bsonType": "object",
+						"required": bson.A{"user_id", "role", "joined_at"},
+						"properties": bson.M{
+							"user_id":   bson.M{"bsonType": "string"},
+							"role":      bson.M{"enum": bson.A{"owner", "manager", "member"}},
+							"joined_at": bson.M{"bsonType": "date"},
+						},
+					},
+					"description": "must be an array of memberships and is required",
+				},
+				"subgroups": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "objectId"},
+					"description": "must be an array of group objectIds",
+				},
+			},
+		},
+	}
+}
+
+// tenantsValidatorV1 exige o slug, no formato de rótulo DNS, e o nome do tenant
+func tenantsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"slug", "name"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"slug": bson.M{
+					"bsonType":    "string",
+					"pattern":     `^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`,
+					"description": "must be a lowercase DNS label and is required",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+			},
+		},
+	}
+}
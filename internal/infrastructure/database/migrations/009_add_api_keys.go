@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addAPIKeys cria a coleção api_keys. O índice único em key_hash atende a autenticação, que busca
+// a chave pelo hash do segredo; o índice por tenant atende a listagem
+var addAPIKeys = Migration{
+	Version:     9,
+	Description: "create api_keys collection",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "api_keys", apiKeysValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("api_keys").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "key_hash", Value: 1}}, Options: options.Index().SetName("key_hash_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("tenant_id_1_created_at_-1")},
+		})
+		return err
+	},
+	// Down mantém as chaves gravadas; só remove os índices e o validator
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "api_keys", "key_hash_1", "tenant_id_1_created_at_-1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "api_keys")
+	},
+}
+
+// apiKeysValidatorV1 exige o tenant, o nome, o hash do segredo e a lista de escopos
+func apiKeysValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "name", "prefix", "key_hash", "scopes"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"prefix": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"key_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"scopes": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"enum": bson.A{"users:read", "users:write", "groups:read", "groups:write", "admin"}},
+					"description": "must be an array of known scopes and is required",
+				},
+			},
+		},
+	}
+}
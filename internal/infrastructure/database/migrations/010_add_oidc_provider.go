@@ -0,0 +1,181 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addOIDCProvider cria as coleções do provedor OpenID Connect: oauth_clients, com client_id
+// único entre todos os tenants; authorization_codes, buscados pelo hash do código e apagados pelo
+// índice TTL quando expiram sem uso; e signing_keys, lidas das mais novas para as mais antigas
+var addOIDCProvider = Migration{
+	Version:     10,
+	Description: "create oauth_clients, authorization_codes and signing_keys collections",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "oauth_clients", oauthClientsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("oauth_clients").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "client_id", Value: 1}}, Options: options.Index().SetName("client_id_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("tenant_id_1_created_at_-1")},
+		}); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "authorization_codes", authorizationCodesValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("authorization_codes").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "code_hash", Value: 1}}, Options: options.Index().SetName("code_hash_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		}); err != nil {
+			return err
+		}
+
+		if err := ensureCollection(ctx, db, "signing_keys", signingKeysValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("signing_keys").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "kid", Value: 1}}, Options: options.Index().SetName("kid_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index().SetName("created_at_-1")},
+		})
+		return err
+	},
+	// Down mantém os documentos gravados; só remove os índices e os validators
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "signing_keys", "kid_1", "created_at_-1"); err != nil {
+			return err
+		}
+		if err := removeValidator(ctx, db, "signing_keys"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "authorization_codes", "code_hash_1", "expires_at_1"); err != nil {
+			return err
+		}
+		if err := removeValidator(ctx, db, "authorization_codes"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "oauth_clients", "client_id_1", "tenant_id_1_created_at_-1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "oauth_clients")
+	},
+}
+
+// oauthClientsValidatorV1 exige o tenant, o client_id, o nome e ao menos uma redirect_uri
+func oauthClientsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "client_id", "name", "redirect_uris"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"client_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"name": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"secret_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string if present",
+				},
+				"redirect_uris": bson.M{
+					"bsonType":    "array",
+					"minItems":    1,
+					"items":       bson.M{"bsonType": "string"},
+					"description": "must be a non-empty array of strings and is required",
+				},
+			},
+		},
+	}
+}
+
+// authorizationCodesValidatorV1 exige os dados conferidos na troca do código
+func authorizationCodesValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "code_hash", "client_id", "user_id", "redirect_uri", "code_challenge", "expires_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"code_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"client_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"redirect_uri": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"code_challenge": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
+
+// signingKeysValidatorV1 exige o kid, o algoritmo, a chave privada e a data de criação, que
+// define quando a chave é trocada e por quanto tempo é publicada
+func signingKeysValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"kid", "algorithm", "private_key", "created_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"kid": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"algorithm": bson.M{
+					"enum":        bson.A{"RS256"},
+					"description": "must be RS256 and is required",
+				},
+				"private_key": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
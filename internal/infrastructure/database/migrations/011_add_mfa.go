@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addMFA cria a coleção mfa_enrollments, com um cadastro de TOTP por usuário em cada tenant, e
+// acrescenta a política require_mfa ao validator de groups
+var addMFA = Migration{
+	Version:     11,
+	Description: "create mfa_enrollments collection and add require_mfa to groups",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "mfa_enrollments", mfaEnrollmentsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("mfa_enrollments").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("tenant_id_1_user_id_1").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+		return ensureCollection(ctx, db, "groups", groupsValidatorV5())
+	},
+	// Down mantém os documentos gravados; remove o índice, o validator de mfa_enrollments e
+	// volta ao validator anterior de groups
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "groups", groupsValidatorV4()); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "mfa_enrollments", "tenant_id_1_user_id_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "mfa_enrollments")
+	},
+}
+
+// mfaEnrollmentsValidatorV1 exige o tenant, o usuário, o segredo e os hashes dos códigos de
+// recuperação
+func mfaEnrollmentsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "user_id", "secret", "recovery_codes"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"secret": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"recovery_codes": bson.M{
+					"bsonType":    "array",
+					"items":       bson.M{"bsonType": "string"},
+					"description": "must be an array of code hashes and is required",
+				},
+				"enabled_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+			},
+		},
+	}
+}
+
+// groupsValidatorV5 acrescenta require_mfa, opcional
+func groupsValidatorV5() bson.M {
+	validator := groupsValidatorV4()
+	properties := validator["$jsonSchema"].(bson.M)["properties"].(bson.M)
+	properties["require_mfa"] = bson.M{
+		"bsonType":    "bool",
+		"description": "must be a boolean",
+	}
+	return validator
+}
@@ -0,0 +1,97 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addInvitations cria a coleção invitations, buscada pela assinatura do token e apagada pelo
+// índice TTL quando o convite expira, e acrescenta password_hash e email_verified_at ao validator
+// de users
+var addInvitations = Migration{
+	Version:     12,
+	Description: "create invitations collection and add password_hash and email_verified_at to users",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "invitations", invitationsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("invitations").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetName("token_hash_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetName("tenant_id_1_user_id_1")},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		}); err != nil {
+			return err
+		}
+		return ensureCollection(ctx, db, "users", usersValidatorV4())
+	},
+	// Down mantém os documentos gravados; remove os índices, o validator de invitations e volta ao
+	// validator anterior de users
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "users", usersValidatorV3()); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "invitations", "token_hash_1", "tenant_id_1_user_id_1", "expires_at_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "invitations")
+	},
+}
+
+// invitationsValidatorV1 exige o tenant, o usuário, o email convidado, a assinatura do token e a
+// expiração
+func invitationsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "user_id", "email", "token_hash", "expires_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"email": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"token_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"accepted_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+			},
+		},
+	}
+}
+
+// usersValidatorV4 acrescenta password_hash e email_verified_at, opcionais
+func usersValidatorV4() bson.M {
+	validator := usersValidatorV3()
+	properties := validator["$jsonSchema"].(bson.M)["properties"].(bson.M)
+	properties["password_hash"] = bson.M{
+		"bsonType":    "string",
+		"description": "must be a string",
+	}
+	properties["email_verified_at"] = bson.M{
+		"bsonType":    "date",
+		"description": "must be a date",
+	}
+	return validator
+}
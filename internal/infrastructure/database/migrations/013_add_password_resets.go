@@ -0,0 +1,152 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addPasswordResets cria a coleção password_resets, buscada pelo hash do token e apagada pelo
+// índice TTL quando o token expira, a coleção audit_events e acrescenta sessions_revoked_at ao
+// validator de users
+var addPasswordResets = Migration{
+	Version:     13,
+	Description: "create password_resets and audit_events collections and add sessions_revoked_at to users",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "password_resets", passwordResetsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("password_resets").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetName("token_hash_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("tenant_id_1_user_id_1_created_at_-1")},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		}); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "audit_events", auditEventsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("audit_events").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("tenant_id_1_created_at_-1")},
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetName("tenant_id_1_user_id_1")},
+		}); err != nil {
+			return err
+		}
+		return ensureCollection(ctx, db, "users", usersValidatorV5())
+	},
+	// Down mantém os documentos gravados; remove os índices e os validators das novas coleções e
+	// volta ao validator anterior de users
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "users", usersValidatorV4()); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "audit_events", "tenant_id_1_created_at_-1", "tenant_id_1_user_id_1"); err != nil {
+			return err
+		}
+		if err := removeValidator(ctx, db, "audit_events"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "password_resets", "token_hash_1", "tenant_id_1_user_id_1_created_at_-1", "expires_at_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "password_resets")
+	},
+}
+
+// passwordResetsValidatorV1 exige o tenant, o usuário, o hash do token, a expiração e a data do
+// pedido, usada no limite de pedidos
+func passwordResetsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "user_id", "token_hash", "expires_at", "created_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"token_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"used_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
+
+// auditEventsValidatorV1 exige o tenant, a ação, quem a executou e quando
+func auditEventsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "action", "actor_id", "created_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"action": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"actor_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"ip": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"user_agent": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
+
+// usersValidatorV5 acrescenta sessions_revoked_at, opcional
+func usersValidatorV5() bson.M {
+	validator := usersValidatorV4()
+	properties := validator["$jsonSchema"].(bson.M)["properties"].(bson.M)
+	properties["sessions_revoked_at"] = bson.M{
+		"bsonType":    "date",
+		"description": "must be a date",
+	}
+	return validator
+}
@@ -0,0 +1,145 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addSessions cria as coleções sessions, listada por usuário, e refresh_tokens, buscada pelo hash
+// do token; os índices TTL apagam as duas quando a sessão expira
+var addSessions = Migration{
+	Version:     14,
+	Description: "create sessions and refresh_tokens collections",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "sessions", sessionsValidatorV1()); err != nil {
+			return err
+		}
+		if _, err := db.Collection("sessions").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "last_used_at", Value: -1}}, Options: options.Index().SetName("tenant_id_1_user_id_1_last_used_at_-1")},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		}); err != nil {
+			return err
+		}
+		if err := ensureCollection(ctx, db, "refresh_tokens", refreshTokensValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("refresh_tokens").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetName("token_hash_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		})
+		return err
+	},
+	// Down mantém os documentos gravados; remove os índices e os validators
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "refresh_tokens", "token_hash_1", "expires_at_1"); err != nil {
+			return err
+		}
+		if err := removeValidator(ctx, db, "refresh_tokens"); err != nil {
+			return err
+		}
+		if err := dropIndexes(ctx, db, "sessions", "tenant_id_1_user_id_1_last_used_at_-1", "expires_at_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "sessions")
+	},
+}
+
+// sessionsValidatorV1 exige o tenant, o usuário, se o segundo fator foi confirmado e as datas da
+// sessão
+func sessionsValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "user_id", "mfa", "created_at", "last_used_at", "expires_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"ip": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"user_agent": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"mfa": bson.M{
+					"bsonType":    "bool",
+					"description": "must be a boolean and is required",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"last_used_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"revoked_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+			},
+		},
+	}
+}
+
+// refreshTokensValidatorV1 exige o tenant, a sessão, o usuário, o hash do token e a expiração
+func refreshTokensValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"tenant_id", "session_id", "user_id", "token_hash", "expires_at", "created_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"tenant_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"session_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"user_id": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"token_hash": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"used_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// addIdempotencyKeys cria a coleção idempotency_keys, com uma chave única por cliente; o índice
+// TTL apaga as chaves quando o prazo de IDEMPOTENCY_TTL termina
+var addIdempotencyKeys = Migration{
+	Version:     15,
+	Description: "create idempotency_keys collection",
+	Up: func(ctx context.Context, db *mongo.Database) error {
+		if err := ensureCollection(ctx, db, "idempotency_keys", idempotencyKeysValidatorV1()); err != nil {
+			return err
+		}
+		_, err := db.Collection("idempotency_keys").Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetName("key_1").SetUnique(true)},
+			{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at_1").SetExpireAfterSeconds(0)},
+		})
+		return err
+	},
+	// Down mantém os documentos gravados; remove os índices e o validator
+	Down: func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(ctx, db, "idempotency_keys", "key_1", "expires_at_1"); err != nil {
+			return err
+		}
+		return removeValidator(ctx, db, "idempotency_keys")
+	},
+}
+
+// idempotencyKeysValidatorV1 exige a chave, o hash da requisição e as datas; a resposta só existe
+// depois que a requisição termina
+func idempotencyKeysValidatorV1() bson.M {
+	return bson.M{
+		"$jsonSchema": bson.M{
+			"bsonType": "object",
+			"required": bson.A{"key", "fingerprint", "created_at", "expires_at"},
+			"properties": bson.M{
+				"_id": bson.M{
+					"bsonType":    "objectId",
+					"description": "must be an objectId",
+				},
+				"key": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"fingerprint": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string and is required",
+				},
+				"status_code": bson.M{
+					"bsonType":    bson.A{"int", "long"},
+					"description": "must be an integer",
+				},
+				"content_type": bson.M{
+					"bsonType":    "string",
+					"description": "must be a string",
+				},
+				"body": bson.M{
+					"bsonType":    "binData",
+					"description": "must be binary data",
+				},
+				"created_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+				"completed_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date",
+				},
+				"expires_at": bson.M{
+					"bsonType":    "date",
+					"description": "must be a date and is required",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// All retorna as migrations conhecidas. Novas migrations devem ser adicionadas ao final,
+// com versão maior que a última, e nunca alteradas depois de publicadas
+func All() []Migration {
+	return []Migration{
+		createUsersCollection,
+		createGroupsCollection,
+		addSoftDeleteIndexes,
+		addAuditTimestamps,
+		replaceIsActiveWithStatus,
+		addNestedGroups,
+		addGroupMemberRoles,
+		addTenants,
+		addAPIKeys,
+		addOIDCProvider,
+		addMFA,
+		addInvitations,
+		addPasswordResets,
+		addSessions,
+		addIdempotencyKeys,
+	}
+}
+
+// Código de erro do MongoDB para índice inexistente
+const indexNotFoundCode = 27
+
+// collectionExists verifica se a coleção já foi criada
+func collectionExists(ctx context.Context, db *mongo.Database, name string) (bool, error) {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": name})
+	if err != nil {
+		return false, err
+	}
+	return len(names) > 0, nil
+}
+
+// ensureCollection cria a coleção com o validator informado ou, se ela já existir
+// (ex.: criada implicitamente por um insert), aplica o validator com collMod
+func ensureCollection(ctx context.Context, db *mongo.Database, name string, validator bson.M) error {
+	exists, err := collectionExists(ctx, db, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err := db.CreateCollection(ctx, name, options.CreateCollection().SetValidator(validator))
+		// Outra instância pode ter criado a coleção entre a verificação e o create
+		if err == nil || !mongo.IsDuplicateKeyError(err) && !isNamespaceExists(err) {
+			return err
+		}
+	}
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: validator},
+	}).Err()
+}
+
+// removeValidator remove o validator da coleção sem apagar os documentos
+func removeValidator(ctx context.Context, db *mongo.Database, name string) error {
+	exists, err := collectionExists(ctx, db, name)
+	if err != nil || !exists {
+		return err
+	}
+	return db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: bson.M{}},
+	}).Err()
+}
+
+// dropIndexes remove os índices pelo nome, ignorando os que não existem
+func dropIndexes(ctx context.Context, db *mongo.Database, collection string, names ...string) error {
+	for _, name := range names {
+		err := db.Collection(collection).Indexes().DropOne(ctx, name)
+		var cmdErr mongo.CommandError
+		if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Code == indexNotFoundCode) && !isNamespaceNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func isNamespaceExists(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Name == "NamespaceExists"
+}
+
+func isNamespaceNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Name == "NamespaceNotFound"
+}
@@ -0,0 +1,220 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// CollectionName é a coleção onde as migrations aplicadas são registradas
+const CollectionName = "schema_migrations"
+
+var (
+	ErrPendingMigrations = errors.New("database has pending migrations")
+	ErrDatabaseNotReady  = errors.New("database connection is not initialized")
+)
+
+// Migration é uma alteração versionada do schema. Up e Down devem ser idempotentes,
+// pois mais de uma instância pode executar as migrations ao mesmo tempo
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Status descreve uma migration e se ela já foi aplicada
+type Status struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// record é o documento gravado em schema_migrations para cada migration aplicada
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrator aplica e reverte as migrations registradas em All
+type Migrator struct {
+	mongoDB    *database.MongoDB
+	migrations []Migration
+	upToDate   atomic.Bool
+}
+
+func NewMigrator(mongoDB *database.MongoDB) *Migrator {
+	sorted := All()
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{mongoDB: mongoDB, migrations: sorted}
+}
+
+// Up aplica, em ordem, todas as migrations pendentes e retorna as que foram aplicadas
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx)
+	applied := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		if err := migration.Up(ctx, m.mongoDB.DB); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"version":     migration.Version,
+				"description": migration.Description,
+			}).Error("Failed to apply migration")
+			return applied, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		// Upsert: outra instância pode ter aplicado a mesma migration em paralelo
+		_, err := m.collection().ReplaceOne(ctx, bson.M{"_id": migration.Version}, record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}, options.Replace().SetUpsert(true))
+		if err != nil {
+			return applied, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+
+		log.WithFields(logrus.Fields{
+			"version":     migration.Version,
+			"description": migration.Description,
+		}).Info("Migration applied")
+		applied = append(applied, migration)
+	}
+
+	m.upToDate.Store(true)
+	return applied, nil
+}
+
+// Down reverte as últimas steps migrations aplicadas, da mais recente para a mais antiga
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx)
+	reverted := make([]Migration, 0, steps)
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := appliedVersions[migration.Version]; !ok {
+			continue
+		}
+
+		m.upToDate.Store(false)
+		if err := migration.Down(ctx, m.mongoDB.DB); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"version":     migration.Version,
+				"description": migration.Description,
+			}).Error("Failed to revert migration")
+			return reverted, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		if _, err := m.collection().DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return reverted, fmt.Errorf("failed to remove migration record %d: %w", migration.Version, err)
+		}
+
+		log.WithFields(logrus.Fields{
+			"version":     migration.Version,
+			"description": migration.Description,
+		}).Info("Migration reverted")
+		reverted = append(reverted, migration)
+	}
+	return reverted, nil
+}
+
+// Status lista todas as migrations conhecidas com a data em que foram aplicadas
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if appliedAt, ok := appliedVersions[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending retorna as migrations ainda não aplicadas, em ordem de versão
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	appliedVersions, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := appliedVersions[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// CheckSchema retorna ErrPendingMigrations enquanto houver migrations pendentes. Depois que o
+// schema fica atualizado o resultado é mantido em memória para não consultar o banco a cada probe
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	if m.upToDate.Load() {
+		return nil
+	}
+	if m.mongoDB == nil || m.mongoDB.DB == nil {
+		return ErrDatabaseNotReady
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d not applied", ErrPendingMigrations, len(pending))
+	}
+	m.upToDate.Store(true)
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	cursor, err := m.collection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+func (m *Migrator) collection() *mongo.Collection {
+	return m.mongoDB.DB.Collection(CollectionName)
+}
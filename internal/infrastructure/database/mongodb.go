@@ -10,24 +10,35 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+const defaultConnectTimeout = 10 * time.Second
+
 type MongoDB struct {
 	Client *mongo.Client
 	DB     *mongo.Database
 }
 
 func NewMongoDB(cfg *config.Config, log *logrus.Logger) (*MongoDB, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	connectTimeout := cfg.MongoConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = defaultConnectTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancel()
 
 	mongoUri := cfg.MongoURI + "/" + cfg.MongoDB
 
-	client, err := mongo.Connect(options.Client().ApplyURI(mongoUri))
+	clientOptions := options.Client().ApplyURI(mongoUri).SetMonitor(newTracingMonitor())
+	if cfg.MongoMaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(cfg.MongoMaxPoolSize)
+	}
+	if cfg.MongoMinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(cfg.MongoMinPoolSize)
+	}
+
+	client, err := mongo.Connect(clientOptions)
 	if err != nil {
 		log.WithFields(logrus.Fields{
-			"ddsource": cfg.DDSource,
-			"service":  cfg.DDService,
-			"ddtags":   cfg.DDTags,
-			"error":    err.Error(),
+			"error": err.Error(),
 		}).Error("Failed to connect to MongoDB")
 		return nil, err
 	}
@@ -35,23 +46,15 @@ func NewMongoDB(cfg *config.Config, log *logrus.Logger) (*MongoDB, error) {
 	err = client.Ping(ctx, nil)
 	if err != nil {
 		log.WithFields(logrus.Fields{
-			"ddsource": cfg.DDSource,
-			"service":  cfg.DDService,
-			"ddtags":   cfg.DDTags,
-			"error":    err.Error(),
+			"error": err.Error(),
 		}).Error("MongoDB is not available")
 		return nil, err
 	}
 
 	log.WithFields(logrus.Fields{
-		"ddsource": cfg.DDSource,
-		"service":  cfg.DDService,
-		"ddtags":   cfg.DDTags,
-		"uri":      cfg.MongoURI,
+		"uri": cfg.MongoURI,
 	}).Info("Successfully connected to MongoDB")
 
-	log.Info("Tests")
-
 	db := client.Database(cfg.MongoDB)
 	return &MongoDB{Client: client, DB: db}, nil
 }
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "user-management/internal/infrastructure/database"
+
+// spanKey identifica um comando em andamento; o RequestID só é único por conexão
+type spanKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// newTracingMonitor cria um CommandMonitor que abre um span client para cada comando
+// enviado ao MongoDB, como filho do span presente no contexto da operação
+func newTracingMonitor() *event.CommandMonitor {
+	tracer := otel.Tracer(tracerName)
+	var spans sync.Map
+
+	finish := func(connectionID string, requestID int64, err error) {
+		value, ok := spans.LoadAndDelete(spanKey{connectionID, requestID})
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", evt.DatabaseName),
+				attribute.String("db.operation", evt.CommandName),
+			}
+			if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+				attrs = append(attrs, attribute.String("db.mongodb.collection", collection))
+			}
+
+			_, span := tracer.Start(ctx, fmt.Sprintf("mongodb.%s", evt.CommandName),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			spans.Store(spanKey{evt.ConnectionID, evt.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.ConnectionID, evt.RequestID, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.ConnectionID, evt.RequestID, evt.Failure)
+		},
+	}
+}
@@ -0,0 +1,432 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Tipos de problema reportados
+const (
+	IssueDanglingMember    = "dangling_member"
+	IssueMalformedMember   = "malformed_member"
+	IssueDuplicateMember   = "duplicate_member"
+	IssueDuplicateEmail    = "duplicate_email"
+	IssueInvalidUser       = "invalid_user"
+	IssueInvalidGroup      = "invalid_group"
+	IssueDanglingSubgroup  = "dangling_subgroup"
+	IssueMalformedSubgroup = "malformed_subgroup"
+	IssueGroupCycle        = "group_cycle"
+	IssueCrossTenant       = "cross_tenant_reference"
+)
+
+// Issue é um problema encontrado em um documento
+type Issue struct {
+	Type       string `json:"type"`
+	Collection string `json:"collection"`
+	DocumentID string `json:"document_id"`
+	Value      string `json:"value,omitempty"`
+	Detail     string `json:"detail"`
+	Fixed      bool   `json:"fixed"`
+}
+
+// Report é o resultado de uma verificação
+type Report struct {
+	CheckedAt     time.Time      `json:"checked_at"`
+	Fix           bool           `json:"fix"`
+	UsersScanned  int            `json:"users_scanned"`
+	GroupsScanned int            `json:"groups_scanned"`
+	Summary       map[string]int `json:"summary"`
+	Fixed         int            `json:"fixed"`
+	Unresolved    int            `json:"unresolved"`
+	Issues        []Issue        `json:"issues"`
+}
+
+func (r *Report) add(issue Issue) {
+	r.Issues = append(r.Issues, issue)
+	r.Summary[issue.Type]++
+	if issue.Fixed {
+		r.Fixed++
+	} else {
+		r.Unresolved++
+	}
+}
+
+// Doctor verifica a consistência entre as coleções users e groups e, opcionalmente, corrige
+// os problemas que têm uma correção segura
+type Doctor struct {
+	users     *mongo.Collection
+	groups    *mongo.Collection
+	validator *validators.InputValidator
+}
+
+func NewDoctor(mongoDB *database.MongoDB) *Doctor {
+	return &Doctor{
+		users:     mongoDB.DB.Collection("users"),
+		groups:    mongoDB.DB.Collection("groups"),
+		validator: validators.NewInputValidator(),
+	}
+}
+
+// userDoc e groupDoc usam tipos flexíveis para conseguir ler documentos fora do formato esperado
+type userDoc struct {
+	ID       interface{} `bson:"_id"`
+	TenantID interface{} `bson:"tenant_id"`
+	Name     interface{} `bson:"name"`
+	Email    interface{} `bson:"email"`
+}
+
+type groupDoc struct {
+	ID        interface{}   `bson:"_id"`
+	TenantID  interface{}   `bson:"tenant_id"`
+	Name      interface{}   `bson:"name"`
+	Members   []interface{} `bson:"members"`
+	Subgroups []interface{} `bson:"subgroups"`
+}
+
+// Run executa todas as verificações, em todos os tenants. Com fix=true:
+//   - membros inexistentes, malformados, repetidos ou de outro tenant são removidos dos grupos;
+//   - subgrupos inexistentes, malformados ou de outro tenant são removidos dos grupos pais;
+//   - para emails duplicados no mesmo tenant (ignorando maiúsculas/minúsculas) o usuário mais
+//     antigo é mantido, as participações dos demais são transferidas para ele e os duplicados
+//     são removidos.
+//
+// Documentos que não passam nas regras de validação dos DTOs e ciclos entre grupos (que não têm
+// uma correção segura) são apenas reportados
+func (d *Doctor) Run(ctx context.Context, fix bool) (*Report, error) {
+	report := &Report{CheckedAt: time.Now().UTC(), Fix: fix, Summary: make(map[string]int), Issues: []Issue{}}
+
+	users, err := d.loadUsers(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	replacements := d.checkDuplicateEmails(users, report, fix)
+	if err := d.checkGroups(ctx, users, replacements, report, fix); err != nil {
+		return report, err
+	}
+	// Os duplicados só são removidos depois que as participações foram transferidas
+	if fix {
+		if err := d.removeDuplicates(ctx, replacements); err != nil {
+			return report, err
+		}
+	}
+
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"fix":        fix,
+		"issues":     len(report.Issues),
+		"fixed":      report.Fixed,
+		"unresolved": report.Unresolved,
+	}).Info("Consistency check completed")
+	return report, nil
+}
+
+type userInfo struct {
+	id       bson.ObjectID
+	tenantID string
+	email    string
+}
+
+// loadUsers lê todos os usuários, validando cada um com as regras de dto.CreateUserRequestDTO
+func (d *Doctor) loadUsers(ctx context.Context, report *Report) (map[string]userInfo, error) {
+	cursor, err := d.users.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := make(map[string]userInfo)
+	for cursor.Next(ctx) {
+		report.UsersScanned++
+
+		var doc userDoc
+		if err := cursor.Decode(&doc); err != nil {
+			report.add(Issue{Type: IssueInvalidUser, Collection: "users", DocumentID: rawID(cursor.Current), Detail: err.Error()})
+			continue
+		}
+		id, ok := doc.ID.(bson.ObjectID)
+		if !ok {
+			report.add(Issue{Type: IssueInvalidUser, Collection: "users", DocumentID: fmt.Sprint(doc.ID), Detail: "_id is not an ObjectID"})
+			continue
+		}
+
+		name, _ := doc.Name.(string)
+		email, _ := doc.Email.(string)
+		if err := d.validator.ValidateStruct(&dto.CreateUserRequestDTO{Name: name, Email: email}); err != nil {
+			report.add(Issue{Type: IssueInvalidUser, Collection: "users", DocumentID: id.Hex(), Detail: d.validator.FormatValidationError(err)})
+		}
+		users[id.Hex()] = userInfo{id: id, tenantID: tenantOf(doc.TenantID), email: email}
+	}
+	return users, cursor.Err()
+}
+
+// checkDuplicateEmails agrupa os usuários por tenant e email normalizado e retorna, para cada
+// duplicado, o ID do usuário mantido (o mais antigo, pela data embutida no ObjectID)
+func (d *Doctor) checkDuplicateEmails(users map[string]userInfo, report *Report, fix bool) map[string]string {
+	byEmail := make(map[string][]userInfo)
+	for _, u := range users {
+		if u.email == "" {
+			continue
+		}
+		key := u.tenantID + "/" + strings.ToLower(strings.TrimSpace(u.email))
+		byEmail[key] = append(byEmail[key], u)
+	}
+
+	emails := make([]string, 0, len(byEmail))
+	for email, list := range byEmail {
+		if len(list) > 1 {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	replacements := make(map[string]string)
+	for _, email := range emails {
+		list := byEmail[email]
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].id.Timestamp().Before(list[j].id.Timestamp()) ||
+				list[i].id.Timestamp().Equal(list[j].id.Timestamp()) && list[i].id.Hex() < list[j].id.Hex()
+		})
+		keeper := list[0]
+
+		for _, duplicate := range list[1:] {
+			issue := Issue{
+				Type:       IssueDuplicateEmail,
+				Collection: "users",
+				DocumentID: duplicate.id.Hex(),
+				Value:      duplicate.email,
+				Detail:     fmt.Sprintf("same email as user %s (%s)", keeper.id.Hex(), keeper.email),
+			}
+			replacements[duplicate.id.Hex()] = keeper.id.Hex()
+			if fix {
+				issue.Fixed = true
+				issue.Detail += "; memberships moved and duplicate removed"
+			}
+			report.add(issue)
+		}
+	}
+	return replacements
+}
+
+// removeDuplicates apaga os usuários duplicados identificados por checkDuplicateEmails
+func (d *Doctor) removeDuplicates(ctx context.Context, replacements map[string]string) error {
+	for duplicateID := range replacements {
+		id, err := bson.ObjectIDFromHex(duplicateID)
+		if err != nil {
+			return err
+		}
+		if _, err := d.users.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+			return fmt.Errorf("failed to delete duplicate user %s: %w", duplicateID, err)
+		}
+	}
+	return nil
+}
+
+// checkGroups valida cada grupo e suas referências de membros
+func (d *Doctor) checkGroups(ctx context.Context, users map[string]userInfo, replacements map[string]string, report *Report, fix bool) error {
+	cursor, err := d.groups.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	subgroups := make(map[bson.ObjectID][]interface{})
+	tenants := make(map[bson.ObjectID]string)
+	for cursor.Next(ctx) {
+		report.GroupsScanned++
+
+		var doc groupDoc
+		if err := cursor.Decode(&doc); err != nil {
+			report.add(Issue{Type: IssueInvalidGroup, Collection: "groups", DocumentID: rawID(cursor.Current), Detail: err.Error()})
+			continue
+		}
+		id, ok := doc.ID.(bson.ObjectID)
+		if !ok {
+			report.add(Issue{Type: IssueInvalidGroup, Collection: "groups", DocumentID: fmt.Sprint(doc.ID), Detail: "_id is not an ObjectID"})
+			continue
+		}
+		groupID := id.Hex()
+		tenantID := tenantOf(doc.TenantID)
+		subgroups[id] = doc.Subgroups
+		tenants[id] = tenantID
+
+		name, _ := doc.Name.(string)
+		if err := d.validator.ValidateStruct(&dto.CreateGroupRequestDTO{Name: name}); err != nil {
+			report.add(Issue{Type: IssueInvalidGroup, Collection: "groups", DocumentID: groupID, Detail: d.validator.FormatValidationError(err)})
+		}
+
+		var issues []Issue
+		changed := false
+		members := make([]bson.D, 0, len(doc.Members))
+		seen := make(map[string]bool, len(doc.Members))
+		seenRaw := make(map[string]bool, len(doc.Members))
+		for _, raw := range doc.Members {
+			membership, member, ok := memberUserID(raw)
+			if !ok {
+				issues = append(issues, Issue{Type: IssueMalformedMember, Value: fmt.Sprint(raw), Detail: "member is not a document with a string user_id"})
+				continue
+			}
+			if _, err := bson.ObjectIDFromHex(member); err != nil {
+				issues = append(issues, Issue{Type: IssueMalformedMember, Value: member, Detail: "member is not a valid user ID"})
+				continue
+			}
+			if seenRaw[member] {
+				issues = append(issues, Issue{Type: IssueDuplicateMember, Value: member, Detail: "user appears more than once"})
+				continue
+			}
+			seenRaw[member] = true
+
+			if keeper, ok := replacements[member]; ok {
+				member = keeper
+				changed = true
+			} else if user, ok := users[member]; !ok {
+				issues = append(issues, Issue{Type: IssueDanglingMember, Value: member, Detail: "user does not exist"})
+				continue
+			} else if user.tenantID != tenantID {
+				issues = append(issues, Issue{Type: IssueCrossTenant, Value: member, Detail: fmt.Sprintf("user belongs to tenant %s", user.tenantID)})
+				continue
+			}
+			// O usuário mantido pode já ser membro; a participação transferida é descartada
+			if seen[member] {
+				continue
+			}
+			seen[member] = true
+			members = append(members, withUserID(membership, member))
+		}
+
+		changed = changed || len(issues) > 0
+		if fix && changed {
+			if _, err := d.groups.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"members": members}}); err != nil {
+				return fmt.Errorf("failed to repair members of group %s: %w", groupID, err)
+			}
+		}
+
+		for _, issue := range issues {
+			issue.Collection = "groups"
+			issue.DocumentID = groupID
+			issue.Fixed = fix
+			report.add(issue)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return d.checkSubgroups(ctx, subgroups, tenants, report, fix)
+}
+
+// checkSubgroups valida as referências de subgrupos, já com todos os grupos e seus tenants
+// carregados, e procura ciclos na hierarquia com entities.ExpandGroups
+func (d *Doctor) checkSubgroups(ctx context.Context, subgroups map[bson.ObjectID][]interface{}, tenants map[bson.ObjectID]string, report *Report, fix bool) error {
+	valid := make(map[string][]string, len(subgroups))
+	for id, raws := range subgroups {
+		groupID := id.Hex()
+		var issues []Issue
+		kept := make([]bson.ObjectID, 0, len(raws))
+		for _, raw := range raws {
+			subgroupID, ok := raw.(bson.ObjectID)
+			if !ok {
+				issues = append(issues, Issue{Type: IssueMalformedSubgroup, Value: fmt.Sprint(raw), Detail: "subgroup is not an ObjectID"})
+				continue
+			}
+			if _, ok := subgroups[subgroupID]; !ok {
+				issues = append(issues, Issue{Type: IssueDanglingSubgroup, Value: subgroupID.Hex(), Detail: "group does not exist"})
+				continue
+			}
+			if tenants[subgroupID] != tenants[id] {
+				issues = append(issues, Issue{Type: IssueCrossTenant, Value: subgroupID.Hex(), Detail: fmt.Sprintf("group belongs to tenant %s", tenants[subgroupID])})
+				continue
+			}
+			kept = append(kept, subgroupID)
+			valid[groupID] = append(valid[groupID], subgroupID.Hex())
+		}
+
+		if fix && len(issues) > 0 {
+			if _, err := d.groups.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"subgroups": kept}}); err != nil {
+				return fmt.Errorf("failed to repair subgroups of group %s: %w", groupID, err)
+			}
+		}
+		for _, issue := range issues {
+			issue.Collection = "groups"
+			issue.DocumentID = groupID
+			issue.Fixed = fix
+			report.add(issue)
+		}
+	}
+
+	// Ordena para que o relatório seja estável entre execuções
+	groupIDs := make([]string, 0, len(valid))
+	for groupID := range valid {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+	next := func(groupID string) []string { return valid[groupID] }
+	for _, groupID := range groupIDs {
+		for _, reached := range entities.ExpandGroups([]string{groupID}, next) {
+			if reached == groupID {
+				report.add(Issue{Type: IssueGroupCycle, Collection: "groups", DocumentID: groupID, Detail: "group contains itself through its subgroups"})
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// memberUserID lê o user_id de um membro, que deve ser um subdocumento (role e joined_at são
+// preservados como estão)
+func memberUserID(raw interface{}) (bson.D, string, bool) {
+	membership, ok := raw.(bson.D)
+	if !ok {
+		return nil, "", false
+	}
+	for _, field := range membership {
+		if field.Key == "user_id" {
+			userID, ok := field.Value.(string)
+			return membership, userID, ok
+		}
+	}
+	return nil, "", false
+}
+
+// withUserID copia o membro trocando o user_id, usado ao transferir participações de duplicados
+func withUserID(membership bson.D, userID string) bson.D {
+	copied := make(bson.D, len(membership))
+	for i, field := range membership {
+		if field.Key == "user_id" {
+			field.Value = userID
+		}
+		copied[i] = field
+	}
+	return copied
+}
+
+// tenantOf lê o tenant_id do documento; documentos anteriores à multi-tenancy pertencem ao
+// tenant padrão
+func tenantOf(raw interface{}) string {
+	if tenantID, ok := raw.(string); ok && tenantID != "" {
+		return tenantID
+	}
+	return tenancy.DefaultTenant
+}
+
+// rawID extrai o _id de um documento que não pôde ser decodificado
+func rawID(raw bson.Raw) string {
+	value, err := raw.LookupErr("_id")
+	if err != nil {
+		return ""
+	}
+	if id, ok := value.ObjectIDOK(); ok {
+		return id.Hex()
+	}
+	return value.String()
+}
@@ -0,0 +1,165 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+	"user-management/internal/config"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+var (
+	ErrShuttingDown     = errors.New("server is shutting down")
+	ErrDatabaseNotReady = errors.New("database connection is not initialized")
+)
+
+// Checker verifica um componente do qual a aplicação depende para atender requisições
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// ComponentReport é o resultado da verificação de um componente
+type ComponentReport struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report é o resultado agregado das verificações
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentReport `json:"components,omitempty"`
+}
+
+// Service executa as verificações de readiness e controla o estado de desligamento
+type Service struct {
+	checkers     []Checker
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewService(cfg *config.Config, mongoDB *database.MongoDB, migrator *migrations.Migrator) *Service {
+	timeout := cfg.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+
+	s := &Service{timeout: timeout}
+	s.checkers = []Checker{
+		&mongoChecker{mongoDB: mongoDB},
+		&schemaChecker{migrator: migrator},
+		&shutdownChecker{service: s},
+	}
+	return s
+}
+
+// MarkShuttingDown faz a readiness falhar a partir deste momento
+func (s *Service) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// ShuttingDown indica se o servidor recebeu o sinal de desligamento
+func (s *Service) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// Liveness indica apenas que o processo está de pé e respondendo
+func (s *Service) Liveness() Report {
+	return Report{Status: StatusUp}
+}
+
+// Readiness executa todas as verificações em paralelo, cada uma com o timeout configurado
+func (s *Service) Readiness(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make(map[string]ComponentReport, len(s.checkers))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, checker := range s.checkers {
+		wg.Add(1)
+		go func(checker Checker) {
+			defer wg.Done()
+			component := s.check(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[checker.Name()] = component
+			if component.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(checker)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (s *Service) check(ctx context.Context, checker Checker) ComponentReport {
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(checkCtx)
+	component := ComponentReport{Status: StatusUp, Latency: time.Since(start).String()}
+	if err != nil {
+		component.Status = StatusDown
+		component.Error = err.Error()
+	}
+	return component
+}
+
+type mongoChecker struct {
+	mongoDB *database.MongoDB
+}
+
+func (c *mongoChecker) Name() string {
+	return "mongodb"
+}
+
+func (c *mongoChecker) Check(ctx context.Context) error {
+	if c.mongoDB == nil || c.mongoDB.Client == nil {
+		return ErrDatabaseNotReady
+	}
+	return c.mongoDB.Client.Ping(ctx, nil)
+}
+
+type schemaChecker struct {
+	migrator *migrations.Migrator
+}
+
+func (c *schemaChecker) Name() string {
+	return "schema"
+}
+
+// Check falha enquanto houver migrations pendentes (ver MIGRATE_ON_START e o comando migrate)
+func (c *schemaChecker) Check(ctx context.Context) error {
+	if c.migrator == nil {
+		return ErrDatabaseNotReady
+	}
+	return c.migrator.CheckSchema(ctx)
+}
+
+type shutdownChecker struct {
+	service *Service
+}
+
+func (c *shutdownChecker) Name() string {
+	return "shutdown"
+}
+
+func (c *shutdownChecker) Check(_ context.Context) error {
+	if c.service.ShuttingDown() {
+		return ErrShuttingDown
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/passwordreset"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	passwordResetWorkers   = 4
+	passwordResetQueueSize = 256
+)
+
+type passwordResetJob struct {
+	ctx   context.Context
+	input dto.PasswordResetRequestDTO
+}
+
+// PasswordResetQueue processa os pedidos de redefinição de senha fora da requisição, para que o
+// tempo de resposta não revele se o email está cadastrado. A fila e o número de workers são
+// limitados; no desligamento os pedidos já enfileirados são processados antes de encerrar
+type PasswordResetQueue struct {
+	request *passwordreset.RequestUseCase
+	log     *logrus.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan passwordResetJob
+	wg     sync.WaitGroup
+}
+
+func NewPasswordResetQueue(request *passwordreset.RequestUseCase, log *logrus.Logger) *PasswordResetQueue {
+	return &PasswordResetQueue{request: request, log: log, jobs: make(chan passwordResetJob, passwordResetQueueSize)}
+}
+
+// Start inicia os workers, que rodam até Shutdown
+func (q *PasswordResetQueue) Start() {
+	for range passwordResetWorkers {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for job := range q.jobs {
+				if err := q.request.Execute(job.ctx, &job.input); err != nil {
+					logger.FromContext(job.ctx).WithError(err).Error("Failed to process password reset request")
+				}
+			}
+		}()
+	}
+}
+
+// Enqueue agenda o pedido sem bloquear. O contexto da requisição é mantido sem o cancelamento,
+// pois carrega o tenant e o logger. Com a fila cheia ou encerrada o pedido é descartado e o
+// retorno é false
+func (q *PasswordResetQueue) Enqueue(ctx context.Context, input dto.PasswordResetRequestDTO) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return false
+	}
+	select {
+	case q.jobs <- passwordResetJob{ctx: context.WithoutCancel(ctx), input: input}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown para de aceitar pedidos e espera os enfileirados serem processados ou ctx vencer
+func (q *PasswordResetQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
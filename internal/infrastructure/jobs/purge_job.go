@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/usecases/maintenance"
+	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PurgeJob apaga periodicamente os registros removidos há mais de SOFT_DELETE_RETENTION
+type PurgeJob struct {
+	purge     *maintenance.PurgeDeletedUseCase
+	clock     services.IClock
+	retention time.Duration
+	interval  time.Duration
+	log       *logrus.Logger
+}
+
+func NewPurgeJob(cfg *config.Config, purge *maintenance.PurgeDeletedUseCase, clock services.IClock, log *logrus.Logger) *PurgeJob {
+	return &PurgeJob{purge: purge, clock: clock, retention: cfg.SoftDeleteRetention, interval: cfg.PurgeInterval, log: log}
+}
+
+// Start executa o expurgo a cada PURGE_INTERVAL até ctx ser cancelado. Com retenção zero
+// o job fica desabilitado e os registros removidos são mantidos indefinidamente
+func (j *PurgeJob) Start(ctx context.Context) {
+	if j.retention <= 0 {
+		j.log.Info("Purge job disabled (SOFT_DELETE_RETENTION=0)")
+		return
+	}
+
+	ctx = auth.WithPrincipal(ctx, auth.Principal{ID: "purge-job", Type: auth.PrincipalSystem, Admin: true})
+	ctx = logger.WithEntry(ctx, j.log.WithField("job", "purge"))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		j.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executa um único expurgo e registra eventuais erros
+func (j *PurgeJob) RunOnce(ctx context.Context) {
+	if _, err := j.purge.Execute(ctx, j.clock.Now().Add(-j.retention)); err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to purge deleted records")
+	}
+}
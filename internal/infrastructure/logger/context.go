@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type entryContextKey struct{}
+
+// WithEntry retorna um contexto que carrega a entrada de log da requisição
+func WithEntry(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryContextKey{}, entry)
+}
+
+// FromContext retorna a entrada de log carregada no contexto (com os campos da requisição)
+// ou uma entrada do logger padrão quando não houver nenhuma. A entrada é associada ao
+// contexto para que o TraceHook inclua o span corrente
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	entry, ok := ctx.Value(entryContextKey{}).(*logrus.Entry)
+	if !ok {
+		entry = logrus.NewEntry(logrus.StandardLogger())
+	}
+	return entry.WithContext(ctx)
+}
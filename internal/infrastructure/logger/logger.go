@@ -1,35 +1,77 @@
 package logger
 
 import (
+	"strings"
+	"user-management/internal/config"
+
 	"github.com/sirupsen/logrus"
 )
 
-func NewLogger() *logrus.Logger {
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	timestampFormat = "2006-01-02T15:04:05.999Z" // Formato ISO 8601 para Datadog
+)
+
+func NewLogger(cfg *config.Config) *logrus.Logger {
 	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: "2006-01-02T15:04:05.999Z", // Formato ISO 8601 para Datadog
+	logger.SetFormatter(newFormatter(cfg.LogFormat))
+
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		level = logrus.InfoLevel
+		defer logger.WithField("log_level", cfg.LogLevel).Warn("Invalid log level, falling back to info")
+	}
+	logger.SetLevel(level)
+
+	// A ordem importa: a redação roda por último para cobrir os campos adicionados pelos demais hooks
+	logger.AddHook(NewDatadogHook(cfg))
+	logger.AddHook(&TraceHook{})
+	logger.AddHook(NewRedactionHook())
+	return logger
+}
+
+// newFormatter retorna o formatter para LOG_FORMAT; JSON é o padrão esperado pelo Datadog
+func newFormatter(format string) logrus.Formatter {
+	if strings.EqualFold(format, FormatText) {
+		return &logrus.TextFormatter{
+			FullTimestamp:   true,
+			TimestampFormat: timestampFormat,
+		}
+	}
+	return &logrus.JSONFormatter{
+		TimestampFormat: timestampFormat,
 		FieldMap: logrus.FieldMap{
 			logrus.FieldKeyTime:  "timestamp",
 			logrus.FieldKeyLevel: "level",
 			logrus.FieldKeyMsg:   "message",
 		},
-	})
-	logger.SetLevel(logrus.InfoLevel)
-	// Adicionar campos padrão para Datadog
-	//logger.AddHook(&DatadogHook{})
-	return logger
+	}
+}
+
+// DatadogHook adiciona campos específicos do Datadog a todas as entradas
+type DatadogHook struct {
+	source  string
+	service string
+	tags    string
+}
+
+func NewDatadogHook(cfg *config.Config) *DatadogHook {
+	return &DatadogHook{
+		source:  cfg.DDSource,
+		service: cfg.DDService,
+		tags:    cfg.DDTags,
+	}
+}
+
+func (h *DatadogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
 }
 
-// DatadogHook adiciona campos específicos do Datadog
-//type DatadogHook struct{}
-//
-//func (h *DatadogHook) Levels() []logrus.Level {
-//	return logrus.AllLevels
-//}
-//
-//func (h *DatadogHook) Fire(entry *logrus.Entry) error {
-//	entry.Data["ddsource"] = "go"
-//	entry.Data["service"] = "user-management"
-//	entry.Data["ddtags"] = "env:dev,app:fiber"
-//	return nil
-//}
+func (h *DatadogHook) Fire(entry *logrus.Entry) error {
+	entry.Data["ddsource"] = h.source
+	entry.Data["service"] = h.service
+	entry.Data["ddtags"] = h.tags
+	return nil
+}
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHook adiciona trace_id e span_id às entradas registradas com WithContext,
+// permitindo correlacionar logs e traces. Também adiciona dd.trace_id e dd.span_id no
+// formato esperado pelo Datadog (os 64 bits menos significativos, em decimal)
+type TraceHook struct{}
+
+func (h *TraceHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *TraceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	traceID := spanContext.TraceID()
+	spanID := spanContext.SpanID()
+
+	entry.Data["trace_id"] = traceID.String()
+	entry.Data["span_id"] = spanID.String()
+	entry.Data["dd.trace_id"] = strconv.FormatUint(binary.BigEndian.Uint64(traceID[8:]), 10)
+	entry.Data["dd.span_id"] = strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10)
+	return nil
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+// FileMailer grava cada mensagem como um arquivo .eml em dir, para desenvolvimento local e
+// testes. Os nomes começam pelo horário do envio, então a ordem alfabética é a ordem de envio
+type FileMailer struct {
+	dir  string
+	from *mail.Address
+}
+
+func NewFileMailer(dir string, from *mail.Address) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, message services.Message) error {
+	now := time.Now()
+	msg, err := compose(m.from, message, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(m.dir, fmt.Sprintf("%s-*.eml", now.UTC().Format("20060102T150405.000000000")))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Write(msg); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).WithField("file", filepath.Base(file.Name())).Debug("Email written to file")
+	return nil
+}
@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"context"
+	"net/mail"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer não envia nada: registra destinatário, assunto e corpo no log. É o padrão em
+// desenvolvimento; o corpo contém os links dos convites, então não deve ser usado em produção
+type LogMailer struct {
+	from *mail.Address
+}
+
+func NewLogMailer(from *mail.Address) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message services.Message) error {
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"from":    m.from.String(),
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	}).Info("Email not sent (MAILER=log)")
+	return nil
+}
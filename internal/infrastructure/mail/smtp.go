@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+)
+
+// SMTPMailer envia pelo servidor SMTP configurado. net/smtp usa STARTTLS quando o servidor
+// oferece e só envia as credenciais em conexões TLS (ou para localhost)
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from *mail.Address
+}
+
+func NewSMTPMailer(host, port, username, password string, from *mail.Address) *SMTPMailer {
+	m := &SMTPMailer{addr: net.JoinHostPort(host, port), host: host, from: from}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message services.Message) error {
+	msg, err := compose(m.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(message.To)
+	if err := smtp.SendMail(m.addr, m.auth, m.from.Address, []string{to.Address}, msg); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("smtp_host", m.host).Error("Failed to send email")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package password
+
+import (
+	"errors"
+	"user-management/internal/domain/interfaces/services"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher gera hashes bcrypt com o custo padrão da biblioteca. O bcrypt aceita no máximo 72
+// bytes; senhas maiores resultam em services.ErrPasswordTooLong em vez de serem truncadas
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher() services.IPasswordHasher {
+	return &BcryptHasher{cost: bcrypt.DefaultCost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", services.ErrPasswordTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Compare(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return services.ErrPasswordMismatch
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"user-management/internal/domain/interfaces/services"
+)
+
+// sweepInterval é o intervalo mínimo entre as limpezas dos baldes cheios
+const sweepInterval = time.Minute
+
+// MemoryStore mantém os baldes na memória do processo: cada réplica aplica a cota sozinha, então
+// com N réplicas atrás de um balanceador o cliente pode fazer até N vezes mais requisições
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket guarda as fichas de um cliente na última requisição; full é quando ele estará cheio de
+// novo e pode ser descartado, já que um balde novo começa cheio
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit services.RateLimit, now time.Time) (services.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, b := range s.buckets {
+			if !b.full.After(now) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds() // fichas por segundo
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	} else if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	result := services.RateLimitResult{Allowed: b.tokens >= 1}
+	if result.Allowed {
+		b.tokens--
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	result.Remaining = uint64(b.tokens)
+	result.ResetAfter = seconds((capacity - b.tokens) / rate)
+	b.full = now.Add(result.ResetAfter)
+	return result, nil
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,17 @@
+package ratelimit
+
+import (
+	"fmt"
+	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/services"
+)
+
+// NewStore escolhe a implementação de services.IRateLimitStore pela configuração RATE_LIMIT_STORE
+func NewStore(cfg *config.Config) (services.IRateLimitStore, error) {
+	switch cfg.RateLimitStore {
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store %q", cfg.RateLimitStore)
+	}
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// APIKeyRepository acessa a coleção api_keys pelo tenantCollection; só GetByHash usa a coleção
+// diretamente (ver IAPIKeyRepository)
+type APIKeyRepository struct {
+	collection *tenantCollection
+	raw        *mongo.Collection
+}
+
+func NewAPIKeyRepository(db *database.MongoDB) (repositories.IAPIKeyRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for api_keys: database connection is nil")
+	}
+	collection := db.DB.Collection("api_keys")
+	return &APIKeyRepository{collection: newTenantCollection(collection), raw: collection}, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	key.ID = bson.NewObjectID()
+	// O validator da coleção exige um array; nil seria gravado como null
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+	err := r.collection.InsertOne(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert API key")
+	}
+	return err
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var key entities.APIKey
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	if err := r.raw.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context, offset int64, limit int64) ([]*entities.APIKey, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSkip(offset).SetLimit(limit).SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*entities.APIKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, key *entities.APIKey) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": key.ID, "revoked_at": nil}, bson.M{"$set": bson.M{
+		"revoked_at": key.RevokedAt,
+		"revoked_by": key.RevokedBy,
+		"updated_at": key.UpdatedAt,
+		"updated_by": key.UpdatedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("api_key_id", key.ID.Hex()).Error("Failed to revoke API key")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, key *entities.APIKey, usedAt time.Time, resolution time.Duration) error {
+	// A chave já foi autenticada, então o tenant é o dela
+	_, err := r.collection.UpdateOne(tenancy.WithTenant(ctx, key.TenantID),
+		bson.M{"_id": key.ID, "$or": bson.A{
+			bson.M{"last_used_at": nil},
+			bson.M{"last_used_at": bson.M{"$lt": usedAt.Add(-resolution)}},
+		}},
+		bson.M{"$set": bson.M{"last_used_at": usedAt}},
+	)
+	return err
+}
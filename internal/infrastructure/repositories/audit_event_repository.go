@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AuditEventRepository grava na coleção audit_events pelo tenantCollection
+type AuditEventRepository struct {
+	collection *tenantCollection
+}
+
+func NewAuditEventRepository(db *database.MongoDB) (repositories.IAuditEventRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for audit_events: database connection is nil")
+	}
+	return &AuditEventRepository{collection: newTenantCollection(db.DB.Collection("audit_events"))}, nil
+}
+
+func (r *AuditEventRepository) Create(ctx context.Context, event *entities.AuditEvent) error {
+	event.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, event)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("action", event.Action).Error("Failed to insert audit event")
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// AuthorizationCodeRepository grava os códigos pelo tenantCollection; Consume usa a coleção
+// diretamente (ver IAuthorizationCodeRepository). Códigos expirados e não usados são apagados
+// pelo índice TTL em expires_at
+type AuthorizationCodeRepository struct {
+	collection *tenantCollection
+	raw        *mongo.Collection
+}
+
+func NewAuthorizationCodeRepository(db *database.MongoDB) (repositories.IAuthorizationCodeRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for authorization_codes: database connection is nil")
+	}
+	collection := db.DB.Collection("authorization_codes")
+	return &AuthorizationCodeRepository{collection: newTenantCollection(collection), raw: collection}, nil
+}
+
+func (r *AuthorizationCodeRepository) Create(ctx context.Context, code *entities.AuthorizationCode) error {
+	code.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, code)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert authorization code")
+	}
+	return err
+}
+
+func (r *AuthorizationCodeRepository) Consume(ctx context.Context, codeHash string) (*entities.AuthorizationCode, error) {
+	var code entities.AuthorizationCode
+	if err := r.raw.FindOneAndDelete(ctx, bson.M{"code_hash": codeHash}).Decode(&code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
@@ -2,27 +2,84 @@ package repositories
 
 import (
 	"context"
+	"time"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
 
+	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-// BaseRepository contém funcionalidades comuns para todos os repositórios
+// BaseRepository contém funcionalidades comuns para os repositórios de recursos por tenant.
+// Todas as operações são restritas ao tenant do contexto (ver tenantCollection)
 type BaseRepository struct {
-	collection *mongo.Collection
+	collection *tenantCollection
 }
 
 // NewBaseRepository cria uma nova instância do BaseRepository
 func NewBaseRepository(collection *mongo.Collection) *BaseRepository {
 	return &BaseRepository{
-		collection: collection,
+		collection: newTenantCollection(collection),
 	}
 }
 
-// Count retorna o total de documentos na coleção
-func (r *BaseRepository) Count(ctx context.Context) (int64, error) {
-	count, err := r.collection.CountDocuments(ctx, bson.M{})
+// notDeleted acrescenta ao filtro a condição que exclui documentos removidos (soft delete).
+// deleted_at: nil casa tanto com o campo ausente quanto com null
+func notDeleted(filter bson.M, includeDeleted bool) bson.M {
+	if includeDeleted {
+		return filter
+	}
+	scoped := bson.M{"deleted_at": nil}
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	return scoped
+}
+
+// withListFilter acrescenta ao filtro as condições de soft delete e os intervalos de data do ListFilter
+func withListFilter(filter bson.M, lf repositories.ListFilter) bson.M {
+	scoped := notDeleted(bson.M{}, lf.IncludeDeleted)
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	addDateRange(scoped, "created_at", lf.CreatedAfter, lf.CreatedBefore)
+	addDateRange(scoped, "updated_at", lf.UpdatedAfter, lf.UpdatedBefore)
+	return scoped
+}
+
+// addDateRange adiciona a condição [after, before) sobre field quando algum limite é informado
+func addDateRange(filter bson.M, field string, after, before *time.Time) {
+	if after == nil && before == nil {
+		return
+	}
+	condition := bson.M{}
+	if after != nil {
+		condition["$gte"] = *after
+	}
+	if before != nil {
+		condition["$lt"] = *before
+	}
+	filter[field] = condition
+}
+
+// sortFor converte a ordenação do ListFilter para o MongoDB. O _id é usado como desempate para
+// que a paginação seja estável quando vários documentos têm o mesmo valor
+func sortFor(lf repositories.ListFilter) bson.D {
+	if lf.SortBy == "" {
+		return nil
+	}
+	direction := 1
+	if lf.SortDesc {
+		direction = -1
+	}
+	return bson.D{{Key: lf.SortBy, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// Count retorna o total de documentos na coleção que atendem ao ListFilter
+func (r *BaseRepository) Count(ctx context.Context, lf repositories.ListFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, withListFilter(bson.M{}, lf))
 	if err != nil {
 		return 0, err
 	}
@@ -38,24 +95,121 @@ func (r *BaseRepository) CountWithFilter(ctx context.Context, filter bson.M) (in
 	return count, nil
 }
 
-// DeleteByID remove um documento pelo ID
-func (r *BaseRepository) DeleteByID(ctx context.Context, id string) error {
+// FindByID busca um documento pelo ID; removidos só são retornados com includeDeleted
+func (r *BaseRepository) FindByID(ctx context.Context, id string, includeDeleted bool) (*mongo.SingleResult, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.collection.FindOne(ctx, notDeleted(bson.M{"_id": objectID}, includeDeleted)), nil
+}
+
+// SoftDeleteByID marca um documento como removido. Remover um documento inexistente ou já
+// removido não é um erro, mantendo o DELETE idempotente
+func (r *BaseRepository) SoftDeleteByID(ctx context.Context, id string, deletedAt time.Time, deletedBy string) error {
 	objectID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	_, err = r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objectID}, false), bson.M{"$set": bson.M{
+		"deleted_at": deletedAt,
+		"deleted_by": deletedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"collection": r.collection.Name(),
+			"id":         id,
+		}).Error("Failed to delete document")
+	}
 	return err
 }
 
-// ExistsByID verifica se um documento existe pelo ID
+// RestoreByID desfaz o soft delete, registrando a restauração em updated_at/updated_by;
+// retorna mongo.ErrNoDocuments se o documento não existir ou não estiver removido
+func (r *BaseRepository) RestoreByID(ctx context.Context, id string, restoredAt time.Time, restoredBy string) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+			"$set":   bson.M{"updated_at": restoredAt, "updated_by": restoredBy},
+		},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"collection": r.collection.Name(),
+			"id":         id,
+		}).Error("Failed to restore document")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// PurgeDeleted remove definitivamente os documentos removidos antes de deletedBefore e
+// retorna os IDs apagados
+func (r *BaseRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]bson.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	// Repete a condição de deleted_at para não apagar um documento restaurado nesse intervalo
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": bson.M{"$lt": deletedBefore}}); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("collection", r.collection.Name()).Error("Failed to purge deleted documents")
+		return nil, err
+	}
+
+	// Os que ainda existem foram restaurados antes do DeleteMany e não entram no resultado
+	var kept []bson.ObjectID
+	distinct, err := r.collection.Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	if err := distinct.Decode(&kept); err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+	keptSet := make(map[bson.ObjectID]bool, len(kept))
+	for _, id := range kept {
+		keptSet[id] = true
+	}
+
+	purged := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !keptSet[id] {
+			purged = append(purged, id.Hex())
+		}
+	}
+	return purged, nil
+}
+
+// ExistsByID verifica se um documento não removido existe pelo ID
 func (r *BaseRepository) ExistsByID(ctx context.Context, id string) (bool, error) {
 	objectID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return false, err
 	}
 
-	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": objectID})
+	count, err := r.collection.CountDocuments(ctx, notDeleted(bson.M{"_id": objectID}, false))
 	if err != nil {
 		return false, err
 	}
@@ -63,8 +217,38 @@ func (r *BaseRepository) ExistsByID(ctx context.Context, id string) (bool, error
 	return count > 0, nil
 }
 
-// FindWithPagination encontra documentos com paginação
-func (r *BaseRepository) FindWithPagination(ctx context.Context, filter bson.M, offset int64, limit int64) (*mongo.Cursor, error) {
+// FindWithPagination encontra documentos com paginação; sort nil mantém a ordem natural
+func (r *BaseRepository) FindWithPagination(ctx context.Context, filter bson.M, offset int64, limit int64, sort bson.D) (*mongo.Cursor, error) {
 	opts := options.Find().SetSkip(offset).SetLimit(limit)
+	if sort != nil {
+		opts.SetSort(sort)
+	}
 	return r.collection.Find(ctx, filter, opts)
 }
+
+// aggregatePage executa o pipeline e pagina o resultado com $facet, retornando a página e o total
+// de documentos antes da paginação em uma única consulta
+func aggregatePage[T any](ctx context.Context, collection *tenantCollection, build func(scope bson.M) mongo.Pipeline, offset int64, limit int64) ([]*T, int64, error) {
+	cursor, err := collection.Aggregate(ctx, func(scope bson.M) mongo.Pipeline {
+		return append(build(scope), bson.D{{Key: "$facet", Value: bson.M{
+			"data":  bson.A{bson.M{"$skip": offset}, bson.M{"$limit": limit}},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var pages []struct {
+		Data  []*T `bson:"data"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cursor.All(ctx, &pages); err != nil {
+		return nil, 0, err
+	}
+	if len(pages) == 0 || len(pages[0].Total) == 0 {
+		return nil, 0, nil
+	}
+	return pages[0].Data, pages[0].Total[0].Count, nil
+}
@@ -3,17 +3,20 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type GroupRepository struct {
 	*BaseRepository
-	collection *mongo.Collection
+	collection *tenantCollection
 }
 
 func NewGroupRepository(db *database.MongoDB) (repositories.IGroupRepository, error) {
@@ -22,34 +25,52 @@ func NewGroupRepository(db *database.MongoDB) (repositories.IGroupRepository, er
 	if collection == nil {
 		return nil, fmt.Errorf("failed to get MongoDB collection for groups")
 	}
+	base := NewBaseRepository(collection)
 	return &GroupRepository{
-		BaseRepository: NewBaseRepository(collection),
-		collection:     collection,
+		BaseRepository: base,
+		collection:     base.collection,
 	}, nil
 }
 
 func (r *GroupRepository) Create(ctx context.Context, group *entities.Group) error {
 	group.ID = bson.NewObjectID()
-	_, err := r.collection.InsertOne(ctx, group)
+	// O validator da coleção exige um array; nil seria gravado como null
+	if group.Members == nil {
+		group.Members = []entities.GroupMember{}
+	}
+	if group.Subgroups == nil {
+		group.Subgroups = []bson.ObjectID{}
+	}
+	err := r.collection.InsertOne(ctx, group)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert group")
+	}
 	return err
 }
 
 func (r *GroupRepository) GetByID(ctx context.Context, id string) (*entities.Group, error) {
-	objectID, err := bson.ObjectIDFromHex(id)
+	return r.getByID(ctx, id, false)
+}
+
+func (r *GroupRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*entities.Group, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *GroupRepository) getByID(ctx context.Context, id string, includeDeleted bool) (*entities.Group, error) {
+	result, err := r.FindByID(ctx, id, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
 
 	var group entities.Group
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&group)
-	if err != nil {
+	if err := result.Decode(&group); err != nil {
 		return nil, err
 	}
 	return &group, nil
 }
 
-func (r *GroupRepository) List(ctx context.Context, offset int64, limit int64) ([]*entities.Group, error) {
-	cursor, err := r.FindWithPagination(ctx, bson.M{}, offset, limit)
+func (r *GroupRepository) List(ctx context.Context, offset int64, limit int64, filter repositories.ListFilter) ([]*entities.Group, error) {
+	cursor, err := r.FindWithPagination(ctx, withListFilter(bson.M{}, filter), offset, limit, sortFor(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -63,31 +84,416 @@ func (r *GroupRepository) List(ctx context.Context, offset int64, limit int64) (
 }
 
 func (r *GroupRepository) Update(ctx context.Context, group *entities.Group) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": group.ID}, bson.M{"$set": bson.M{
-		"name":    group.Name,
-		"members": group.Members,
+	if group.Members == nil {
+		group.Members = []entities.GroupMember{}
+	}
+	_, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": group.ID}, false), bson.M{"$set": bson.M{
+		"name":       group.Name,
+		"members":    group.Members,
+		"updated_at": group.UpdatedAt,
+		"updated_by": group.UpdatedBy,
 	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("group_id", group.ID.Hex()).Error("Failed to update group")
+	}
 	return err
 }
 
-func (r *GroupRepository) Delete(ctx context.Context, id string) error {
-	return r.DeleteByID(ctx, id)
+func (r *GroupRepository) SetRequireMFA(ctx context.Context, groupID string, required bool, updatedAt time.Time, updatedBy string) error {
+	objectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objectID}, false), bson.M{"$set": bson.M{
+		"require_mfa": required,
+		"updated_at":  updatedAt,
+		"updated_by":  updatedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("group_id", groupID).Error("Failed to update group MFA policy")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *GroupRepository) Delete(ctx context.Context, id string, deletedAt time.Time, deletedBy string) error {
+	return r.SoftDeleteByID(ctx, id, deletedAt, deletedBy)
+}
+
+func (r *GroupRepository) Restore(ctx context.Context, id string, restoredAt time.Time, restoredBy string) error {
+	return r.RestoreByID(ctx, id, restoredAt, restoredBy)
+}
+
+func (r *GroupRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return r.BaseRepository.PurgeDeleted(ctx, deletedBefore)
 }
 
-func (r *GroupRepository) AddUserToGroup(ctx context.Context, groupID, userID string) error {
+func (r *GroupRepository) AddUserToGroup(ctx context.Context, groupID string, member entities.GroupMember, updatedAt time.Time, updatedBy string) error {
 	groupObjectID, err := bson.ObjectIDFromHex(groupID)
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": groupObjectID}, bson.M{"$addToSet": bson.M{"members": userID}})
+	// Só altera (e atualiza updated_at) se o usuário ainda não for membro
+	_, err = r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": groupObjectID, "members.user_id": bson.M{"$ne": member.UserID}}, false), bson.M{
+		"$push": bson.M{"members": member},
+		"$set":  bson.M{"updated_at": updatedAt, "updated_by": updatedBy},
+	})
 	return err
 }
 
-func (r *GroupRepository) RemoveUserFromGroup(ctx context.Context, groupID, userID string) error {
+func (r *GroupRepository) RemoveUserFromGroup(ctx context.Context, groupID, userID string, updatedAt time.Time, updatedBy string) error {
 	groupObjectID, err := bson.ObjectIDFromHex(groupID)
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": groupObjectID}, bson.M{"$pull": bson.M{"members": userID}})
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": groupObjectID, "$or": keepsAnOwner(userID)}, false), bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": userID}},
+		"$set":  bson.M{"updated_at": updatedAt, "updated_by": updatedBy},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *GroupRepository) AddMembers(ctx context.Context, groupID string, members []entities.GroupMember, updatedAt time.Time, updatedBy string) error {
+	groupObjectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return err
+	}
+	// Equivalente a $addToSet com $each comparando apenas user_id: os subdocumentos de quem já é
+	// membro (com papel e joined_at diferentes) são descartados no próprio update
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": groupObjectID}, false), bson.A{
+		bson.M{"$set": bson.M{
+			"members": bson.M{"$concatArrays": bson.A{"$members", bson.M{"$filter": bson.M{
+				"input": bson.M{"$literal": members},
+				"cond":  bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$$this.user_id", "$members.user_id"}}}},
+			}}}},
+			"updated_at": updatedAt,
+			"updated_by": updatedBy,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *GroupRepository) RemoveMembers(ctx context.Context, groupID string, userIDs []string, updatedAt time.Time, updatedBy string) error {
+	groupObjectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": groupObjectID, "$or": keepsAnOwnerWithout(userIDs)}, false), bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": bson.M{"$in": userIDs}}},
+		"$set":  bson.M{"updated_at": updatedAt, "updated_by": updatedBy},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *GroupRepository) ReplaceMembers(ctx context.Context, current *entities.Group, members []entities.GroupMember, updatedAt time.Time, updatedBy string) error {
+	if members == nil {
+		members = []entities.GroupMember{}
+	}
+	// A remoção de usuários expurgados não altera updated_at; por isso os membros também são comparados
+	currentIDs := make(bson.A, 0, len(current.Members))
+	for _, member := range current.Members {
+		currentIDs = append(currentIDs, member.UserID)
+	}
+	filter := bson.M{
+		"_id":        current.ID,
+		"updated_at": current.UpdatedAt,
+		"$expr":      bson.M{"$setEquals": bson.A{bson.M{"$ifNull": bson.A{"$members.user_id", bson.A{}}}, bson.M{"$literal": currentIDs}}},
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(filter, false), bson.M{
+		"$set": bson.M{"members": members, "updated_at": updatedAt, "updated_by": updatedBy},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *GroupRepository) UpdateMemberRole(ctx context.Context, groupID, userID string, role entities.GroupRole, updatedAt time.Time, updatedBy string) error {
+	groupObjectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": groupObjectID, "members.user_id": userID}
+	if role != entities.GroupRoleOwner {
+		filter["$or"] = keepsAnOwner(userID)
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(filter, false),
+		bson.M{"$set": bson.M{
+			"members.$[member].role": role,
+			"updated_at":             updatedAt,
+			"updated_by":             updatedBy,
+		}},
+		options.UpdateOne().SetArrayFilters([]interface{}{bson.M{"member.user_id": userID}}),
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// keepsAnOwner é a condição ($or) de que userID é membro e o grupo continua com um owner sem ele:
+// ou ele não é owner, ou existe outro owner. Repetida no filtro do update, garante o último owner
+// mesmo com alterações concorrentes
+func keepsAnOwner(userID string) bson.A {
+	return bson.A{
+		bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": userID, "role": bson.M{"$ne": entities.GroupRoleOwner}}}},
+		bson.M{"$and": bson.A{
+			bson.M{"members.user_id": userID},
+			bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": bson.M{"$ne": userID}, "role": entities.GroupRoleOwner}}},
+		}},
+	}
+}
+
+// keepsAnOwnerWithout é a condição ($or) de que o grupo continua com um owner depois de remover
+// userIDs: ou nenhum deles é owner (inclusive grupos sem owner), ou sobra outro owner
+func keepsAnOwnerWithout(userIDs []string) bson.A {
+	return bson.A{
+		bson.M{"members": bson.M{"$not": bson.M{"$elemMatch": bson.M{"user_id": bson.M{"$in": userIDs}, "role": entities.GroupRoleOwner}}}},
+		bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": bson.M{"$nin": userIDs}, "role": entities.GroupRoleOwner}}},
+	}
+}
+
+// RemoveUsersFromAllGroups remove os usuários de todos os grupos, inclusive os removidos
+// (usado quando os usuários são apagados definitivamente ou desprovisionados). Não há garantia
+// de owner aqui: um usuário que deixou de existir não pode continuar gerenciando o grupo
+func (r *GroupRepository) RemoveUsersFromAllGroups(ctx context.Context, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.UpdateMany(ctx, bson.M{"members.user_id": bson.M{"$in": userIDs}}, bson.M{"$pull": bson.M{"members": bson.M{"user_id": bson.M{"$in": userIDs}}}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to remove users from groups")
+	}
+	return err
+}
+
+func (r *GroupRepository) AddSubgroup(ctx context.Context, groupID, subgroupID string, updatedAt time.Time, updatedBy string) error {
+	groupObjectID, subgroupObjectID, err := parseGroupPair(groupID, subgroupID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": groupObjectID, "subgroups": bson.M{"$ne": subgroupObjectID}}, false), bson.M{
+		"$addToSet": bson.M{"subgroups": subgroupObjectID},
+		"$set":      bson.M{"updated_at": updatedAt, "updated_by": updatedBy},
+	})
+	return err
+}
+
+func (r *GroupRepository) RemoveSubgroup(ctx context.Context, groupID, subgroupID string, updatedAt time.Time, updatedBy string) error {
+	groupObjectID, subgroupObjectID, err := parseGroupPair(groupID, subgroupID)
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": groupObjectID, "subgroups": subgroupObjectID}, bson.M{
+		"$pull": bson.M{"subgroups": subgroupObjectID},
+		"$set":  bson.M{"updated_at": updatedAt, "updated_by": updatedBy},
+	})
 	return err
 }
+
+func (r *GroupRepository) RemoveSubgroupsFromAllGroups(ctx context.Context, groupIDs []string) error {
+	if len(groupIDs) == 0 {
+		return nil
+	}
+	objectIDs := make([]bson.ObjectID, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		objectID, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	_, err := r.collection.UpdateMany(ctx, bson.M{"subgroups": bson.M{"$in": objectIDs}}, bson.M{"$pullAll": bson.M{"subgroups": objectIDs}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to remove subgroups from groups")
+	}
+	return err
+}
+
+func (r *GroupRepository) GetDescendantIDs(ctx context.Context, groupID string) ([]string, error) {
+	objectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.collection.Aggregate(ctx, func(scope bson.M) mongo.Pipeline {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"_id": objectID}}},
+			{{Key: "$graphLookup", Value: bson.M{
+				"from":                    r.collection.Name(),
+				"startWith":               "$subgroups",
+				"connectFromField":        "subgroups",
+				"connectToField":          "_id",
+				"as":                      "descendants",
+				"restrictSearchWithMatch": scope,
+			}}},
+			{{Key: "$project", Value: bson.M{"ids": "$descendants._id"}}},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		IDs []bson.ObjectID `bson:"ids"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, result := range results {
+		for _, id := range result.IDs {
+			ids = append(ids, id.Hex())
+		}
+	}
+	return ids, nil
+}
+
+func (r *GroupRepository) ListMembers(ctx context.Context, groupID, search string, offset int64, limit int64) ([]*entities.User, int64, error) {
+	objectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, 0, err
+	}
+	userFilter := bson.M{}
+	if search != "" {
+		userFilter = searchFilter(search)
+	}
+	// Um único $lookup resolve os IDs dos membros (string) pelo _id de users; a busca e o soft
+	// delete são aplicados dentro do próprio $lookup
+	return aggregatePage[entities.User](ctx, r.collection, func(scope bson.M) mongo.Pipeline {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: notDeleted(bson.M{"_id": objectID}, false)}},
+			{{Key: "$project", Value: bson.M{"member_ids": bson.M{"$map": bson.M{
+				"input": "$members.user_id",
+				"in":    bson.M{"$convert": bson.M{"input": "$$this", "to": "objectId", "onError": nil, "onNull": nil}},
+			}}}}},
+			{{Key: "$lookup", Value: bson.M{
+				"from":         "users",
+				"localField":   "member_ids",
+				"foreignField": "_id",
+				"pipeline":     bson.A{bson.M{"$match": notDeleted(withScope(userFilter, scope), false)}},
+				"as":           "users",
+			}}},
+			{{Key: "$unwind", Value: "$users"}},
+			{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$users"}}},
+			{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+		}
+	}, offset, limit)
+}
+
+func (r *GroupRepository) ListUserGroups(ctx context.Context, userID, search string, offset int64, limit int64) ([]*entities.Group, int64, error) {
+	filter := bson.M{"members.user_id": userID}
+	if search != "" {
+		filter["name"] = bson.M{mongoRegex: search, mongoOptions: "i"}
+	}
+	return aggregatePage[entities.Group](ctx, r.collection, func(bson.M) mongo.Pipeline {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: notDeleted(filter, false)}},
+			{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+		}
+	}, offset, limit)
+}
+
+func (r *GroupRepository) ListEffectiveMembers(ctx context.Context, groupID string, offset int64, limit int64) ([]*entities.User, int64, error) {
+	objectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Junta os membros do grupo e dos subgrupos alcançáveis (sem passar por grupos removidos)
+	// e resolve os IDs, guardados como string, nos documentos de users
+	return aggregatePage[entities.User](ctx, r.collection, func(scope bson.M) mongo.Pipeline {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: notDeleted(bson.M{"_id": objectID}, false)}},
+			{{Key: "$graphLookup", Value: bson.M{
+				"from":                    r.collection.Name(),
+				"startWith":               "$subgroups",
+				"connectFromField":        "subgroups",
+				"connectToField":          "_id",
+				"as":                      "descendants",
+				"restrictSearchWithMatch": notDeleted(scope, false),
+			}}},
+			{{Key: "$project", Value: bson.M{"member_ids": bson.M{"$map": bson.M{
+				"input": bson.M{"$setUnion": bson.A{"$members.user_id", bson.M{"$reduce": bson.M{
+					"input":        "$descendants.members.user_id",
+					"initialValue": bson.A{},
+					"in":           bson.M{"$concatArrays": bson.A{"$$value", "$$this"}},
+				}}}},
+				"in": bson.M{"$convert": bson.M{"input": "$$this", "to": "objectId", "onError": nil, "onNull": nil}},
+			}}}}},
+			{{Key: "$lookup", Value: bson.M{
+				"from":         "users",
+				"localField":   "member_ids",
+				"foreignField": "_id",
+				"pipeline":     bson.A{bson.M{"$match": notDeleted(scope, false)}},
+				"as":           "users",
+			}}},
+			{{Key: "$unwind", Value: "$users"}},
+			{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$users"}}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		}
+	}, offset, limit)
+}
+
+func (r *GroupRepository) ListEffectiveGroups(ctx context.Context, userID string, offset int64, limit int64) ([]*entities.Group, int64, error) {
+	// Parte dos grupos em que o usuário é membro direto e sobe pelos pais (grupos cujo
+	// subgroups contém o _id); um grupo alcançado por mais de um caminho aparece uma vez
+	return aggregatePage[entities.Group](ctx, r.collection, func(scope bson.M) mongo.Pipeline {
+		return mongo.Pipeline{
+			{{Key: "$match", Value: notDeleted(bson.M{"members.user_id": userID}, false)}},
+			{{Key: "$graphLookup", Value: bson.M{
+				"from":                    r.collection.Name(),
+				"startWith":               "$_id",
+				"connectFromField":        "_id",
+				"connectToField":          "subgroups",
+				"as":                      "ancestors",
+				"restrictSearchWithMatch": notDeleted(scope, false),
+			}}},
+			{{Key: "$project", Value: bson.M{"groups": bson.M{"$concatArrays": bson.A{bson.A{"$$ROOT"}, "$ancestors"}}}}},
+			{{Key: "$unwind", Value: "$groups"}},
+			{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$groups"}}},
+			{{Key: "$unset", Value: "ancestors"}},
+			{{Key: "$group", Value: bson.M{"_id": "$_id", "group": bson.M{"$first": "$$ROOT"}}}},
+			{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$group"}}},
+			{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+		}
+	}, offset, limit)
+}
+
+// parseGroupPair converte os IDs do grupo pai e do subgrupo
+func parseGroupPair(groupID, subgroupID string) (bson.ObjectID, bson.ObjectID, error) {
+	groupObjectID, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return bson.NilObjectID, bson.NilObjectID, err
+	}
+	subgroupObjectID, err := bson.ObjectIDFromHex(subgroupID)
+	if err != nil {
+		return bson.NilObjectID, bson.NilObjectID, err
+	}
+	return groupObjectID, subgroupObjectID, nil
+}
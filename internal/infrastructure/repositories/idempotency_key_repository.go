@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// IdempotencyKeyRepository acessa a coleção idempotency_keys diretamente (ver
+// IIdempotencyKeyRepository). O índice único em key garante um único dono por chave e um índice
+// TTL em expires_at remove as chaves vencidas
+type IdempotencyKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIdempotencyKeyRepository(db *database.MongoDB) (repositories.IIdempotencyKeyRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for idempotency_keys: database connection is nil")
+	}
+	return &IdempotencyKeyRepository{collection: db.DB.Collection("idempotency_keys")}, nil
+}
+
+func (r *IdempotencyKeyRepository) Create(ctx context.Context, key *entities.IdempotencyKey) error {
+	key.ID = bson.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, key)
+	if mongo.IsDuplicateKeyError(err) {
+		return entities.ErrIdempotencyKeyInUse
+	}
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert idempotency key")
+	}
+	return err
+}
+
+func (r *IdempotencyKeyRepository) GetByKey(ctx context.Context, key string) (*entities.IdempotencyKey, error) {
+	var record entities.IdempotencyKey
+	if err := r.collection.FindOne(ctx, bson.M{"key": key}).Decode(&record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyKeyRepository) Complete(ctx context.Context, id string, statusCode int, contentType string, body []byte, completedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "completed_at": nil},
+		bson.M{"$set": bson.M{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+			"completed_at": completedAt,
+		}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("idempotency_key_id", id).Error("Failed to complete idempotency key")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *IdempotencyKeyRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID}); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("idempotency_key_id", id).Error("Failed to delete idempotency key")
+		return err
+	}
+	return nil
+}
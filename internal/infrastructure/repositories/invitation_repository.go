@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// InvitationRepository acessa a coleção invitations pelo tenantCollection; só GetByHash usa a
+// coleção diretamente (ver IInvitationRepository). Um índice TTL em expires_at remove os
+// convites vencidos
+type InvitationRepository struct {
+	collection *tenantCollection
+	raw        *mongo.Collection
+}
+
+func NewInvitationRepository(db *database.MongoDB) (repositories.IInvitationRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for invitations: database connection is nil")
+	}
+	collection := db.DB.Collection("invitations")
+	return &InvitationRepository{collection: newTenantCollection(collection), raw: collection}, nil
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, invitation *entities.Invitation) error {
+	invitation.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, invitation)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", invitation.UserID).Error("Failed to insert invitation")
+	}
+	return err
+}
+
+func (r *InvitationRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.Invitation, error) {
+	var invitation entities.Invitation
+	if err := r.raw.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&invitation); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *InvitationRepository) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "accepted_at": nil, "expires_at": bson.M{"$gt": acceptedAt}},
+		bson.M{"$set": bson.M{"accepted_at": acceptedAt}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("invitation_id", id).Error("Failed to accept invitation")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *InvitationRepository) DeletePending(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID, "accepted_at": nil})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to delete pending invitations")
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// MFAEnrollmentRepository acessa a coleção mfa_enrollments pelo tenantCollection. O índice único
+// em tenant_id e user_id garante um cadastro por usuário
+type MFAEnrollmentRepository struct {
+	collection *tenantCollection
+}
+
+func NewMFAEnrollmentRepository(db *database.MongoDB) (repositories.IMFAEnrollmentRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for mfa_enrollments: database connection is nil")
+	}
+	return &MFAEnrollmentRepository{collection: newTenantCollection(db.DB.Collection("mfa_enrollments"))}, nil
+}
+
+func (r *MFAEnrollmentRepository) Create(ctx context.Context, enrollment *entities.MFAEnrollment) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"user_id": enrollment.UserID, "enabled_at": nil}); err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", enrollment.UserID).Error("Failed to delete pending MFA enrollment")
+		return err
+	}
+	enrollment.ID = bson.NewObjectID()
+	// O validator da coleção exige um array; nil seria gravado como null
+	if enrollment.RecoveryCodes == nil {
+		enrollment.RecoveryCodes = []string{}
+	}
+	err := r.collection.InsertOne(ctx, enrollment)
+	if mongo.IsDuplicateKeyError(err) {
+		return entities.ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", enrollment.UserID).Error("Failed to insert MFA enrollment")
+	}
+	return err
+}
+
+func (r *MFAEnrollmentRepository) GetByUserID(ctx context.Context, userID string) (*entities.MFAEnrollment, error) {
+	var enrollment entities.MFAEnrollment
+	if err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&enrollment); err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *MFAEnrollmentRepository) Enable(ctx context.Context, userID string, recoveryCodes []string, step int64, enabledAt time.Time) error {
+	return r.updateOne(ctx, bson.M{"user_id": userID, "enabled_at": nil}, bson.M{"$set": bson.M{
+		"recovery_codes": recoveryCodes,
+		"last_used_step": step,
+		"enabled_at":     enabledAt,
+		"updated_at":     enabledAt,
+	}})
+}
+
+func (r *MFAEnrollmentRepository) RecordAttempt(ctx context.Context, userID string, failedAttempts int, lockedUntil *time.Time, now time.Time) error {
+	attempts := bson.M{"failed_attempts": failedAttempts}
+	if failedAttempts == 0 {
+		// cadastros gravados antes do contador não têm o campo
+		attempts = bson.M{"$or": bson.A{bson.M{"failed_attempts": 0}, bson.M{"failed_attempts": bson.M{"$exists": false}}}}
+	}
+	return r.updateOne(ctx, bson.M{"user_id": userID, "$and": bson.A{
+		attempts,
+		bson.M{"$or": bson.A{bson.M{"locked_until": nil}, bson.M{"locked_until": bson.M{"$lte": now}}}},
+	}}, bson.M{"$set": bson.M{
+		"failed_attempts": failedAttempts + 1,
+		"locked_until":    lockedUntil,
+	}})
+}
+
+func (r *MFAEnrollmentRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	return r.updateOne(ctx, bson.M{"user_id": userID, "last_used_step": bson.M{"$lt": step}}, bson.M{"$set": bson.M{
+		"last_used_step":  step,
+		"failed_attempts": 0,
+		"locked_until":    nil,
+	}})
+}
+
+func (r *MFAEnrollmentRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, usedAt time.Time) error {
+	return r.updateOne(ctx, bson.M{"user_id": userID, "enabled_at": bson.M{"$ne": nil}, "recovery_codes": codeHash}, bson.M{
+		"$pull": bson.M{"recovery_codes": codeHash},
+		"$set":  bson.M{"updated_at": usedAt, "failed_attempts": 0, "locked_until": nil},
+	})
+}
+
+func (r *MFAEnrollmentRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodes []string, updatedAt time.Time) error {
+	return r.updateOne(ctx, bson.M{"user_id": userID, "enabled_at": bson.M{"$ne": nil}}, bson.M{"$set": bson.M{
+		"recovery_codes": recoveryCodes,
+		"updated_at":     updatedAt,
+	}})
+}
+
+func (r *MFAEnrollmentRepository) Delete(ctx context.Context, userID string) error {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to delete MFA enrollment")
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MFAEnrollmentRepository) DeleteByUserIDs(ctx context.Context, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to delete MFA enrollments")
+	}
+	return err
+}
+
+// updateOne aplica a alteração condicional e retorna mongo.ErrNoDocuments se nada casar
+func (r *MFAEnrollmentRepository) updateOne(ctx context.Context, filter bson.M, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", filter["user_id"]).Error("Failed to update MFA enrollment")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// OAuthClientRepository acessa a coleção oauth_clients pelo tenantCollection
+type OAuthClientRepository struct {
+	collection *tenantCollection
+}
+
+func NewOAuthClientRepository(db *database.MongoDB) (repositories.IOAuthClientRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for oauth_clients: database connection is nil")
+	}
+	return &OAuthClientRepository{collection: newTenantCollection(db.DB.Collection("oauth_clients"))}, nil
+}
+
+func (r *OAuthClientRepository) Create(ctx context.Context, client *entities.OAuthClient) error {
+	client.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, client)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert OAuth client")
+	}
+	return err
+}
+
+func (r *OAuthClientRepository) GetByClientID(ctx context.Context, clientID string) (*entities.OAuthClient, error) {
+	var client entities.OAuthClient
+	if err := r.collection.FindOne(ctx, bson.M{"client_id": clientID}).Decode(&client); err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *OAuthClientRepository) List(ctx context.Context, offset int64, limit int64) ([]*entities.OAuthClient, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSkip(offset).SetLimit(limit).SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var clients []*entities.OAuthClient
+	if err := cursor.All(ctx, &clients); err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+func (r *OAuthClientRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *OAuthClientRepository) Delete(ctx context.Context, clientID string) error {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"client_id": clientID})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("client_id", clientID).Error("Failed to delete OAuth client")
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// PasswordResetRepository acessa a coleção password_resets pelo tenantCollection; só GetByHash
+// usa a coleção diretamente (ver IPasswordResetRepository). Um índice TTL em expires_at remove os
+// pedidos vencidos
+type PasswordResetRepository struct {
+	collection *tenantCollection
+	raw        *mongo.Collection
+}
+
+func NewPasswordResetRepository(db *database.MongoDB) (repositories.IPasswordResetRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for password_resets: database connection is nil")
+	}
+	collection := db.DB.Collection("password_resets")
+	return &PasswordResetRepository{collection: newTenantCollection(collection), raw: collection}, nil
+}
+
+func (r *PasswordResetRepository) Create(ctx context.Context, reset *entities.PasswordReset) error {
+	reset.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, reset)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", reset.UserID).Error("Failed to insert password reset")
+	}
+	return err
+}
+
+func (r *PasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.PasswordReset, error) {
+	var reset entities.PasswordReset
+	if err := r.raw.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&reset); err != nil {
+		return nil, err
+	}
+	return &reset, nil
+}
+
+func (r *PasswordResetRepository) Use(ctx context.Context, id string, usedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "used_at": nil, "expires_at": bson.M{"$gt": usedAt}},
+		bson.M{"$set": bson.M{"used_at": usedAt}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("password_reset_id", id).Error("Failed to use password reset")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *PasswordResetRepository) CountSince(ctx context.Context, userID string, since time.Time) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}})
+}
+
+func (r *PasswordResetRepository) DeleteUnused(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID, "used_at": nil})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to delete password resets")
+	}
+	return err
+}
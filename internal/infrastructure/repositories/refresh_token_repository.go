@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// RefreshTokenRepository acessa a coleção refresh_tokens pelo tenantCollection; só GetByHash usa a
+// coleção diretamente (ver IRefreshTokenRepository). Um índice TTL em expires_at remove os tokens
+// vencidos
+type RefreshTokenRepository struct {
+	collection *tenantCollection
+	raw        *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *database.MongoDB) (repositories.IRefreshTokenRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for refresh_tokens: database connection is nil")
+	}
+	collection := db.DB.Collection("refresh_tokens")
+	return &RefreshTokenRepository{collection: newTenantCollection(collection), raw: collection}, nil
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entities.RefreshToken) error {
+	token.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, token)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("session_id", token.SessionID).Error("Failed to insert refresh token")
+	}
+	return err
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	if err := r.raw.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) Use(ctx context.Context, id string, usedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "used_at": nil},
+		bson.M{"$set": bson.M{"used_at": usedAt}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("refresh_token_id", id).Error("Failed to use refresh token")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SessionRepository acessa a coleção sessions pelo tenantCollection. Um índice TTL em expires_at
+// remove as sessões expiradas
+type SessionRepository struct {
+	collection *tenantCollection
+}
+
+func NewSessionRepository(db *database.MongoDB) (repositories.ISessionRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for sessions: database connection is nil")
+	}
+	return &SessionRepository{collection: newTenantCollection(db.DB.Collection("sessions"))}, nil
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *entities.Session) error {
+	session.ID = bson.NewObjectID()
+	err := r.collection.InsertOne(ctx, session)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", session.UserID).Error("Failed to insert session")
+	}
+	return err
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*entities.Session, error) {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var session entities.Session
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepository) ListActive(ctx context.Context, userID string, now time.Time) ([]*entities.Session, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"user_id": userID, "revoked_at": nil, "expires_at": bson.M{"$gt": now}},
+		options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}, {Key: "_id", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []*entities.Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id string, ip string, userAgent string, usedAt time.Time) error {
+	return r.update(ctx, id, bson.M{"last_used_at": usedAt, "ip": ip, "user_agent": userAgent})
+}
+
+func (r *SessionRepository) MarkMFA(ctx context.Context, id string) error {
+	return r.update(ctx, id, bson.M{"mfa": true})
+}
+
+func (r *SessionRepository) update(ctx context.Context, id string, set bson.M) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": set})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("session_id", id).Error("Failed to update session")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, userID string, id string, revokedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithFields(logrus.Fields{"user_id": userID, "session_id": id}).Error("Failed to revoke session")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeAll(ctx context.Context, userID string, revokedAt time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt}},
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to revoke sessions")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
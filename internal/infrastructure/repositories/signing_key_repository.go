@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SigningKeyRepository acessa a coleção signing_keys diretamente: as chaves não pertencem a
+// nenhum tenant
+type SigningKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSigningKeyRepository(db *database.MongoDB) (repositories.ISigningKeyRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for signing_keys: database connection is nil")
+	}
+	return &SigningKeyRepository{collection: db.DB.Collection("signing_keys")}, nil
+}
+
+func (r *SigningKeyRepository) Create(ctx context.Context, key *entities.SigningKey) error {
+	key.ID = bson.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert signing key")
+	}
+	return err
+}
+
+func (r *SigningKeyRepository) ListCreatedAfter(ctx context.Context, after time.Time) ([]*entities.SigningKey, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"created_at": bson.M{"$gt": after}}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []*entities.SigningKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *SigningKeyRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": before}})
+	return err
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"user-management/internal/application/tenancy"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// tenantDocument é implementado pelas entidades gravadas por tenant
+type tenantDocument interface {
+	SetTenantID(tenantID string)
+}
+
+// tenantCollection envolve as coleções cujos documentos pertencem a um tenant (users e groups).
+// Toda consulta, alteração e agregação recebe a condição tenant_id do contexto (tenancy.WithTenant)
+// e toda inserção grava o tenant no documento. Os repositórios só acessam essas coleções por aqui,
+// de modo que nenhuma operação alcança documentos de outro tenant; sem tenant no contexto as
+// operações falham com tenancy.ErrTenantRequired
+type tenantCollection struct {
+	collection *mongo.Collection
+}
+
+func newTenantCollection(collection *mongo.Collection) *tenantCollection {
+	return &tenantCollection{collection: collection}
+}
+
+func (c *tenantCollection) Name() string {
+	return c.collection.Name()
+}
+
+// scope retorna a condição de tenant do contexto; nas rotinas de manutenção
+// (tenancy.WithAllTenants) a condição é vazia
+func (c *tenantCollection) scope(ctx context.Context) (bson.M, error) {
+	tenantID, all, err := tenancy.Scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		return bson.M{}, nil
+	}
+	return bson.M{"tenant_id": tenantID}, nil
+}
+
+// scoped acrescenta ao filtro a condição de tenant do contexto, que prevalece sobre um tenant_id
+// eventualmente presente no filtro
+func (c *tenantCollection) scoped(ctx context.Context, filter bson.M) (bson.M, error) {
+	scope, err := c.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return withScope(filter, scope), nil
+}
+
+// withScope combina o filtro com a condição de tenant
+func withScope(filter bson.M, scope bson.M) bson.M {
+	combined := make(bson.M, len(filter)+len(scope))
+	for key, value := range filter {
+		combined[key] = value
+	}
+	for key, value := range scope {
+		combined[key] = value
+	}
+	return combined
+}
+
+// InsertOne grava o documento no tenant do contexto, que precisa ser um tenant específico
+func (c *tenantCollection) InsertOne(ctx context.Context, document tenantDocument) error {
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return tenancy.ErrTenantRequired
+	}
+	document.SetTenantID(tenantID)
+	_, err := c.collection.InsertOne(ctx, document)
+	return err
+}
+
+func (c *tenantCollection) Find(ctx context.Context, filter bson.M, opts ...options.Lister[options.FindOptions]) (*mongo.Cursor, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.Find(ctx, filter, opts...)
+}
+
+func (c *tenantCollection) FindOne(ctx context.Context, filter bson.M) *mongo.SingleResult {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+	return c.collection.FindOne(ctx, filter)
+}
+
+func (c *tenantCollection) CountDocuments(ctx context.Context, filter bson.M) (int64, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return c.collection.CountDocuments(ctx, filter)
+}
+
+func (c *tenantCollection) Distinct(ctx context.Context, field string, filter bson.M) (*mongo.DistinctResult, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.Distinct(ctx, field, filter), nil
+}
+
+func (c *tenantCollection) UpdateOne(ctx context.Context, filter bson.M, update interface{}, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.UpdateOne(ctx, filter, update, opts...)
+}
+
+func (c *tenantCollection) UpdateMany(ctx context.Context, filter bson.M, update interface{}) (*mongo.UpdateResult, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.UpdateMany(ctx, filter, update)
+}
+
+func (c *tenantCollection) DeleteMany(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	filter, err := c.scoped(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.collection.DeleteMany(ctx, filter)
+}
+
+// Aggregate executa o pipeline montado por build, sempre precedido do $match do tenant. build
+// recebe a mesma condição para repeti-la nos $lookup e $graphLookup, que leem outras coleções
+// (ou a própria) sem passar por este filtro
+func (c *tenantCollection) Aggregate(ctx context.Context, build func(scope bson.M) mongo.Pipeline) (*mongo.Cursor, error) {
+	scope, err := c.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := append(mongo.Pipeline{{{Key: "$match", Value: scope}}}, build(scope)...)
+	return c.collection.Aggregate(ctx, pipeline)
+}
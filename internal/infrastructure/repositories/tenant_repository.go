@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// TenantRepository acessa a coleção tenants diretamente: o cadastro de tenants não pertence a
+// nenhum tenant e por isso não usa o BaseRepository
+type TenantRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTenantRepository(db *database.MongoDB) (repositories.ITenantRepository, error) {
+	if db == nil || db.DB == nil {
+		return nil, fmt.Errorf("failed to get MongoDB collection for tenants: database connection is nil")
+	}
+	return &TenantRepository{collection: db.DB.Collection("tenants")}, nil
+}
+
+func (r *TenantRepository) Create(ctx context.Context, tenant *entities.Tenant) error {
+	tenant.ID = bson.NewObjectID()
+	_, err := r.collection.InsertOne(ctx, tenant)
+	if mongo.IsDuplicateKeyError(err) {
+		return entities.ErrTenantExists
+	}
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert tenant")
+	}
+	return err
+}
+
+func (r *TenantRepository) GetBySlug(ctx context.Context, slug string) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	if err := r.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&tenant); err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+func (r *TenantRepository) List(ctx context.Context, offset int64, limit int64) ([]*entities.Tenant, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSkip(offset).SetLimit(limit).SetSort(bson.D{{Key: "slug", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tenants []*entities.Tenant
+	if err := cursor.All(ctx, &tenants); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (r *TenantRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+func (r *TenantRepository) Update(ctx context.Context, tenant *entities.Tenant) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{"slug": tenant.Slug}, bson.M{"$set": bson.M{
+		"name":       tenant.Name,
+		"updated_at": tenant.UpdatedAt,
+		"updated_by": tenant.UpdatedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("tenant", tenant.Slug).Error("Failed to update tenant")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *TenantRepository) Delete(ctx context.Context, slug string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"slug": slug})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("tenant", slug).Error("Failed to delete tenant")
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -3,9 +3,11 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 	"user-management/internal/domain/entities"
 	"user-management/internal/domain/interfaces/repositories"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -19,7 +21,7 @@ const (
 
 type UserRepository struct {
 	*BaseRepository
-	collection *mongo.Collection
+	collection *tenantCollection
 }
 
 func NewUserRepository(db *database.MongoDB) (repositories.IUserRepository, error) {
@@ -34,34 +36,57 @@ func NewUserRepository(db *database.MongoDB) (repositories.IUserRepository, erro
 		return nil, fmt.Errorf("failed to get MongoDB collection for users")
 	}
 
+	base := NewBaseRepository(collection)
 	return &UserRepository{
-		BaseRepository: NewBaseRepository(collection),
-		collection:     collection,
+		BaseRepository: base,
+		collection:     base.collection,
 	}, nil
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
 	user.ID = bson.NewObjectID()
-	_, err := r.collection.InsertOne(ctx, user)
+	err := r.collection.InsertOne(ctx, user)
+	// O índice único de email é por tenant (tenant_id, email)
+	if mongo.IsDuplicateKeyError(err) {
+		return entities.ErrEmailTaken
+	}
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to insert user")
+	}
 	return err
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
-	objectID, err := bson.ObjectIDFromHex(id)
+	return r.getByID(ctx, id, false)
+}
+
+func (r *UserRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*entities.User, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	var user entities.User
+	if err := r.collection.FindOne(ctx, notDeleted(bson.M{"email": email}, false)).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) getByID(ctx context.Context, id string, includeDeleted bool) (*entities.User, error) {
+	result, err := r.FindByID(ctx, id, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
 
 	var user entities.User
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user)
-	if err != nil {
+	if err := result.Decode(&user); err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) List(ctx context.Context, offset int64, limit int64) ([]*entities.User, error) {
-	cursor, err := r.FindWithPagination(ctx, bson.M{}, offset, limit)
+func (r *UserRepository) List(ctx context.Context, offset int64, limit int64, filter repositories.ListFilter) ([]*entities.User, error) {
+	cursor, err := r.FindWithPagination(ctx, withListFilter(bson.M{}, filter), offset, limit, sortFor(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -74,16 +99,29 @@ func (r *UserRepository) List(ctx context.Context, offset int64, limit int64) ([
 	return users, nil
 }
 
-func (r *UserRepository) Search(ctx context.Context, searchTerm string, offset int64, limit int64) ([]*entities.User, error) {
-	// Cria filtro de busca usando regex para buscar no nome e email
-	filter := bson.M{
-		"$or": []bson.M{
-			{"name": bson.M{mongoRegex: searchTerm, mongoOptions: "i"}},
-			{"email": bson.M{mongoRegex: searchTerm, mongoOptions: "i"}},
-		},
+func (r *UserRepository) ListByIDs(ctx context.Context, ids []string) ([]*entities.User, error) {
+	objectIDs := make([]bson.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		// IDs malformados não correspondem a nenhum usuário
+		if objectID, err := bson.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
 	}
+	cursor, err := r.collection.Find(ctx, notDeleted(bson.M{"_id": bson.M{"$in": objectIDs}}, false))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
 
-	cursor, err := r.FindWithPagination(ctx, filter, offset, limit)
+	var users []*entities.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Search(ctx context.Context, searchTerm string, offset int64, limit int64, filter repositories.ListFilter) ([]*entities.User, error) {
+	cursor, err := r.FindWithPagination(ctx, withListFilter(searchFilter(searchTerm), filter), offset, limit, sortFor(filter))
 	if err != nil {
 		return nil, err
 	}
@@ -96,26 +134,128 @@ func (r *UserRepository) Search(ctx context.Context, searchTerm string, offset i
 	return users, nil
 }
 
-func (r *UserRepository) CountSearch(ctx context.Context, searchTerm string) (int64, error) {
-	// Cria filtro de busca usando regex para buscar no nome e email
-	filter := bson.M{
+func (r *UserRepository) CountSearch(ctx context.Context, searchTerm string, filter repositories.ListFilter) (int64, error) {
+	return r.CountWithFilter(ctx, withListFilter(searchFilter(searchTerm), filter))
+}
+
+// searchFilter cria o filtro de busca usando regex para buscar no nome e email
+func searchFilter(searchTerm string) bson.M {
+	return bson.M{
 		"$or": []bson.M{
 			{"name": bson.M{mongoRegex: searchTerm, mongoOptions: "i"}},
 			{"email": bson.M{mongoRegex: searchTerm, mongoOptions: "i"}},
 		},
 	}
-
-	return r.CountWithFilter(ctx, filter)
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
-	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": user.ID}, bson.M{"$set": bson.M{
-		"name":  user.Name,
-		"email": user.Email,
-	}})
+	update := bson.M{"$set": bson.M{
+		"name":       user.Name,
+		"email":      user.Email,
+		"updated_at": user.UpdatedAt,
+		"updated_by": user.UpdatedBy,
+	}}
+	// Um email alterado ainda não foi verificado
+	if user.EmailVerifiedAt == nil {
+		update["$unset"] = bson.M{"email_verified_at": ""}
+	}
+	_, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": user.ID}, false), update)
+	if mongo.IsDuplicateKeyError(err) {
+		return entities.ErrEmailTaken
+	}
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to update user")
+	}
 	return err
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	return r.DeleteByID(ctx, id)
+func (r *UserRepository) UpdateStatus(ctx context.Context, user *entities.User, from entities.UserStatus) error {
+	// O filtro pelo status atual evita que duas transições concorrentes se sobreponham
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": user.ID, "status": from}, false), bson.M{"$set": bson.M{
+		"status":            user.Status,
+		"status_reason":     user.StatusReason,
+		"status_changed_at": user.StatusChangedAt,
+		"status_changed_by": user.StatusChangedBy,
+		"updated_at":        user.UpdatedAt,
+		"updated_by":        user.UpdatedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to update user status")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *UserRepository) AcceptInvitation(ctx context.Context, user *entities.User) error {
+	set := bson.M{
+		"status":            entities.UserStatusActive,
+		"status_reason":     user.StatusReason,
+		"status_changed_at": user.StatusChangedAt,
+		"status_changed_by": user.StatusChangedBy,
+		"email_verified_at": user.EmailVerifiedAt,
+		"updated_at":        user.UpdatedAt,
+		"updated_by":        user.UpdatedBy,
+	}
+	if user.PasswordHash != "" {
+		set["password_hash"] = user.PasswordHash
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": user.ID, "status": entities.UserStatusPending}, false), bson.M{"$set": set})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to activate invited user")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, user *entities.User) error {
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": user.ID}, false), bson.M{"$set": bson.M{
+		"password_hash":       user.PasswordHash,
+		"sessions_revoked_at": user.SessionsRevokedAt,
+		"updated_at":          user.UpdatedAt,
+		"updated_by":          user.UpdatedBy,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", user.ID.Hex()).Error("Failed to update user password")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *UserRepository) RevokeSessions(ctx context.Context, id string, revokedAt time.Time) error {
+	objectID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objectID}, false), bson.M{"$set": bson.M{
+		"sessions_revoked_at": revokedAt,
+	}})
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("user_id", id).Error("Failed to revoke user sessions")
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id string, deletedAt time.Time, deletedBy string) error {
+	return r.SoftDeleteByID(ctx, id, deletedAt, deletedBy)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string, restoredAt time.Time, restoredBy string) error {
+	return r.RestoreByID(ctx, id, restoredAt, restoredBy)
+}
+
+func (r *UserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	return r.BaseRepository.PurgeDeleted(ctx, deletedBefore)
 }
@@ -0,0 +1,124 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	firstNames = []string{
+		"Ana", "Bruno", "Carla", "Daniel", "Eduarda", "Felipe", "Gabriela", "Henrique", "Isabela", "João",
+		"Larissa", "Lucas", "Mariana", "Matheus", "Natália", "Otávio", "Paula", "Rafael", "Sofia", "Thiago",
+		"Alice", "Benjamin", "Chloe", "David", "Emma", "Ethan", "Grace", "Hannah", "Isaac", "Jack",
+		"Liam", "Mia", "Noah", "Olivia", "Priya", "Ravi", "Sara", "Wei", "Yuki", "Zoe",
+	}
+	lastNames = []string{
+		"Silva", "Santos", "Oliveira", "Souza", "Rodrigues", "Ferreira", "Alves", "Pereira", "Lima", "Gomes",
+		"Costa", "Ribeiro", "Martins", "Carvalho", "Almeida", "Lopes", "Araújo", "Barbosa", "Rocha", "Dias",
+		"Smith", "Johnson", "Brown", "Garcia", "Miller", "Davis", "Wilson", "Anderson", "Taylor", "Moore",
+		"Nguyen", "Kim", "Patel", "Müller", "Rossi", "Tanaka", "Cohen", "Novak", "O'Brien", "Schmidt",
+	}
+	emailDomains = []string{"example.com", "example.org", "example.net", "mail.test", "corp.test"}
+
+	teamAreas = []string{
+		"Platform", "Payments", "Identity", "Growth", "Mobile", "Data", "Security", "Infrastructure",
+		"Marketing", "Sales", "Finance", "Support", "Design", "Research", "Operations", "Legal",
+	}
+	teamKinds = []string{"Engineering", "Team", "Squad", "Guild", "Admins", "Reviewers", "On-call", "Leads"}
+)
+
+// Generator produz dados fictícios realistas e reproduzíveis: a mesma seed gera a mesma sequência
+type Generator struct {
+	rand   *rand.Rand
+	emails map[string]int
+	groups map[string]int
+}
+
+func NewGenerator(seed int64) *Generator {
+	return &Generator{
+		rand:   rand.New(rand.NewSource(seed)),
+		emails: make(map[string]int),
+		groups: make(map[string]int),
+	}
+}
+
+// User gera um usuário com nome, email único (dentro do Generator) e ~85% de usuários ativos
+func (g *Generator) User() dto.CreateUserRequestDTO {
+	first := g.pick(firstNames)
+	last := g.pick(lastNames)
+
+	local := emailLocalPart(first) + "." + emailLocalPart(last)
+	domain := g.pick(emailDomains)
+	email := unique(g.emails, local+"@"+domain, func(n int) string {
+		return fmt.Sprintf("%s%d@%s", local, n, domain)
+	})
+
+	status := entities.UserStatusActive
+	if g.rand.Float64() >= 0.85 {
+		status = entities.UserStatusPending
+	}
+
+	return dto.CreateUserRequestDTO{
+		Name:   first + " " + last,
+		Email:  email,
+		Status: string(status),
+	}
+}
+
+// GroupName gera um nome de grupo único (dentro do Generator), ex.: "Payments Squad"
+func (g *Generator) GroupName() string {
+	name := g.pick(teamAreas) + " " + g.pick(teamKinds)
+	return unique(g.groups, name, func(n int) string {
+		return fmt.Sprintf("%s %d", name, n)
+	})
+}
+
+// Sample escolhe até n elementos distintos de items, sem alterar o slice original
+func (g *Generator) Sample(items []string, n int) []string {
+	if n > len(items) {
+		n = len(items)
+	}
+	sample := make([]string, 0, n)
+	for _, i := range g.rand.Perm(len(items))[:n] {
+		sample = append(sample, items[i])
+	}
+	return sample
+}
+
+// Intn retorna um inteiro em [0, n)
+func (g *Generator) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return g.rand.Intn(n)
+}
+
+func (g *Generator) pick(items []string) string {
+	return items[g.rand.Intn(len(items))]
+}
+
+// unique retorna value na primeira ocorrência e variações numeradas (value2, value3...) nas seguintes
+func unique(seen map[string]int, value string, numbered func(n int) string) string {
+	seen[value]++
+	if n := seen[value]; n > 1 {
+		return numbered(n)
+	}
+	return value
+}
+
+// emailLocalPart remove acentos e caracteres que não são letras (ex.: "Araújo" -> "araujo")
+func emailLocalPart(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/user"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Options define o volume de dados gerado pelo Seeder
+type Options struct {
+	Users      int
+	Groups     int
+	MaxMembers int // máximo de membros por grupo; 0 usa todos os usuários criados como limite
+	Seed       int64
+}
+
+// Result resume o que foi gravado no banco
+type Result struct {
+	Seed         int64 `json:"seed"`
+	UsersCreated int   `json:"users_created"`
+	UsersSkipped int   `json:"users_skipped"`
+	Groups       int   `json:"groups_created"`
+	Memberships  int   `json:"memberships"`
+}
+
+// Seeder grava dados fictícios através dos mesmos use cases usados pela API
+type Seeder struct {
+	createUser     *user.CreateUserUseCase
+	createGroup    *group.CreateGroupUseCase
+	addUserToGroup *group.AddUserToGroupUseCase
+}
+
+func NewSeeder(createUser *user.CreateUserUseCase, createGroup *group.CreateGroupUseCase, addUserToGroup *group.AddUserToGroupUseCase) *Seeder {
+	return &Seeder{createUser: createUser, createGroup: createGroup, addUserToGroup: addUserToGroup}
+}
+
+// Run cria opts.Users usuários e opts.Groups grupos com membros aleatórios. Emails que já
+// existem no banco (ex.: ao repetir a mesma seed) são ignorados e contados em UsersSkipped
+func (s *Seeder) Run(ctx context.Context, opts Options) (*Result, error) {
+	gen := NewGenerator(opts.Seed)
+	result := &Result{Seed: opts.Seed}
+	log := logger.FromContext(ctx)
+
+	userIDs := make([]string, 0, opts.Users)
+	sendInvitation := false
+	for i := 0; i < opts.Users; i++ {
+		input := gen.User()
+		// Os emails gerados são fictícios: os usuários pending não recebem convite
+		input.SendInvitation = &sendInvitation
+		created, err := s.createUser.Execute(ctx, &input)
+		if errors.Is(err, entities.ErrEmailTaken) {
+			result.UsersSkipped++
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to create user %d: %w", i+1, err)
+		}
+		userIDs = append(userIDs, created.ID)
+		result.UsersCreated++
+	}
+
+	maxMembers := opts.MaxMembers
+	if maxMembers <= 0 || maxMembers > len(userIDs) {
+		maxMembers = len(userIDs)
+	}
+
+	for i := 0; i < opts.Groups; i++ {
+		created, err := s.createGroup.Execute(ctx, &dto.CreateGroupRequestDTO{Name: gen.GroupName()})
+		if err != nil {
+			return result, fmt.Errorf("failed to create group %d: %w", i+1, err)
+		}
+		result.Groups++
+
+		// O primeiro membro sorteado é o owner do grupo
+		for j, userID := range gen.Sample(userIDs, gen.Intn(maxMembers+1)) {
+			role := entities.GroupRoleMember
+			if j == 0 {
+				role = entities.GroupRoleOwner
+			}
+			if err := s.addUserToGroup.Execute(ctx, created.ID, userID, role); err != nil {
+				return result, fmt.Errorf("failed to add user %s to group %s: %w", userID, created.ID, err)
+			}
+			result.Memberships++
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"seed":          result.Seed,
+		"users_created": result.UsersCreated,
+		"users_skipped": result.UsersSkipped,
+		"groups":        result.Groups,
+		"memberships":   result.Memberships,
+	}).Info("Seed completed")
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package signing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedKeyPrefix identifica as chaves privadas cifradas; chaves gravadas antes da cifragem
+// estão em PEM puro
+const encryptedKeyPrefix = "aes256gcm:"
+
+// keyCipher cifra as chaves privadas gravadas em signing_keys com AES-256-GCM e a chave
+// OIDC_KEY_ENCRYPTION_KEY. O kid entra como dado autenticado, de modo que uma chave cifrada não
+// pode ser copiada para outro documento
+type keyCipher struct {
+	aead cipher.AEAD
+}
+
+func newKeyCipher(encoded string) (*keyCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("OIDC_KEY_ENCRYPTION_KEY must be 32 bytes encoded in base64")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &keyCipher{aead: aead}, nil
+}
+
+// seal cifra a chave privada em PEM para ser gravada no documento de kid
+func (c *keyCipher) seal(kid string, plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(kid))
+	return encryptedKeyPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decifra a chave gravada no documento de kid. encrypted é false para as chaves antigas,
+// gravadas em PEM puro, que são devolvidas como estão
+func (c *keyCipher) open(kid, stored string) (plaintext []byte, encrypted bool, err error) {
+	encoded, ok := strings.CutPrefix(stored, encryptedKeyPrefix)
+	if !ok {
+		return []byte(stored), false, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return nil, true, errors.New("invalid encrypted key")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	if plaintext, err = c.aead.Open(nil, nonce, ciphertext, []byte(kid)); err != nil {
+		return nil, true, fmt.Errorf("failed to decrypt key (wrong OIDC_KEY_ENCRYPTION_KEY?): %w", err)
+	}
+	return plaintext, true, nil
+}
@@ -0,0 +1,323 @@
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// keySize é o tamanho das chaves RSA geradas
+	keySize = 2048
+	// cacheTTL é por quanto tempo as chaves lidas do banco são reutilizadas; limita o atraso com
+	// que uma réplica enxerga a chave gerada por outra
+	cacheTTL = time.Minute
+	// minReloadInterval limita as leituras forçadas por tokens com kid desconhecido: o userinfo é
+	// público e qualquer um pode enviar kids aleatórios
+	minReloadInterval = time.Second
+)
+
+type loadedKey struct {
+	kid        string
+	privateKey *rsa.PrivateKey
+	createdAt  time.Time
+}
+
+// KeyManager guarda as chaves RS256 do provedor OpenID Connect na coleção signing_keys, para que
+// todas as réplicas assinem com as mesmas chaves. Uma chave nova é gerada quando a atual fica
+// mais velha que OIDC_KEY_ROTATION_INTERVAL; as anteriores continuam publicadas no JWKS por mais
+// OIDC_TOKEN_TTL, o tempo de vida dos últimos tokens assinados com elas, e depois são apagadas.
+// As chaves privadas são gravadas cifradas com OIDC_KEY_ENCRYPTION_KEY
+type KeyManager struct {
+	repo     repositories.ISigningKeyRepository
+	cipher   *keyCipher
+	clock    services.IClock
+	issuer   string
+	rotation time.Duration
+	tokenTTL time.Duration
+
+	// mu protege o cache e é mantido apenas para lê-lo ou trocá-lo; loadMu serializa as leituras do
+	// banco e as trocas de chave, que acontecem fora de mu
+	mu       sync.RWMutex
+	keys     []*loadedKey // das mais novas para as mais antigas
+	loadedAt time.Time
+	loadMu   sync.Mutex
+}
+
+func NewKeyManager(cfg *config.Config, repo repositories.ISigningKeyRepository, clock services.IClock) (*KeyManager, error) {
+	keyCipher, err := newKeyCipher(cfg.OIDCKeyEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyManager{
+		repo:     repo,
+		cipher:   keyCipher,
+		clock:    clock,
+		issuer:   cfg.OIDCIssuer,
+		rotation: cfg.OIDCKeyRotationInterval,
+		tokenTTL: cfg.OIDCTokenTTL,
+	}, nil
+}
+
+// Sign assina as claims com a chave mais recente, gerando uma nova se ela tiver vencido
+func (m *KeyManager) Sign(ctx context.Context, claims map[string]interface{}) (string, error) {
+	keys, err := m.load(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	if m.expired(keys) {
+		if keys, err = m.rotateExpired(ctx); err != nil {
+			return "", err
+		}
+	}
+	current := keys[0]
+
+	mapClaims := jwt.MapClaims{}
+	for name, value := range claims {
+		mapClaims[name] = value
+	}
+	mapClaims["iss"] = m.issuer
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
+	token.Header["kid"] = current.kid
+	return token.SignedString(current.privateKey)
+}
+
+// Verify valida o token com a chave indicada no cabeçalho kid. Uma chave desconhecida recarrega
+// as chaves do banco antes de recusar o token, pois pode ter sido gerada por outra réplica; as
+// recargas acontecem no máximo uma vez por minReloadInterval
+func (m *KeyManager) Verify(ctx context.Context, raw string, audience string) (map[string]interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := m.find(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		return &key.privateKey.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.clock.Now),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenUnverifiable) && !errors.Is(err, errUnknownKey) {
+			return nil, err
+		}
+		return nil, services.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+var errUnknownKey = errors.New("unknown signing key")
+
+func (m *KeyManager) find(ctx context.Context, kid string) (*loadedKey, error) {
+	for _, reload := range []bool{false, true} {
+		keys, err := m.load(ctx, reload)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if key.kid == kid {
+				return key, nil
+			}
+		}
+	}
+	return nil, errUnknownKey
+}
+
+// JWK é uma chave pública no formato do RFC 7517
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKS retorna as chaves públicas publicadas, das mais novas para as mais antigas
+func (m *KeyManager) JWKS(ctx context.Context) ([]JWK, error) {
+	keys, err := m.load(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	jwks := make([]JWK, 0, len(keys))
+	for _, key := range keys {
+		public := key.privateKey.PublicKey
+		jwks = append(jwks, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			KeyID:     key.kid,
+			Modulus:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		})
+	}
+	return jwks, nil
+}
+
+// Rotate gera uma nova chave de assinatura imediatamente (ex.: suspeita de vazamento da atual) e
+// retorna o seu kid. A chave anterior continua publicada até os tokens assinados com ela expirarem
+func (m *KeyManager) Rotate(ctx context.Context) (string, error) {
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	keys, err := m.rotate(ctx)
+	if err != nil {
+		return "", err
+	}
+	return keys[0].kid, nil
+}
+
+// expired indica se não há chave ou se a mais recente já deveria ter sido trocada
+func (m *KeyManager) expired(keys []*loadedKey) bool {
+	return len(keys) == 0 || !m.clock.Now().Before(keys[0].createdAt.Add(m.rotation))
+}
+
+// rotateExpired troca a chave vencida, a menos que outra chamada (ou outra réplica) já a tenha
+// trocado enquanto esta esperava por loadMu
+func (m *KeyManager) rotateExpired(ctx context.Context) ([]*loadedKey, error) {
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	keys, err := m.refresh(ctx)
+	if err != nil || !m.expired(keys) {
+		return keys, err
+	}
+	return m.rotate(ctx)
+}
+
+// rotate grava uma nova chave, apaga as que não são mais publicadas e recarrega as demais; deve
+// ser chamado com loadMu. Réplicas que trocam a chave ao mesmo tempo geram chaves diferentes;
+// todas são publicadas e a mais nova passa a assinar
+func (m *KeyManager) rotate(ctx context.Context) ([]*loadedKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	thumbprint := sha256.Sum256(publicDER)
+	kid := base64.RawURLEncoding.EncodeToString(thumbprint[:12])
+	sealed, err := m.cipher.seal(kid, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.clock.Now()
+	key := &entities.SigningKey{
+		KID:        kid,
+		Algorithm:  jwt.SigningMethodRS256.Alg(),
+		PrivateKey: sealed,
+		CreatedAt:  now,
+	}
+	if err := m.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := m.repo.DeleteCreatedBefore(ctx, m.publishedAfter(now)); err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("Failed to delete expired signing keys")
+	}
+	logger.FromContext(ctx).WithField("kid", key.KID).Info("Signing key rotated")
+	return m.refresh(ctx)
+}
+
+// publishedAfter é a data de criação a partir da qual uma chave ainda pode ter tokens válidos
+func (m *KeyManager) publishedAfter(now time.Time) time.Time {
+	return now.Add(-m.rotation - m.tokenTTL)
+}
+
+// load retorna as chaves publicadas, lendo do banco se o cache tiver vencido ou se reload for
+// true. Chamadas simultâneas fazem uma única leitura
+func (m *KeyManager) load(ctx context.Context, reload bool) ([]*loadedKey, error) {
+	if keys, ok := m.cached(reload); ok {
+		return keys, nil
+	}
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+	// outra chamada pode ter lido o banco enquanto esta esperava
+	if keys, ok := m.cached(reload); ok {
+		return keys, nil
+	}
+	return m.refresh(ctx)
+}
+
+// cached retorna as chaves em cache se ainda valerem; com reload, só as lidas há menos de
+// minReloadInterval
+func (m *KeyManager) cached(reload bool) ([]*loadedKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.keys == nil {
+		return nil, false
+	}
+	age := m.clock.Now().Sub(m.loadedAt)
+	return m.keys, age < minReloadInterval || (!reload && age < cacheTTL)
+}
+
+// refresh lê as chaves publicadas do banco e troca o cache; deve ser chamado com loadMu
+func (m *KeyManager) refresh(ctx context.Context) ([]*loadedKey, error) {
+	now := m.clock.Now()
+	stored, err := m.repo.ListCreatedAfter(ctx, m.publishedAfter(now))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*loadedKey, 0, len(stored))
+	for _, key := range stored {
+		encoded, encrypted, err := m.cipher.open(key.KID, key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", key.KID, err)
+		}
+		if !encrypted {
+			logger.FromContext(ctx).WithField("kid", key.KID).Warn("Signing key stored without encryption; run oidc rotate-keys to replace it")
+		}
+		privateKey, err := parsePrivateKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", key.KID, err)
+		}
+		keys = append(keys, &loadedKey{kid: key.KID, privateKey: privateKey, createdAt: key.CreatedAt})
+	}
+	m.mu.Lock()
+	m.keys, m.loadedAt = keys, now
+	m.mu.Unlock()
+	return keys, nil
+}
+
+func parsePrivateKey(encoded []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(encoded)
+	if block == nil {
+		return nil, errors.New("invalid PEM")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA key")
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,85 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// claims são as claims dos access tokens emitidos pelo serviço
+type claims struct {
+	Admin         bool   `json:"admin,omitempty"`
+	Tenant        string `json:"tenant,omitempty"`
+	PlatformAdmin bool   `json:"platform_admin,omitempty"`
+	MFA           bool   `json:"mfa,omitempty"`
+	SessionID     string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Service emite e valida access tokens JWT assinados com HS256 usando AUTH_TOKEN_SECRET, com o
+// emissor AUTH_TOKEN_ISSUER
+type Service struct {
+	secret []byte
+	ttl    time.Duration
+	issuer string
+}
+
+func NewService(cfg *config.Config) *Service {
+	return &Service{secret: []byte(cfg.AuthTokenSecret), ttl: cfg.AuthTokenTTL, issuer: cfg.AuthTokenIssuer}
+}
+
+// Issue emite um access token para o principal, válido por AUTH_TOKEN_TTL
+func (s *Service) Issue(principal auth.Principal) (string, time.Time, error) {
+	if len(s.secret) == 0 {
+		return "", time.Time{}, fmt.Errorf("AUTH_TOKEN_SECRET is not configured")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Admin:         principal.Admin,
+		Tenant:        principal.TenantID,
+		PlatformAdmin: principal.PlatformAdmin,
+		MFA:           principal.MFA,
+		SessionID:     principal.SessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   principal.ID,
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	signed, err := token.SignedString(s.secret)
+	return signed, expiresAt, err
+}
+
+// Verify valida assinatura, emissor e expiração e retorna o principal do token
+func (s *Service) Verify(raw string) (auth.Principal, error) {
+	var parsed claims
+	_, err := jwt.ParseWithClaims(raw, &parsed, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired())
+	if err != nil || parsed.Subject == "" {
+		return auth.Principal{}, ErrInvalidToken
+	}
+	var issuedAt time.Time
+	if parsed.IssuedAt != nil {
+		issuedAt = parsed.IssuedAt.Time
+	}
+	return auth.Principal{
+		ID:            parsed.Subject,
+		Type:          auth.PrincipalUser,
+		TenantID:      parsed.Tenant,
+		Admin:         parsed.Admin,
+		PlatformAdmin: parsed.PlatformAdmin,
+		MFA:           parsed.MFA,
+		IssuedAt:      issuedAt,
+		SessionID:     parsed.SessionID,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/apikey"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	apiKeyNotFoundError = "API key not found"
+)
+
+// APIKeyController gerencia as API keys do tenant da requisição, restrito aos administradores
+type APIKeyController struct {
+	validator           *validators.InputValidator
+	createAPIKeyUseCase *apikey.CreateAPIKeyUseCase
+	getAPIKeyUseCase    *apikey.GetAPIKeyUseCase
+	listAPIKeysUseCase  *apikey.ListAPIKeysUseCase
+	revokeAPIKeyUseCase *apikey.RevokeAPIKeyUseCase
+}
+
+func NewAPIKeyController(createAPIKey *apikey.CreateAPIKeyUseCase, getAPIKey *apikey.GetAPIKeyUseCase, listAPIKeys *apikey.ListAPIKeysUseCase, revokeAPIKey *apikey.RevokeAPIKeyUseCase) *APIKeyController {
+	return &APIKeyController{
+		validator:           validators.NewInputValidator(),
+		createAPIKeyUseCase: createAPIKey,
+		getAPIKeyUseCase:    getAPIKey,
+		listAPIKeysUseCase:  listAPIKeys,
+		revokeAPIKeyUseCase: revokeAPIKey,
+	}
+}
+
+func (h *APIKeyController) Create(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "APIKeyController.Create")
+	defer span.End()
+
+	var input dto.CreateAPIKeyRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	created, err := h.createAPIKeyUseCase.Execute(ctx, &input)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	// a resposta traz o segredo, exibido uma única vez
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+func (h *APIKeyController) Get(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "APIKeyController.Get")
+	defer span.End()
+
+	key, err := h.getAPIKeyUseCase.Execute(ctx, c.Params("id"))
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.JSON(key)
+}
+
+func (h *APIKeyController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "APIKeyController.List")
+	defer span.End()
+
+	var input dto.PageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	keys, err := h.listAPIKeysUseCase.Execute(ctx, &input)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.JSON(keys)
+}
+
+func (h *APIKeyController) Revoke(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "APIKeyController.Revoke")
+	defer span.End()
+
+	if err := h.revokeAPIKeyUseCase.Execute(ctx, c.Params("id")); err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// apiKeyErrorResponse traduz os erros dos casos de uso de API keys
+func apiKeyErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+		return errorResponse(c, fiber.StatusNotFound, apiKeyNotFoundError)
+	case errors.Is(err, apikey.ErrExpiryInPast):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrAPIKeyRevoked):
+		return errorResponse(c, fiber.StatusConflict, err.Error())
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"errors"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/usecases/group"
+	"user-management/internal/domain/entities"
 	"user-management/internal/infrastructure/web/validators"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
@@ -22,9 +26,19 @@ type GroupController struct {
 	listGroupsUseCase          *group.ListGroupsUseCase
 	addUserToGroupUseCase      *group.AddUserToGroupUseCase
 	removeUserFromGroupUseCase *group.RemoveUserFromGroupUseCase
+	restoreGroupUseCase        *group.RestoreGroupUseCase
+	addSubgroupUseCase         *group.AddSubgroupUseCase
+	removeSubgroupUseCase      *group.RemoveSubgroupUseCase
+	effectiveMembersUseCase    *group.ListEffectiveMembersUseCase
+	updateMemberRoleUseCase    *group.UpdateMemberRoleUseCase
+	listMembersUseCase         *group.ListGroupMembersUseCase
+	addMembersUseCase          *group.AddGroupMembersUseCase
+	removeMembersUseCase       *group.RemoveGroupMembersUseCase
+	replaceMembersUseCase      *group.ReplaceGroupMembersUseCase
+	setMFAPolicyUseCase        *group.SetGroupMFAPolicyUseCase
 }
 
-func NewGroupController(createGroup *group.CreateGroupUseCase, getGroup *group.GetGroupUseCase, updateGroup *group.UpdateGroupUseCase, deleteGroup *group.DeleteGroupUseCase, listGroups *group.ListGroupsUseCase, addUserToGroup *group.AddUserToGroupUseCase, removeUserFromGroup *group.RemoveUserFromGroupUseCase) *GroupController {
+func NewGroupController(createGroup *group.CreateGroupUseCase, getGroup *group.GetGroupUseCase, updateGroup *group.UpdateGroupUseCase, deleteGroup *group.DeleteGroupUseCase, listGroups *group.ListGroupsUseCase, addUserToGroup *group.AddUserToGroupUseCase, removeUserFromGroup *group.RemoveUserFromGroupUseCase, restoreGroup *group.RestoreGroupUseCase, addSubgroup *group.AddSubgroupUseCase, removeSubgroup *group.RemoveSubgroupUseCase, effectiveMembers *group.ListEffectiveMembersUseCase, updateMemberRole *group.UpdateMemberRoleUseCase, listMembers *group.ListGroupMembersUseCase, addMembers *group.AddGroupMembersUseCase, removeMembers *group.RemoveGroupMembersUseCase, replaceMembers *group.ReplaceGroupMembersUseCase, setMFAPolicy *group.SetGroupMFAPolicyUseCase) *GroupController {
 	return &GroupController{
 		validator:                  validators.NewInputValidator(),
 		createGroupUseCase:         createGroup,
@@ -34,97 +48,394 @@ func NewGroupController(createGroup *group.CreateGroupUseCase, getGroup *group.G
 		listGroupsUseCase:          listGroups,
 		addUserToGroupUseCase:      addUserToGroup,
 		removeUserFromGroupUseCase: removeUserFromGroup,
+		restoreGroupUseCase:        restoreGroup,
+		addSubgroupUseCase:         addSubgroup,
+		removeSubgroupUseCase:      removeSubgroup,
+		effectiveMembersUseCase:    effectiveMembers,
+		updateMemberRoleUseCase:    updateMemberRole,
+		listMembersUseCase:         listMembers,
+		addMembersUseCase:          addMembers,
+		removeMembersUseCase:       removeMembers,
+		replaceMembersUseCase:      replaceMembers,
+		setMFAPolicyUseCase:        setMFAPolicy,
 	}
 }
 
 func (h *GroupController) Create(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Create")
+	defer span.End()
+
 	var createGroupDTO dto.CreateGroupRequestDTO
 
 	if err := h.validator.ParseAndValidate(c, &createGroupDTO); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
 	}
 
-	groupDTO, err := h.createGroupUseCase.Execute(c.Context(), &createGroupDTO)
+	groupDTO, err := h.createGroupUseCase.Execute(ctx, &createGroupDTO)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.Status(fiber.StatusCreated).JSON(groupDTO)
 }
 
 func (h *GroupController) Get(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Get")
+	defer span.End()
+
 	id := c.Params("id")
-	groupDTO, err := h.getGroupUseCase.Execute(c.Context(), id)
+	groupDTO, err := h.getGroupUseCase.Execute(ctx, id, c.QueryBool("include_deleted"))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": groupNotFoundError})
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
 	}
 	return c.JSON(groupDTO)
 }
 
 func (h *GroupController) Update(c *fiber.Ctx) error {
-	var updateGroupDTO dto.CreateGroupRequestDTO
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Update")
+	defer span.End()
+
+	var updateGroupDTO dto.UpdateGroupRequestDTO
 
 	if err := h.validator.ParseAndValidate(c, &updateGroupDTO); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
 	}
 
 	groupID := c.Params("id")
-	responseDTO, err := h.updateGroupUseCase.Execute(c.Context(), groupID, &updateGroupDTO)
+	responseDTO, err := h.updateGroupUseCase.Execute(ctx, groupID, &updateGroupDTO)
 	if err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		if errors.Is(err, entities.ErrLastOwner) {
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		}
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": groupNotFoundError})
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(responseDTO)
 }
 
 func (h *GroupController) Delete(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Delete")
+	defer span.End()
+
 	id := c.Params("id")
-	if err := h.deleteGroupUseCase.Execute(c.Context(), id); err != nil {
+	if err := h.deleteGroupUseCase.Execute(ctx, id); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": groupNotFoundError})
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
 func (h *GroupController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.List")
+	defer span.End()
+
 	var input dto.ListGroupQueryParam
 	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid query parameters"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
 	}
 
-	groups, err := h.listGroupsUseCase.Execute(c.Context(), &input)
+	groups, err := h.listGroupsUseCase.Execute(ctx, &input)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(groups)
 }
 
 func (h *GroupController) AddUser(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.AddUser")
+	defer span.End()
+
+	// O body com o papel é opcional; sem ele o usuário entra como member
+	var input dto.AddGroupMemberRequestDTO
+	if len(c.Body()) > 0 {
+		if err := h.validator.ParseAndValidate(c, &input); err != nil {
+			if validationErr, ok := err.(*validators.ValidationError); ok {
+				return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+			}
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+		}
+	}
+	role := entities.GroupRoleMember
+	if input.Role != "" {
+		role = entities.GroupRole(input.Role)
+	}
+
 	groupID := c.Params("groupId")
 	userID := c.Params("userId")
-	if err := h.addUserToGroupUseCase.Execute(c.Context(), groupID, userID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	if err := h.addUserToGroupUseCase.Execute(ctx, groupID, userID, role); err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		if errors.Is(err, group.ErrUserDeprovisioned) {
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
 
+func (h *GroupController) UpdateMember(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.UpdateMember")
+	defer span.End()
+
+	var input dto.UpdateGroupMemberRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	groupDTO, err := h.updateMemberRoleUseCase.Execute(ctx, c.Params("groupId"), c.Params("userId"), entities.GroupRole(input.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, entities.ErrLastOwner):
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, group.ErrMemberNotFound):
+			return errorResponse(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(groupDTO)
+}
+
 func (h *GroupController) RemoveUser(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.RemoveUser")
+	defer span.End()
+
 	groupID := c.Params("groupId")
 	userID := c.Params("userId")
-	if err := h.removeUserFromGroupUseCase.Execute(c.Context(), groupID, userID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	if err := h.removeUserFromGroupUseCase.Execute(ctx, groupID, userID); err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		if errors.Is(err, entities.ErrLastOwner) {
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
+
+func (h *GroupController) AddMembers(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.AddMembers")
+	defer span.End()
+
+	var input dto.AddGroupMembersRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	diff, err := h.addMembersUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		return bulkMembersErrorResponse(c, err)
+	}
+	return c.JSON(diff)
+}
+
+func (h *GroupController) RemoveMembers(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.RemoveMembers")
+	defer span.End()
+
+	var input dto.RemoveGroupMembersRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	diff, err := h.removeMembersUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		return bulkMembersErrorResponse(c, err)
+	}
+	return c.JSON(diff)
+}
+
+func (h *GroupController) ReplaceMembers(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.ReplaceMembers")
+	defer span.End()
+
+	var input dto.ReplaceGroupMembersRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	diff, err := h.replaceMembersUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		return bulkMembersErrorResponse(c, err)
+	}
+	return c.JSON(diff)
+}
+
+// bulkMembersErrorResponse traduz os erros comuns às operações de membros em lote
+func bulkMembersErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, group.ErrUsersNotFound):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, group.ErrUserDeprovisioned), errors.Is(err, entities.ErrLastOwner), errors.Is(err, entities.ErrGroupModified):
+		return errorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+		return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+	}
+	return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+}
+
+func (h *GroupController) Restore(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Restore")
+	defer span.End()
+
+	id := c.Params("id")
+	responseDTO, err := h.restoreGroupUseCase.Execute(ctx, id)
+	if err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		if err == mongo.ErrNoDocuments {
+			return errorResponse(c, fiber.StatusNotFound, "Deleted group not found")
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(responseDTO)
+}
+
+// SetMFAPolicy liga ou desliga a exigência de MFA para os membros efetivos do grupo
+func (h *GroupController) SetMFAPolicy(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.SetMFAPolicy")
+	defer span.End()
+
+	var input dto.GroupMFAPolicyRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	responseDTO, err := h.setMFAPolicyUseCase.Execute(ctx, c.Params("id"), *input.Required)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(responseDTO)
+}
+
+func (h *GroupController) AddSubgroup(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.AddSubgroup")
+	defer span.End()
+
+	groupID := c.Params("groupId")
+	subgroupID := c.Params("subgroupId")
+	if err := h.addSubgroupUseCase.Execute(ctx, groupID, subgroupID); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, entities.ErrGroupCycle):
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *GroupController) RemoveSubgroup(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.RemoveSubgroup")
+	defer span.End()
+
+	groupID := c.Params("groupId")
+	subgroupID := c.Params("subgroupId")
+	if err := h.removeSubgroupUseCase.Execute(ctx, groupID, subgroupID); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *GroupController) Members(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.Members")
+	defer span.End()
+
+	var input dto.SearchPageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	users, err := h.listMembersUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(users)
+}
+
+func (h *GroupController) EffectiveMembers(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "GroupController.EffectiveMembers")
+	defer span.End()
+
+	var input dto.PageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	users, err := h.effectiveMembersUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return errorResponse(c, fiber.StatusNotFound, groupNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(users)
+}
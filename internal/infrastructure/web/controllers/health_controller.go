@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"user-management/internal/infrastructure/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthController struct {
+	service *health.Service
+}
+
+func NewHealthController(service *health.Service) *HealthController {
+	return &HealthController{service: service}
+}
+
+// Live responde 200 enquanto o processo estiver de pé (liveness probe)
+func (h *HealthController) Live(c *fiber.Ctx) error {
+	return c.JSON(h.service.Liveness())
+}
+
+// Ready verifica as dependências e responde 503 se alguma estiver indisponível (readiness probe)
+func (h *HealthController) Ready(c *fiber.Ctx) error {
+	report := h.service.Readiness(c.UserContext())
+	if report.Status != health.StatusUp {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.JSON(report)
+}
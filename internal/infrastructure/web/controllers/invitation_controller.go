@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/user"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// InvitationController expõe a aceitação de convites, uma rota pública: o token do convite é a
+// credencial
+type InvitationController struct {
+	validator     *validators.InputValidator
+	acceptUseCase *user.AcceptInvitationUseCase
+}
+
+func NewInvitationController(accept *user.AcceptInvitationUseCase) *InvitationController {
+	return &InvitationController{
+		validator:     validators.NewInputValidator(),
+		acceptUseCase: accept,
+	}
+}
+
+// Accept ativa a conta do convite; o body com a senha é opcional
+func (h *InvitationController) Accept(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "InvitationController.Accept")
+	defer span.End()
+
+	var input dto.AcceptInvitationRequestDTO
+	if len(c.Body()) > 0 {
+		if err := h.validator.ParseAndValidate(c, &input); err != nil {
+			if validationErr, ok := err.(*validators.ValidationError); ok {
+				return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+			}
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+		}
+	}
+
+	responseDTO, err := h.acceptUseCase.Execute(ctx, c.Params("token"), &input)
+	if err != nil {
+		return invitationErrorResponse(c, err)
+	}
+	return c.JSON(responseDTO)
+}
+
+// invitationErrorResponse converte os erros do envio e da aceitação de convites em respostas HTTP
+func invitationErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrPasswordTooLong):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, user.ErrInvitationNotFound):
+		return errorResponse(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, entities.ErrInvitationExpired), errors.Is(err, entities.ErrInvitationAccepted):
+		return errorResponse(c, fiber.StatusGone, err.Error())
+	case errors.Is(err, user.ErrUserNotPending):
+		return errorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+		return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+	}
+	return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+}
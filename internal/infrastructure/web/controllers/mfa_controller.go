@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// MFAController gerencia o segundo fator (TOTP) do usuário autenticado; Reset é a única operação
+// sobre outro usuário, restrita aos administradores
+type MFAController struct {
+	validator                      *validators.InputValidator
+	getStatusUseCase               *mfa.GetStatusUseCase
+	enrollUseCase                  *mfa.EnrollUseCase
+	confirmUseCase                 *mfa.ConfirmUseCase
+	verifyUseCase                  *mfa.VerifyUseCase
+	regenerateRecoveryCodesUseCase *mfa.RegenerateRecoveryCodesUseCase
+	disableUseCase                 *mfa.DisableUseCase
+	resetUseCase                   *mfa.ResetUseCase
+}
+
+func NewMFAController(getStatus *mfa.GetStatusUseCase, enroll *mfa.EnrollUseCase, confirm *mfa.ConfirmUseCase, verify *mfa.VerifyUseCase, regenerateRecoveryCodes *mfa.RegenerateRecoveryCodesUseCase, disable *mfa.DisableUseCase, reset *mfa.ResetUseCase) *MFAController {
+	return &MFAController{
+		validator:                      validators.NewInputValidator(),
+		getStatusUseCase:               getStatus,
+		enrollUseCase:                  enroll,
+		confirmUseCase:                 confirm,
+		verifyUseCase:                  verify,
+		regenerateRecoveryCodesUseCase: regenerateRecoveryCodes,
+		disableUseCase:                 disable,
+		resetUseCase:                   reset,
+	}
+}
+
+func (h *MFAController) Status(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Status")
+	defer span.End()
+
+	status, err := h.getStatusUseCase.Execute(ctx)
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	return c.JSON(status)
+}
+
+func (h *MFAController) Enroll(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Enroll")
+	defer span.End()
+
+	enrollment, err := h.enrollUseCase.Execute(ctx)
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(enrollment)
+}
+
+func (h *MFAController) Confirm(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Confirm")
+	defer span.End()
+
+	var input dto.MFACodeRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	confirmed, err := h.confirmUseCase.Execute(ctx, &input)
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(confirmed)
+}
+
+func (h *MFAController) Verify(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Verify")
+	defer span.End()
+
+	var input dto.VerifyMFARequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	token, err := h.verifyUseCase.Execute(ctx, &input)
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(token)
+}
+
+func (h *MFAController) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.RegenerateRecoveryCodes")
+	defer span.End()
+
+	var input dto.MFACodeRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	codes, err := h.regenerateRecoveryCodesUseCase.Execute(ctx, &input)
+	if err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(codes)
+}
+
+func (h *MFAController) Disable(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Disable")
+	defer span.End()
+
+	var input dto.MFACodeRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	if err := h.disableUseCase.Execute(ctx, &input); err != nil {
+		return mfaErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Reset apaga o segundo fator do usuário :id (DELETE /users/:id/mfa)
+func (h *MFAController) Reset(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "MFAController.Reset")
+	defer span.End()
+
+	if err := h.resetUseCase.Execute(ctx, c.Params("id")); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errorResponse(c, fiber.StatusNotFound, "MFA enrollment not found")
+		}
+		return mfaErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// mfaErrorResponse traduz os erros dos casos de uso de MFA
+func mfaErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, mfa.ErrUserRequired), errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, mfa.ErrInvalidCode):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, mfa.ErrTooManyAttempts):
+		return errorResponse(c, fiber.StatusTooManyRequests, err.Error())
+	case errors.Is(err, mfa.ErrNotEnabled), errors.Is(err, mfa.ErrNoPendingEnrollment),
+		errors.Is(err, mfa.ErrRequiredByPolicy), errors.Is(err, entities.ErrMFAAlreadyEnabled):
+		return errorResponse(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+		return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	oauthClientNotFoundError = "OAuth client not found"
+)
+
+// OAuthClientController gerencia os clientes OpenID Connect do tenant da requisição, restrito aos
+// administradores
+type OAuthClientController struct {
+	validator             *validators.InputValidator
+	registerClientUseCase *oidc.RegisterClientUseCase
+	getClientUseCase      *oidc.GetClientUseCase
+	listClientsUseCase    *oidc.ListClientsUseCase
+	deleteClientUseCase   *oidc.DeleteClientUseCase
+}
+
+func NewOAuthClientController(registerClient *oidc.RegisterClientUseCase, getClient *oidc.GetClientUseCase, listClients *oidc.ListClientsUseCase, deleteClient *oidc.DeleteClientUseCase) *OAuthClientController {
+	return &OAuthClientController{
+		validator:             validators.NewInputValidator(),
+		registerClientUseCase: registerClient,
+		getClientUseCase:      getClient,
+		listClientsUseCase:    listClients,
+		deleteClientUseCase:   deleteClient,
+	}
+}
+
+func (h *OAuthClientController) Create(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OAuthClientController.Create")
+	defer span.End()
+
+	var input dto.CreateOAuthClientRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	created, err := h.registerClientUseCase.Execute(ctx, &input)
+	if err != nil {
+		return oauthClientErrorResponse(c, err)
+	}
+	// a resposta traz o segredo, exibido uma única vez
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+func (h *OAuthClientController) Get(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OAuthClientController.Get")
+	defer span.End()
+
+	client, err := h.getClientUseCase.Execute(ctx, c.Params("clientId"))
+	if err != nil {
+		return oauthClientErrorResponse(c, err)
+	}
+	return c.JSON(client)
+}
+
+func (h *OAuthClientController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OAuthClientController.List")
+	defer span.End()
+
+	var input dto.PageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	clients, err := h.listClientsUseCase.Execute(ctx, &input)
+	if err != nil {
+		return oauthClientErrorResponse(c, err)
+	}
+	return c.JSON(clients)
+}
+
+func (h *OAuthClientController) Delete(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OAuthClientController.Delete")
+	defer span.End()
+
+	if err := h.deleteClientUseCase.Execute(ctx, c.Params("clientId")); err != nil {
+		return oauthClientErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// oauthClientErrorResponse traduz os erros dos casos de uso de clientes OpenID Connect
+func oauthClientErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return errorResponse(c, fiber.StatusNotFound, oauthClientNotFoundError)
+	case errors.Is(err, oidc.ErrMalformedRedirectURI):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/config"
+	"user-management/internal/infrastructure/signing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Caminhos dos endpoints do provedor, publicados na discovery a partir de OIDC_ISSUER
+const (
+	OIDCAuthorizePath = "/oauth2/authorize"
+	OIDCTokenPath     = "/oauth2/token"
+	OIDCUserInfoPath  = "/oauth2/userinfo"
+	OIDCJWKSPath      = "/oauth2/jwks"
+)
+
+// OIDCController expõe os endpoints do provedor OpenID Connect. Exceto a autorização, que exige
+// o usuário autenticado na API, os endpoints são públicos e respondem erros no formato do OAuth
+type OIDCController struct {
+	discovery        *dto.DiscoveryResponseDTO
+	keys             *signing.KeyManager
+	authorizeUseCase *oidc.AuthorizeUseCase
+	exchangeUseCase  *oidc.ExchangeCodeUseCase
+	userInfoUseCase  *oidc.UserInfoUseCase
+}
+
+func NewOIDCController(cfg *config.Config, keys *signing.KeyManager, authorize *oidc.AuthorizeUseCase, exchange *oidc.ExchangeCodeUseCase, userInfo *oidc.UserInfoUseCase) *OIDCController {
+	return &OIDCController{
+		discovery: &dto.DiscoveryResponseDTO{
+			Issuer:                            cfg.OIDCIssuer,
+			AuthorizationEndpoint:             cfg.OIDCIssuer + OIDCAuthorizePath,
+			TokenEndpoint:                     cfg.OIDCIssuer + OIDCTokenPath,
+			UserInfoEndpoint:                  cfg.OIDCIssuer + OIDCUserInfoPath,
+			JWKSURI:                           cfg.OIDCIssuer + OIDCJWKSPath,
+			ResponseTypesSupported:            []string{"code"},
+			GrantTypesSupported:               []string{"authorization_code"},
+			SubjectTypesSupported:             []string{"public"},
+			IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+			ScopesSupported:                   oidc.Scopes,
+			ClaimsSupported:                   oidc.Claims,
+			CodeChallengeMethodsSupported:     []string{"S256"},
+			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		},
+		keys:             keys,
+		authorizeUseCase: authorize,
+		exchangeUseCase:  exchange,
+		userInfoUseCase:  userInfo,
+	}
+}
+
+func (h *OIDCController) Discovery(c *fiber.Ctx) error {
+	return c.JSON(h.discovery)
+}
+
+func (h *OIDCController) JWKS(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OIDCController.JWKS")
+	defer span.End()
+
+	keys, err := h.keys.JWKS(ctx)
+	if err != nil {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{"keys": keys})
+}
+
+// Authorize redireciona para a redirect_uri do cliente com o código ou com o erro; os erros que
+// impedem confiar na redirect_uri são respondidos diretamente
+func (h *OIDCController) Authorize(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OIDCController.Authorize")
+	defer span.End()
+
+	var input dto.AuthorizeRequestDTO
+	if err := c.QueryParser(&input); err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	location, err := h.authorizeUseCase.Execute(ctx, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, oidc.ErrUserRequired), errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, oidc.ErrInvalidClient), errors.Is(err, oidc.ErrInvalidRedirectURI):
+			return errorResponse(c, fiber.StatusBadRequest, err.Error())
+		default:
+			return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	return c.Redirect(location, fiber.StatusFound)
+}
+
+func (h *OIDCController) Token(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OIDCController.Token")
+	defer span.End()
+
+	var input dto.TokenRequestDTO
+	if err := c.BodyParser(&input); err != nil {
+		return oauthErrorResponse(c, &oidc.Error{Code: oidc.ErrorInvalidRequest, Description: "the request body must be application/x-www-form-urlencoded"})
+	}
+	if clientID, secret, ok := basicCredentials(c.Get(fiber.HeaderAuthorization)); ok {
+		input.ClientID, input.ClientSecret = clientID, secret
+	}
+
+	tokens, err := h.exchangeUseCase.Execute(ctx, &input)
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set(fiber.HeaderPragma, "no-cache")
+	return c.JSON(tokens)
+}
+
+func (h *OIDCController) UserInfo(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "OIDCController.UserInfo")
+	defer span.End()
+
+	scheme, token, _ := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return oauthErrorResponse(c, &oidc.Error{Code: oidc.ErrorInvalidToken, Description: "a Bearer access token is required"})
+	}
+	info, err := h.userInfoUseCase.Execute(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+	return c.JSON(info)
+}
+
+// basicCredentials lê client_id e client_secret de Authorization: Basic; o RFC 6749 manda
+// codificar ambos como formulário antes do base64
+func basicCredentials(header string) (string, string, bool) {
+	scheme, value, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Basic") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", "", false
+	}
+	rawID, rawSecret, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", "", false
+	}
+	clientID, err := url.QueryUnescape(rawID)
+	if err != nil {
+		return "", "", false
+	}
+	secret, err := url.QueryUnescape(rawSecret)
+	if err != nil {
+		return "", "", false
+	}
+	return clientID, secret, true
+}
+
+// oauthErrorResponse responde os erros do protocolo no formato do RFC 6749 (error e
+// error_description); os demais são erros internos
+func oauthErrorResponse(c *fiber.Ctx, err error) error {
+	var oauthErr *oidc.Error
+	if !errors.As(err, &oauthErr) {
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	status := fiber.StatusBadRequest
+	switch oauthErr.Code {
+	case oidc.ErrorInvalidClient:
+		status = fiber.StatusUnauthorized
+		c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="oauth2"`)
+	case oidc.ErrorInvalidToken:
+		status = fiber.StatusUnauthorized
+		c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error":             oauthErr.Code,
+		"error_description": oauthErr.Description,
+	})
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/passwordreset"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/jobs"
+	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PasswordResetController expõe a redefinição de senha; as duas rotas são públicas
+type PasswordResetController struct {
+	validator      *validators.InputValidator
+	requests       *jobs.PasswordResetQueue
+	confirmUseCase *passwordreset.ConfirmUseCase
+}
+
+func NewPasswordResetController(requests *jobs.PasswordResetQueue, confirm *passwordreset.ConfirmUseCase) *PasswordResetController {
+	return &PasswordResetController{
+		validator:      validators.NewInputValidator(),
+		requests:       requests,
+		confirmUseCase: confirm,
+	}
+}
+
+// Request responde 202 para qualquer email válido e deixa o pedido para a fila de redefinição,
+// para que nem o status nem o tempo de resposta revelem se o email está cadastrado
+func (h *PasswordResetController) Request(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "PasswordResetController.Request")
+	defer span.End()
+
+	var input dto.PasswordResetRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	if !h.requests.Enqueue(ctx, input) {
+		logger.FromContext(ctx).Warn("Password reset queue is full or closed; request dropped")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "If the email belongs to an active user, a password reset link has been sent",
+	})
+}
+
+// Confirm grava a nova senha com o token recebido por email
+func (h *PasswordResetController) Confirm(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "PasswordResetController.Confirm")
+	defer span.End()
+
+	var input dto.ConfirmPasswordResetRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	if err := h.confirmUseCase.Execute(ctx, &input, clientInfo(c)); err != nil {
+		if errors.Is(err, passwordreset.ErrInvalidToken) || errors.Is(err, services.ErrPasswordTooLong) {
+			return errorResponse(c, fiber.StatusBadRequest, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
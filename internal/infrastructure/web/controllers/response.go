@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"user-management/internal/infrastructure/web/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errorResponse envia o corpo de erro padrão da API, incluindo o ID da requisição
+// para que o cliente possa correlacioná-lo com os logs
+func errorResponse(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"error":      message,
+		"request_id": middleware.GetRequestID(c),
+	})
+}
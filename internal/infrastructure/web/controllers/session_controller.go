@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// SessionController expõe o login com senha, a renovação por refresh token (ambos públicos) e a
+// gestão das sessões de um usuário
+type SessionController struct {
+	validator        *validators.InputValidator
+	loginUseCase     *session.LoginUseCase
+	refreshUseCase   *session.RefreshUseCase
+	listUseCase      *session.ListUseCase
+	revokeUseCase    *session.RevokeUseCase
+	revokeAllUseCase *session.RevokeAllUseCase
+}
+
+func NewSessionController(login *session.LoginUseCase, refresh *session.RefreshUseCase, list *session.ListUseCase, revoke *session.RevokeUseCase, revokeAll *session.RevokeAllUseCase) *SessionController {
+	return &SessionController{
+		validator:        validators.NewInputValidator(),
+		loginUseCase:     login,
+		refreshUseCase:   refresh,
+		listUseCase:      list,
+		revokeUseCase:    revoke,
+		revokeAllUseCase: revokeAll,
+	}
+}
+
+func (h *SessionController) Login(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "SessionController.Login")
+	defer span.End()
+
+	var input dto.LoginRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	tokens, err := h.loginUseCase.Execute(ctx, &input, clientInfo(c))
+	if err != nil {
+		return sessionErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(tokens)
+}
+
+func (h *SessionController) Refresh(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "SessionController.Refresh")
+	defer span.End()
+
+	var input dto.RefreshSessionRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+	tokens, err := h.refreshUseCase.Execute(ctx, &input, clientInfo(c))
+	if err != nil {
+		return sessionErrorResponse(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(tokens)
+}
+
+func (h *SessionController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "SessionController.List")
+	defer span.End()
+
+	sessions, err := h.listUseCase.Execute(ctx, c.Params("id"))
+	if err != nil {
+		return sessionErrorResponse(c, err)
+	}
+	return c.JSON(sessions)
+}
+
+func (h *SessionController) Revoke(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "SessionController.Revoke")
+	defer span.End()
+
+	if err := h.revokeUseCase.Execute(ctx, c.Params("id"), c.Params("sessionId")); err != nil {
+		return sessionErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *SessionController) RevokeAll(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "SessionController.RevokeAll")
+	defer span.End()
+
+	revoked, err := h.revokeAllUseCase.Execute(ctx, c.Params("id"))
+	if err != nil {
+		return sessionErrorResponse(c, err)
+	}
+	return c.JSON(dto.RevokeSessionsResponseDTO{Revoked: revoked})
+}
+
+// clientInfo identifica o cliente da requisição para as sessões e o log de auditoria
+func clientInfo(c *fiber.Ctx) dto.ClientInfo {
+	return dto.ClientInfo{IP: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
+}
+
+func sessionErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, session.ErrInvalidCredentials), errors.Is(err, session.ErrInvalidRefreshToken):
+		return errorResponse(c, fiber.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrPasswordTooLong):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, session.ErrSessionNotFound):
+		return errorResponse(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+		return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
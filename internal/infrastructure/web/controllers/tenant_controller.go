@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/application/usecases/tenant"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	tenantNotFoundError = "Tenant not found"
+)
+
+// TenantController expõe o cadastro de tenants, restrito aos administradores da plataforma
+type TenantController struct {
+	validator           *validators.InputValidator
+	createTenantUseCase *tenant.CreateTenantUseCase
+	getTenantUseCase    *tenant.GetTenantUseCase
+	listTenantsUseCase  *tenant.ListTenantsUseCase
+	updateTenantUseCase *tenant.UpdateTenantUseCase
+	deleteTenantUseCase *tenant.DeleteTenantUseCase
+}
+
+func NewTenantController(createTenant *tenant.CreateTenantUseCase, getTenant *tenant.GetTenantUseCase, listTenants *tenant.ListTenantsUseCase, updateTenant *tenant.UpdateTenantUseCase, deleteTenant *tenant.DeleteTenantUseCase) *TenantController {
+	return &TenantController{
+		validator:           validators.NewInputValidator(),
+		createTenantUseCase: createTenant,
+		getTenantUseCase:    getTenant,
+		listTenantsUseCase:  listTenants,
+		updateTenantUseCase: updateTenant,
+		deleteTenantUseCase: deleteTenant,
+	}
+}
+
+func (h *TenantController) Create(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "TenantController.Create")
+	defer span.End()
+
+	var input dto.CreateTenantRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	tenantDTO, err := h.createTenantUseCase.Execute(ctx, &input)
+	if err != nil {
+		return tenantErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(tenantDTO)
+}
+
+func (h *TenantController) Get(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "TenantController.Get")
+	defer span.End()
+
+	tenantDTO, err := h.getTenantUseCase.Execute(ctx, c.Params("slug"))
+	if err != nil {
+		return tenantErrorResponse(c, err)
+	}
+	return c.JSON(tenantDTO)
+}
+
+func (h *TenantController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "TenantController.List")
+	defer span.End()
+
+	var input dto.PageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	tenants, err := h.listTenantsUseCase.Execute(ctx, &input)
+	if err != nil {
+		return tenantErrorResponse(c, err)
+	}
+	return c.JSON(tenants)
+}
+
+func (h *TenantController) Update(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "TenantController.Update")
+	defer span.End()
+
+	var input dto.UpdateTenantRequestDTO
+	if err := h.validator.ParseAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+	}
+
+	tenantDTO, err := h.updateTenantUseCase.Execute(ctx, c.Params("slug"), &input)
+	if err != nil {
+		return tenantErrorResponse(c, err)
+	}
+	return c.JSON(tenantDTO)
+}
+
+func (h *TenantController) Delete(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "TenantController.Delete")
+	defer span.End()
+
+	if err := h.deleteTenantUseCase.Execute(ctx, c.Params("slug")); err != nil {
+		return tenantErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// tenantErrorResponse traduz os erros dos casos de uso de tenants
+func tenantErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		return errorResponse(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return errorResponse(c, fiber.StatusNotFound, tenantNotFoundError)
+	case errors.Is(err, tenancy.ErrInvalidSlug):
+		return errorResponse(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, entities.ErrTenantExists), errors.Is(err, entities.ErrTenantNotEmpty), errors.Is(err, entities.ErrDefaultTenant):
+		return errorResponse(c, fiber.StatusConflict, err.Error())
+	default:
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,5 @@
+package controllers
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("user-management/internal/infrastructure/web/controllers")
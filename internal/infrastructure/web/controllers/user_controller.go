@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"errors"
+	"user-management/internal/application/auth"
 	"user-management/internal/application/dto"
 	"user-management/internal/application/usecases/user"
+	"user-management/internal/domain/entities"
 	"user-management/internal/infrastructure/web/validators"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
@@ -14,96 +18,252 @@ const (
 )
 
 type UserController struct {
-	validator         *validators.InputValidator
-	createUserUseCase *user.CreateUserUseCase
-	getUserUseCase    *user.GetUserUseCase
-	updateUserUseCase *user.UpdateUserUseCase
-	deleteUserUseCase *user.DeleteUserUseCase
-	listUsersUseCase  *user.ListUsersUseCase
+	validator              *validators.InputValidator
+	createUserUseCase      *user.CreateUserUseCase
+	getUserUseCase         *user.GetUserUseCase
+	updateUserUseCase      *user.UpdateUserUseCase
+	deleteUserUseCase      *user.DeleteUserUseCase
+	listUsersUseCase       *user.ListUsersUseCase
+	restoreUserUseCase     *user.RestoreUserUseCase
+	changeStatusUseCase    *user.ChangeUserStatusUseCase
+	effectiveGroupsUseCase *user.ListEffectiveGroupsUseCase
+	userGroupsUseCase      *user.ListUserGroupsUseCase
+	resendInviteUseCase    *user.ResendInvitationUseCase
 }
 
-func NewUserController(createUser *user.CreateUserUseCase, getUser *user.GetUserUseCase, updateUser *user.UpdateUserUseCase, deleteUser *user.DeleteUserUseCase, listUsers *user.ListUsersUseCase) *UserController {
+func NewUserController(createUser *user.CreateUserUseCase, getUser *user.GetUserUseCase, updateUser *user.UpdateUserUseCase, deleteUser *user.DeleteUserUseCase, listUsers *user.ListUsersUseCase, restoreUser *user.RestoreUserUseCase, changeStatus *user.ChangeUserStatusUseCase, effectiveGroups *user.ListEffectiveGroupsUseCase, userGroups *user.ListUserGroupsUseCase, resendInvite *user.ResendInvitationUseCase) *UserController {
 	return &UserController{
-		validator:         validators.NewInputValidator(),
-		createUserUseCase: createUser,
-		getUserUseCase:    getUser,
-		updateUserUseCase: updateUser,
-		deleteUserUseCase: deleteUser,
-		listUsersUseCase:  listUsers,
+		validator:              validators.NewInputValidator(),
+		createUserUseCase:      createUser,
+		getUserUseCase:         getUser,
+		updateUserUseCase:      updateUser,
+		deleteUserUseCase:      deleteUser,
+		listUsersUseCase:       listUsers,
+		restoreUserUseCase:     restoreUser,
+		changeStatusUseCase:    changeStatus,
+		effectiveGroupsUseCase: effectiveGroups,
+		userGroupsUseCase:      userGroups,
+		resendInviteUseCase:    resendInvite,
 	}
 }
 
 func (h *UserController) Create(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Create")
+	defer span.End()
+
 	var createUserDTO dto.CreateUserRequestDTO
 
 	// Parse e valida em uma operação
 	if err := h.validator.ParseAndValidate(c, &createUserDTO); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
 	}
 
-	responseDTO, err := h.createUserUseCase.Execute(c.Context(), &createUserDTO)
+	responseDTO, err := h.createUserUseCase.Execute(ctx, &createUserDTO)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if errors.Is(err, entities.ErrEmailTaken) {
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.Status(fiber.StatusCreated).JSON(responseDTO)
 }
 
 func (h *UserController) Get(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Get")
+	defer span.End()
+
 	id := c.Params("id")
-	userDTO, err := h.getUserUseCase.Execute(c.Context(), id)
+	userDTO, err := h.getUserUseCase.Execute(ctx, id, c.QueryBool("include_deleted"))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": userNotFoundError})
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
 	}
 	return c.JSON(userDTO)
 }
 
 func (h *UserController) Update(c *fiber.Ctx) error {
-	var updateUserDTO dto.CreateUserRequestDTO
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Update")
+	defer span.End()
+
+	var updateUserDTO dto.UpdateUserRequestDTO
 
 	if err := h.validator.ParseAndValidate(c, &updateUserDTO); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
 	}
 
 	userID := c.Params("id")
-	responseDTO, err := h.updateUserUseCase.Execute(c.Context(), userID, &updateUserDTO)
+	responseDTO, err := h.updateUserUseCase.Execute(ctx, userID, &updateUserDTO)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": userNotFoundError})
+			return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+		}
+		if errors.Is(err, entities.ErrEmailTaken) {
+			return errorResponse(c, fiber.StatusConflict, err.Error())
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(responseDTO)
 }
 
 func (h *UserController) Delete(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Delete")
+	defer span.End()
+
 	id := c.Params("id")
-	if err := h.deleteUserUseCase.Execute(c.Context(), id); err != nil {
+	if err := h.deleteUserUseCase.Execute(ctx, id); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": userNotFoundError})
+			return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
 func (h *UserController) List(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.List")
+	defer span.End()
+
 	var input dto.ListUserQueryParam
 	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
 		if validationErr, ok := err.(*validators.ValidationError); ok {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": validationErr.Message})
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid query parameters"})
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
 	}
 
-	users, err := h.listUsersUseCase.Execute(c.Context(), &input)
+	users, err := h.listUsersUseCase.Execute(ctx, &input)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
 	}
 	return c.JSON(users)
 }
+
+func (h *UserController) Restore(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Restore")
+	defer span.End()
+
+	id := c.Params("id")
+	responseDTO, err := h.restoreUserUseCase.Execute(ctx, id)
+	if err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		}
+		if err == mongo.ErrNoDocuments {
+			return errorResponse(c, fiber.StatusNotFound, "Deleted user not found")
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(responseDTO)
+}
+
+func (h *UserController) Groups(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.Groups")
+	defer span.End()
+
+	var input dto.SearchPageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	groups, err := h.userGroupsUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(groups)
+}
+
+func (h *UserController) EffectiveGroups(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.EffectiveGroups")
+	defer span.End()
+
+	var input dto.PageQueryParam
+	if err := h.validator.ParseQueryAndValidate(c, &input); err != nil {
+		if validationErr, ok := err.(*validators.ValidationError); ok {
+			return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+		}
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	groups, err := h.effectiveGroupsUseCase.Execute(ctx, c.Params("id"), &input)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+			return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(groups)
+}
+
+// ResendInvitation envia um novo convite a um usuário pending
+func (h *UserController) ResendInvitation(c *fiber.Ctx) error {
+	ctx, span := tracer.Start(c.UserContext(), "UserController.ResendInvitation")
+	defer span.End()
+
+	responseDTO, err := h.resendInviteUseCase.Execute(ctx, c.Params("id"))
+	if err != nil {
+		return invitationErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(responseDTO)
+}
+
+func (h *UserController) Activate(c *fiber.Ctx) error {
+	return h.changeStatus(c, "UserController.Activate", entities.UserStatusActive)
+}
+
+func (h *UserController) Suspend(c *fiber.Ctx) error {
+	return h.changeStatus(c, "UserController.Suspend", entities.UserStatusSuspended)
+}
+
+func (h *UserController) Deprovision(c *fiber.Ctx) error {
+	return h.changeStatus(c, "UserController.Deprovision", entities.UserStatusDeprovisioned)
+}
+
+// changeStatus trata os endpoints de transição de status; o body com o motivo é opcional
+// para /activate e obrigatório para /suspend e /deprovision
+func (h *UserController) changeStatus(c *fiber.Ctx, spanName string, status entities.UserStatus) error {
+	ctx, span := tracer.Start(c.UserContext(), spanName)
+	defer span.End()
+
+	var input dto.ChangeUserStatusRequestDTO
+	if len(c.Body()) > 0 {
+		if err := h.validator.ParseAndValidate(c, &input); err != nil {
+			if validationErr, ok := err.(*validators.ValidationError); ok {
+				return errorResponse(c, fiber.StatusBadRequest, validationErr.Message)
+			}
+			return errorResponse(c, fiber.StatusBadRequest, "Invalid request")
+		}
+	}
+
+	responseDTO, err := h.changeStatusUseCase.Execute(ctx, c.Params("id"), status, &input)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrForbidden):
+			return errorResponse(c, fiber.StatusForbidden, err.Error())
+		case errors.Is(err, user.ErrStatusReasonRequired):
+			return errorResponse(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, entities.ErrInvalidStatusTransition):
+			return errorResponse(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, mongo.ErrNoDocuments), errors.Is(err, bson.ErrInvalidHex):
+			return errorResponse(c, fiber.StatusNotFound, userNotFoundError)
+		}
+		return errorResponse(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(responseDTO)
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"time"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AccessLog registra cada requisição através do Logrus, usando a entrada de log da
+// requisição para manter os mesmos campos (request_id, trace_id) dos demais logs
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		if err != nil {
+			// Executa o ErrorHandler para que o status registrado seja o enviado ao cliente
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		entry := logger.FromContext(c.UserContext()).WithFields(logrus.Fields{
+			"status":  status,
+			"latency": time.Since(start).String(),
+			"ip":      c.IP(),
+		})
+
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			entry.Error("Request completed")
+		case status >= fiber.StatusBadRequest:
+			entry.Warn("Request completed")
+		default:
+			entry.Info("Request completed")
+		}
+		return nil
+	}
+}
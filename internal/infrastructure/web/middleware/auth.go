@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/usecases/apikey"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/token"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Authenticator identifica o principal de cada requisição e o coloca no c.UserContext()
+type Authenticator struct {
+	enabled  bool
+	tokens   *token.Service
+	apiKeys  *apikey.AuthenticateAPIKeyUseCase
+	sessions *session.ValidateUseCase
+}
+
+func NewAuthenticator(cfg *config.Config, tokens *token.Service, apiKeys *apikey.AuthenticateAPIKeyUseCase, sessions *session.ValidateUseCase) *Authenticator {
+	return &Authenticator{enabled: cfg.AuthEnabled, tokens: tokens, apiKeys: apiKeys, sessions: sessions}
+}
+
+// Handler exige uma credencial válida quando AUTH_ENABLED=true: um access token ou uma API key em
+// Authorization: Bearer (as API keys são reconhecidas pelo prefixo) ou uma API key em X-API-Key.
+// Access tokens de usuários cujas sessões foram revogadas depois da emissão são recusados.
+// Com a autenticação desabilitada todas as chamadas são tratadas como um administrador anônimo
+// da plataforma, mantendo o comportamento de ambientes sem autenticação
+func (a *Authenticator) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := auth.Principal{ID: auth.AnonymousID, Type: auth.PrincipalSystem, Admin: true, PlatformAdmin: true}
+
+		if a.enabled {
+			raw, isAPIKey := strings.TrimSpace(c.Get(APIKeyHeader)), true
+			if raw == "" {
+				bearer, ok := bearerToken(c.Get(fiber.HeaderAuthorization))
+				if !ok {
+					return unauthorized(c, auth.ErrUnauthorized.Error())
+				}
+				raw, isAPIKey = bearer, apikey.IsSecret(bearer)
+			}
+
+			var err error
+			if isAPIKey {
+				principal, err = a.apiKeys.Execute(c.UserContext(), raw)
+			} else {
+				principal, err = a.tokens.Verify(raw)
+				if err == nil {
+					err = a.sessions.Execute(c.UserContext(), principal)
+				}
+			}
+			if err != nil {
+				if errors.Is(err, apikey.ErrInvalidAPIKey) || errors.Is(err, entities.ErrAPIKeyRevoked) ||
+					errors.Is(err, entities.ErrAPIKeyExpired) || errors.Is(err, token.ErrInvalidToken) ||
+					errors.Is(err, session.ErrSessionRevoked) {
+					return unauthorized(c, err.Error())
+				}
+				logger.FromContext(c.UserContext()).WithError(err).Error("Failed to authenticate request")
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":      "Failed to authenticate request",
+					"request_id": GetRequestID(c),
+				})
+			}
+		}
+
+		ctx := auth.WithPrincipal(c.UserContext(), principal)
+		ctx = logger.WithEntry(ctx, logger.FromContext(ctx).WithField("actor", principal.ID))
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// APIKeyHeader é a alternativa ao Authorization: Bearer para enviar uma API key
+const APIKeyHeader = "X-API-Key"
+
+func bearerToken(header string) (string, bool) {
+	scheme, value, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(value) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+func unauthorized(c *fiber.Ctx, message string) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"error":      message,
+		"request_id": GetRequestID(c),
+	})
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	// HeaderIdempotencyKey identifica as retentativas de um mesmo POST
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marca as respostas repetidas de uma requisição anterior
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTimeout é quanto tempo uma chave em andamento bloqueia as retentativas. Só
+	// vence se o processo morrer no meio da requisição; as que terminam liberam ou completam a chave
+	idempotencyLockTimeout = time.Minute
+)
+
+// Idempotency torna seguras as retentativas dos POSTs com o cabeçalho Idempotency-Key: a primeira
+// requisição com a chave é executada e a resposta guardada por IDEMPOTENCY_TTL; as seguintes
+// recebem a mesma resposta sem executar de novo. Nas rotas da API deve ser registrado depois do
+// Authenticator, pois as chaves são separadas por cliente
+type Idempotency struct {
+	ttl   time.Duration
+	keys  repositories.IIdempotencyKeyRepository
+	clock services.IClock
+}
+
+func NewIdempotency(cfg *config.Config, keys repositories.IIdempotencyKeyRepository, clock services.IClock) *Idempotency {
+	return &Idempotency{ttl: cfg.IdempotencyTTL, keys: keys, clock: clock}
+}
+
+// Handler responde 422 se a chave já foi usada com outra requisição e 409 enquanto a primeira
+// ainda está em andamento. Só as respostas de sucesso são guardadas: erros e respostas com
+// Cache-Control: no-store (que trazem segredos, como tokens e API keys) liberam a chave para
+// uma nova tentativa
+func (i *Idempotency) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		value := c.Get(HeaderIdempotencyKey)
+		if c.Method() != fiber.MethodPost || value == "" {
+			return c.Next()
+		}
+		if len(value) > maxIdempotencyKeyLength {
+			return idempotencyError(c, fiber.StatusBadRequest, "Idempotency-Key must have at most 255 characters")
+		}
+
+		ctx := c.UserContext()
+		now := i.clock.Now()
+		record := &entities.IdempotencyKey{
+			Key:         clientKey(c) + "|" + value,
+			Fingerprint: fingerprint(c),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(i.ttl),
+		}
+
+		err := i.keys.Create(ctx, record)
+		if errors.Is(err, entities.ErrIdempotencyKeyInUse) {
+			var existing *entities.IdempotencyKey
+			existing, err = i.keys.GetByKey(ctx, record.Key)
+			switch {
+			case errors.Is(err, mongo.ErrNoDocuments):
+				// a chave foi liberada entre as duas consultas
+				err = i.keys.Create(ctx, record)
+			case err != nil:
+				// tratado abaixo
+			case !existing.ExpiresAt.After(now) || (!existing.Completed() && !existing.CreatedAt.Add(idempotencyLockTimeout).After(now)):
+				// chave vencida, ainda não apagada pelo índice TTL, ou abandonada no meio da requisição
+				if err = i.keys.Delete(ctx, existing.ID.Hex()); err == nil {
+					err = i.keys.Create(ctx, record)
+				}
+			case existing.Fingerprint != record.Fingerprint:
+				return idempotencyError(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+			case !existing.Completed():
+				return idempotencyError(c, fiber.StatusConflict, "A request with this Idempotency-Key is still in progress")
+			default:
+				c.Set(HeaderIdempotentReplayed, "true")
+				if existing.ContentType != "" {
+					c.Set(fiber.HeaderContentType, existing.ContentType)
+				}
+				return c.Status(existing.StatusCode).Send(existing.Body)
+			}
+		}
+		if errors.Is(err, entities.ErrIdempotencyKeyInUse) {
+			return idempotencyError(c, fiber.StatusConflict, "A request with this Idempotency-Key is still in progress")
+		}
+		if err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Failed to check idempotency key")
+			return idempotencyError(c, fiber.StatusInternalServerError, "Failed to check idempotency key")
+		}
+
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusBadRequest || strings.Contains(string(c.Response().Header.Peek(fiber.HeaderCacheControl)), "no-store") {
+			if releaseErr := i.keys.Delete(ctx, record.ID.Hex()); releaseErr != nil {
+				logger.FromContext(ctx).WithError(releaseErr).Error("Failed to release idempotency key")
+			}
+			return err
+		}
+		body := append([]byte(nil), c.Response().Body()...)
+		if err := i.keys.Complete(ctx, record.ID.Hex(), status, string(c.Response().Header.ContentType()), body, i.clock.Now()); err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Failed to store idempotent response")
+		}
+		return nil
+	}
+}
+
+// fingerprint identifica a requisição pelo método, caminho com query, tenant pedido e body; uma
+// retentativa precisa repetir todos
+func fingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(c.Method()), []byte(c.OriginalURL()), []byte(c.Get(tenancy.Header)), []byte(c.Hostname()), c.Body()} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func idempotencyError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"error":      message,
+		"request_id": GetRequestID(c),
+	})
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"errors"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MFAEnforcer recusa os tokens de usuários que precisam do segundo fator e ainda não o
+// verificaram. Deve ser registrado depois do Authenticator
+type MFAEnforcer struct {
+	check *mfa.CheckUseCase
+}
+
+func NewMFAEnforcer(check *mfa.CheckUseCase) *MFAEnforcer {
+	return &MFAEnforcer{check: check}
+}
+
+// Handler responde 403 enquanto o usuário não trocar o token por um verificado em
+// POST /api/v1/auth/mfa/verify (ou não cadastrar o TOTP, se um grupo o exigir)
+func (m *MFAEnforcer) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := m.check.Execute(c.UserContext()); err != nil {
+			status := fiber.StatusForbidden
+			if !errors.Is(err, mfa.ErrMFARequired) {
+				logger.FromContext(c.UserContext()).WithError(err).Error("Failed to check MFA requirement")
+				status = fiber.StatusInternalServerError
+			}
+			return c.Status(status).JSON(fiber.Map{
+				"error":      err.Error(),
+				"request_id": GetRequestID(c),
+			})
+		}
+		return c.Next()
+	}
+}
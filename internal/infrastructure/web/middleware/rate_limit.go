@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+	"user-management/internal/application/auth"
+	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/services"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Headers do rate limiting (draft IETF RateLimit header fields)
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimiter limita as requisições de cada cliente com um token bucket: a API key, o usuário do
+// token ou, sem principal autenticado, o IP. As rotas de RATE_LIMIT_ROUTES têm cada uma o próprio
+// balde, no lugar do padrão; a primeira regra que casa com a requisição vale. Nas rotas da API
+// deve ser registrado depois do Authenticator
+type RateLimiter struct {
+	enabled bool
+	limit   services.RateLimit
+	rules   []config.RateLimitRule
+	store   services.IRateLimitStore
+	clock   services.IClock
+}
+
+func NewRateLimiter(cfg *config.Config, store services.IRateLimitStore, clock services.IClock) *RateLimiter {
+	return &RateLimiter{
+		enabled: cfg.RateLimitEnabled,
+		limit:   services.RateLimit{Requests: cfg.RateLimitRequests, Period: cfg.RateLimitPeriod},
+		rules:   cfg.RateLimitRoutes,
+		store:   store,
+		clock:   clock,
+	}
+}
+
+// Handler responde 429 com Retry-After quando o balde do cliente está vazio. Se o store falhar,
+// a requisição passa: o rate limiting não deve derrubar a API
+func (r *RateLimiter) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !r.enabled {
+			return c.Next()
+		}
+
+		scope, limit := "default", r.limit
+		for _, rule := range r.rules {
+			if rule.Matches(c.Method(), c.Path()) {
+				scope, limit = rule.Method+" "+rule.Path, services.RateLimit{Requests: rule.Requests, Period: rule.Period}
+				break
+			}
+		}
+
+		result, err := r.store.Take(c.UserContext(), scope+"|"+clientKey(c), limit, r.clock.Now())
+		if err != nil {
+			logger.FromContext(c.UserContext()).WithError(err).Error("Failed to check rate limit")
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitLimit, strconv.FormatUint(limit.Requests, 10))
+		c.Set(HeaderRateLimitRemaining, strconv.FormatUint(result.Remaining, 10))
+		c.Set(HeaderRateLimitReset, strconv.FormatInt(ceilSeconds(result.ResetAfter), 10))
+		c.Set(HeaderRateLimitPolicy, strconv.FormatUint(limit.Requests, 10)+";w="+strconv.FormatInt(ceilSeconds(limit.Period), 10))
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(max(ceilSeconds(result.RetryAfter), 1), 10))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":      "Too many requests",
+				"request_id": GetRequestID(c),
+			})
+		}
+		return c.Next()
+	}
+}
+
+// clientKey identifica o cliente da requisição. Sem autenticação (AUTH_ENABLED=false ou rotas
+// públicas) todos compartilhariam o principal anônimo, então o IP é usado
+func clientKey(c *fiber.Ctx) string {
+	principal, ok := auth.FromContext(c.UserContext())
+	switch {
+	case !ok || principal.ID == auth.AnonymousID:
+		return "ip:" + c.IP()
+	case principal.Type == auth.PrincipalAPIKey:
+		return "api_key:" + principal.ID
+	default:
+		return principal.Type + ":" + principal.TenantID + ":" + principal.ID
+	}
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
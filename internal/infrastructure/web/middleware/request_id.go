@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HeaderRequestID é o header usado para receber e devolver o ID da requisição
+	HeaderRequestID = fiber.HeaderXRequestID
+
+	requestIDLocalsKey = "request_id"
+	maxRequestIDLength = 128
+)
+
+// RequestID aceita o X-Request-ID enviado pelo cliente (ou gera um novo), devolve-o na
+// resposta e coloca no c.UserContext() uma entrada de log com o ID da requisição
+func RequestID(log *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Locals(requestIDLocalsKey, requestID)
+		c.Set(HeaderRequestID, requestID)
+
+		entry := log.WithFields(logrus.Fields{
+			"request_id": requestID,
+			"method":     c.Method(),
+			"path":       c.Path(),
+		})
+		c.SetUserContext(logger.WithEntry(c.UserContext(), entry))
+
+		return c.Next()
+	}
+}
+
+// GetRequestID retorna o ID da requisição atribuído pelo middleware RequestID
+func GetRequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(requestIDLocalsKey).(string)
+	return requestID
+}
+
+// isValidRequestID evita propagar IDs vazios, muito longos ou com caracteres de controle
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"user-management/internal/application/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireScope restringe as rotas de um recurso aos principais com o escopo correspondente ao
+// método: read para GET e HEAD, write para os demais. Só as API keys têm escopos; os tokens de
+// usuário passam sempre. Deve ser registrado depois do Authenticator
+func RequireScope(read, write string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scope := write
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			scope = read
+		}
+		if err := auth.RequireScope(c.UserContext(), scope); err != nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":      err.Error(),
+				"request_id": GetRequestID(c),
+			})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"user-management/internal/application/auth"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// TenantResolver define o tenant de cada requisição e o coloca no c.UserContext(). Deve ser
+// registrado depois do Authenticator, pois depende do principal
+type TenantResolver struct {
+	baseDomain string
+	tenants    repositories.ITenantRepository
+}
+
+func NewTenantResolver(cfg *config.Config, tenants repositories.ITenantRepository) *TenantResolver {
+	return &TenantResolver{baseDomain: strings.ToLower(strings.TrimPrefix(cfg.TenantBaseDomain, ".")), tenants: tenants}
+}
+
+// Handler resolve o tenant pedido pelo cabeçalho X-Tenant-ID ou, na falta dele, pelo subdomínio
+// de TENANT_BASE_DOMAIN. Um usuário fica preso ao tenant da claim tenant do seu token (o tenant
+// padrão quando ausente) e pedir outro resulta em 403; apenas administradores da plataforma
+// escolhem livremente o tenant, que também é o padrão quando nenhum é pedido. Em rotas públicas,
+// sem Authenticator e portanto sem principal, vale o tenant pedido.
+// Tenants diferentes do padrão precisam estar cadastrados
+func (r *TenantResolver) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		requested := strings.TrimSpace(c.Get(tenancy.Header))
+		if requested == "" {
+			requested = r.subdomain(c.Hostname())
+		}
+
+		principal, authenticated := auth.FromContext(ctx)
+		tenantID := requested
+		if authenticated && !principal.PlatformAdmin {
+			tenantID = principal.TenantID
+			if tenantID == "" {
+				tenantID = tenancy.DefaultTenant
+			}
+			if requested != "" && requested != tenantID {
+				return tenantError(c, fiber.StatusForbidden, "Access to this tenant is not allowed")
+			}
+		}
+		if tenantID == "" {
+			tenantID = tenancy.DefaultTenant
+		}
+
+		if tenantID != tenancy.DefaultTenant {
+			if !tenancy.ValidSlug(tenantID) {
+				return tenantError(c, fiber.StatusNotFound, "Tenant not found")
+			}
+			if _, err := r.tenants.GetBySlug(ctx, tenantID); err != nil {
+				if errors.Is(err, mongo.ErrNoDocuments) {
+					return tenantError(c, fiber.StatusNotFound, "Tenant not found")
+				}
+				logger.FromContext(ctx).WithError(err).Error("Failed to resolve tenant")
+				return tenantError(c, fiber.StatusInternalServerError, "Failed to resolve tenant")
+			}
+		}
+
+		ctx = tenancy.WithTenant(ctx, tenantID)
+		ctx = logger.WithEntry(ctx, logger.FromContext(ctx).WithField("tenant", tenantID))
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// subdomain retorna o rótulo imediatamente antes de TENANT_BASE_DOMAIN no host
+// (acme.example.com -> acme), ou vazio se o host não for um subdomínio direto
+func (r *TenantResolver) subdomain(host string) string {
+	if r.baseDomain == "" {
+		return ""
+	}
+	label, found := strings.CutSuffix(strings.ToLower(host), "."+r.baseDomain)
+	if !found || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
+
+func tenantError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"error":      message,
+		"request_id": GetRequestID(c),
+	})
+}
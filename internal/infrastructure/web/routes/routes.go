@@ -1,44 +1,124 @@
 package routes
 
 import (
-	"log"
+	"user-management/internal/application/auth"
 	"user-management/internal/infrastructure/web/controllers"
+	"user-management/internal/infrastructure/web/middleware"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/sirupsen/logrus"
 )
 
-func SetupRoutes(app *fiber.App, UserController *controllers.UserController, GroupController *controllers.GroupController) {
-	// Health check endpoint
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusOK)
-	})
+func SetupRoutes(app *fiber.App, log *logrus.Logger, authenticator *middleware.Authenticator, tenantResolver *middleware.TenantResolver, mfaEnforcer *middleware.MFAEnforcer, rateLimiter *middleware.RateLimiter, idempotency *middleware.Idempotency, HealthController *controllers.HealthController, UserController *controllers.UserController, GroupController *controllers.GroupController, TenantController *controllers.TenantController, APIKeyController *controllers.APIKeyController, OAuthClientController *controllers.OAuthClientController, OIDCController *controllers.OIDCController, MFAController *controllers.MFAController, InvitationController *controllers.InvitationController, PasswordResetController *controllers.PasswordResetController, SessionController *controllers.SessionController) {
+	// Health check endpoints (registrados antes dos middlewares para não poluir o access log)
+	app.Get("/health", HealthController.Live)
+	app.Get("/health/live", HealthController.Live)
+	app.Get("/health/ready", HealthController.Ready)
 
-	app.Use(logger.New(logger.Config{
-		Format:     `{"timestamp":"${time}","status":${status},"method":"${method}","path":"${path}","latency":"${latency}"}` + "\n",
-		TimeFormat: "2006-01-02T15:04:05.999Z", // Formato ISO 8601
-		TimeZone:   "UTC",
-		Output:     log.Writer(), // Enviar logs para o Logrus
-	}))
+	app.Use(middleware.RequestID(log))
+	app.Use(middleware.AccessLog())
+	app.Use(middleware.Tracing())
 
-	api := app.Group("/api")
+	// Provedor OpenID Connect: discovery, JWKS, token e userinfo são públicos (o token autentica o
+	// cliente e o userinfo o access token emitido pelo provedor); a autorização exige o usuário
+	// autenticado na API (com o segundo fator, se exigido dele) e emite o código no tenant dele.
+	// Nas rotas públicas o rate limiting e as chaves de idempotência usam o IP do cliente
+	app.Get("/.well-known/openid-configuration", OIDCController.Discovery)
+	app.Get(controllers.OIDCJWKSPath, OIDCController.JWKS)
+	app.Get(controllers.OIDCAuthorizePath, authenticator.Handler(), rateLimiter.Handler(), tenantResolver.Handler(), mfaEnforcer.Handler(), OIDCController.Authorize)
+	app.Post(controllers.OIDCTokenPath, rateLimiter.Handler(), idempotency.Handler(), OIDCController.Token)
+	app.Get(controllers.OIDCUserInfoPath, rateLimiter.Handler(), OIDCController.UserInfo)
+	app.Post(controllers.OIDCUserInfoPath, rateLimiter.Handler(), idempotency.Handler(), OIDCController.UserInfo)
+
+	// Aceitação de convite: pública (o token do convite é a credencial) e registrada antes do grupo
+	// /api para não passar pelo authenticator; o tenant é o do convite
+	app.Post("/api/v1/invitations/:token/accept", rateLimiter.Handler(), idempotency.Handler(), InvitationController.Accept)
+
+	// Redefinição de senha: também pública. O pedido procura o email no tenant pedido (cabeçalho
+	// ou subdomínio); a confirmação usa o tenant do próprio token
+	app.Post("/api/v1/auth/password-reset", rateLimiter.Handler(), idempotency.Handler(), tenantResolver.Handler(), PasswordResetController.Request)
+	app.Post("/api/v1/auth/password-reset/confirm", rateLimiter.Handler(), idempotency.Handler(), PasswordResetController.Confirm)
+
+	// Login e renovação da sessão: públicos. O login procura o usuário no tenant pedido; a
+	// renovação usa o tenant da sessão do refresh token
+	app.Post("/api/v1/auth/login", rateLimiter.Handler(), idempotency.Handler(), tenantResolver.Handler(), SessionController.Login)
+	app.Post("/api/v1/auth/refresh", rateLimiter.Handler(), idempotency.Handler(), SessionController.Refresh)
+
+	// O rate limiting e as chaves de idempotência da API vêm depois do authenticator para separar
+	// os clientes por API key ou usuário
+	api := app.Group("/api", authenticator.Handler(), rateLimiter.Handler(), idempotency.Handler())
 	v1 := api.Group("/v1")
 
-	// User routes
-	users := v1.Group("/users")
+	// MFA do próprio usuário: as únicas rotas sem o MFAEnforcer, para que o usuário cadastre o
+	// TOTP e troque o token por um verificado com o segundo fator
+	mfa := v1.Group("/auth/mfa")
+	mfa.Get("/", MFAController.Status)
+	mfa.Post("/enroll", MFAController.Enroll)
+	mfa.Post("/confirm", MFAController.Confirm)
+	mfa.Post("/verify", MFAController.Verify)
+	mfa.Post("/recovery-codes", MFAController.RegenerateRecoveryCodes)
+	mfa.Post("/disable", MFAController.Disable)
+
+	// User routes (usuários e grupos sempre operam no tenant resolvido para a requisição; as API
+	// keys precisam do escopo de leitura ou escrita do recurso; usuários que precisam do segundo
+	// fator só passam com um token verificado)
+	users := v1.Group("/users", tenantResolver.Handler(), mfaEnforcer.Handler(), middleware.RequireScope(auth.ScopeUsersRead, auth.ScopeUsersWrite))
 	users.Post("/", UserController.Create)
 	users.Get("/:id", UserController.Get)
 	users.Put("/:id", UserController.Update)
 	users.Delete("/:id", UserController.Delete)
 	users.Get("/", UserController.List)
+	users.Post("/:id/restore", UserController.Restore)
+	users.Post("/:id/activate", UserController.Activate)
+	users.Post("/:id/suspend", UserController.Suspend)
+	users.Post("/:id/deprovision", UserController.Deprovision)
+	users.Post("/:id/invitation", UserController.ResendInvitation)
+	users.Get("/:id/groups", UserController.Groups)
+	users.Get("/:id/effective-groups", UserController.EffectiveGroups)
+	users.Delete("/:id/mfa", MFAController.Reset)
+	users.Get("/:id/sessions", SessionController.List)
+	users.Delete("/:id/sessions", SessionController.RevokeAll)
+	users.Delete("/:id/sessions/:sessionId", SessionController.Revoke)
 
 	// Group routes
-	groups := v1.Group("/groups")
+	groups := v1.Group("/groups", tenantResolver.Handler(), mfaEnforcer.Handler(), middleware.RequireScope(auth.ScopeGroupsRead, auth.ScopeGroupsWrite))
 	groups.Post("/", GroupController.Create)
 	groups.Get("/:id", GroupController.Get)
 	groups.Put("/:id", GroupController.Update)
 	groups.Delete("/:id", GroupController.Delete)
 	groups.Get("/", GroupController.List)
+	groups.Post("/:id/restore", GroupController.Restore)
+	groups.Put("/:id/mfa-policy", GroupController.SetMFAPolicy)
 	groups.Post("/:groupId/members/:userId", GroupController.AddUser)
+	groups.Put("/:groupId/members/:userId", GroupController.UpdateMember)
 	groups.Delete("/:groupId/members/:userId", GroupController.RemoveUser)
+	groups.Post("/:groupId/subgroups/:subgroupId", GroupController.AddSubgroup)
+	groups.Delete("/:groupId/subgroups/:subgroupId", GroupController.RemoveSubgroup)
+	groups.Get("/:id/members", GroupController.Members)
+	groups.Post("/:id/members", GroupController.AddMembers)
+	groups.Put("/:id/members", GroupController.ReplaceMembers)
+	groups.Delete("/:id/members", GroupController.RemoveMembers)
+	groups.Get("/:id/effective-members", GroupController.EffectiveMembers)
+
+	// API key routes (administradores do tenant; as chaves pertencem ao tenant da requisição)
+	apiKeys := v1.Group("/api-keys", tenantResolver.Handler(), mfaEnforcer.Handler())
+	apiKeys.Post("/", APIKeyController.Create)
+	apiKeys.Get("/", APIKeyController.List)
+	apiKeys.Get("/:id", APIKeyController.Get)
+	apiKeys.Delete("/:id", APIKeyController.Revoke)
+
+	// OAuth client routes (clientes do provedor OpenID Connect, administrados como as API keys)
+	oauthClients := v1.Group("/oauth-clients", tenantResolver.Handler(), mfaEnforcer.Handler())
+	oauthClients.Post("/", OAuthClientController.Create)
+	oauthClients.Get("/", OAuthClientController.List)
+	oauthClients.Get("/:clientId", OAuthClientController.Get)
+	oauthClients.Delete("/:clientId", OAuthClientController.Delete)
+
+	// Tenant routes (apenas administradores da plataforma)
+	tenants := v1.Group("/tenants", mfaEnforcer.Handler())
+	tenants.Post("/", TenantController.Create)
+	tenants.Get("/", TenantController.List)
+	tenants.Get("/:slug", TenantController.Get)
+	tenants.Put("/:slug", TenantController.Update)
+	tenants.Delete("/:slug", TenantController.Delete)
 }
@@ -4,49 +4,101 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 	"user-management/internal/config"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/health"
+	"user-management/internal/infrastructure/jobs"
+	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/tracing"
 	"user-management/internal/infrastructure/web/controllers"
+	"user-management/internal/infrastructure/web/middleware"
 	"user-management/internal/infrastructure/web/routes"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/sirupsen/logrus"
 )
 
 type Server struct {
-	app     *fiber.App
-	cfg     *config.Config
-	log     *logrus.Logger
-	mongoDB *database.MongoDB
+	app      *fiber.App
+	cfg      *config.Config
+	log      *logrus.Logger
+	mongoDB  *database.MongoDB
+	tracing  *tracing.Provider
+	health   *health.Service
+	migrator *migrations.Migrator
+	purgeJob *jobs.PurgeJob
+	resets   *jobs.PasswordResetQueue
 }
 
 func NewServer(cfg *config.Config,
 	UserController *controllers.UserController,
 	GroupController *controllers.GroupController,
+	TenantController *controllers.TenantController,
+	APIKeyController *controllers.APIKeyController,
+	OAuthClientController *controllers.OAuthClientController,
+	OIDCController *controllers.OIDCController,
+	MFAController *controllers.MFAController,
+	InvitationController *controllers.InvitationController,
+	PasswordResetController *controllers.PasswordResetController,
+	SessionController *controllers.SessionController,
+	HealthController *controllers.HealthController,
 	log *logrus.Logger,
-	mongoDB *database.MongoDB) *Server {
+	mongoDB *database.MongoDB,
+	tracingProvider *tracing.Provider,
+	healthService *health.Service,
+	migrator *migrations.Migrator,
+	authenticator *middleware.Authenticator,
+	tenantResolver *middleware.TenantResolver,
+	mfaEnforcer *middleware.MFAEnforcer,
+	rateLimiter *middleware.RateLimiter,
+	idempotency *middleware.Idempotency,
+	purgeJob *jobs.PurgeJob,
+	passwordResets *jobs.PasswordResetQueue) *Server {
 
-	app := fiber.New()
-	routes.SetupRoutes(app, UserController, GroupController)
-	return &Server{app: app, cfg: cfg, log: log, mongoDB: mongoDB}
+	app := fiber.New(fiber.Config{
+		ErrorHandler: errorHandler,
+		ReadTimeout:  cfg.ServerReadTimeout,
+		WriteTimeout: cfg.ServerWriteTimeout,
+		IdleTimeout:  cfg.ServerIdleTimeout,
+	})
+	app.Use(cors.New(cors.Config{
+		AllowOrigins:     strings.Join(cfg.CORSAllowOrigins, ","),
+		AllowMethods:     strings.Join(cfg.CORSAllowMethods, ","),
+		AllowHeaders:     strings.Join(cfg.CORSAllowHeaders, ","),
+		AllowCredentials: cfg.CORSAllowCredentials,
+		ExposeHeaders: strings.Join([]string{middleware.HeaderRequestID, middleware.HeaderRateLimitLimit, middleware.HeaderRateLimitRemaining,
+			middleware.HeaderRateLimitReset, middleware.HeaderRateLimitPolicy, fiber.HeaderRetryAfter, middleware.HeaderIdempotentReplayed}, ","),
+	}))
+	routes.SetupRoutes(app, log, authenticator, tenantResolver, mfaEnforcer, rateLimiter, idempotency, HealthController, UserController, GroupController, TenantController, APIKeyController, OAuthClientController, OIDCController, MFAController, InvitationController, PasswordResetController, SessionController)
+	return &Server{app: app, cfg: cfg, log: log, mongoDB: mongoDB, tracing: tracingProvider, health: healthService, migrator: migrator, purgeJob: purgeJob, resets: passwordResets}
+}
+
+// errorHandler responde erros não tratados pelos handlers no mesmo formato dos controllers
+func errorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+	}
+	return c.Status(code).JSON(fiber.Map{
+		"error":      err.Error(),
+		"request_id": middleware.GetRequestID(c),
+	})
 }
 
 func (s *Server) Start() error {
-	// Initialize database connection
-	// ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	// defer cancel()
-
-	// if err := s.dbManager.Initialize(ctx); err != nil {
-	// 	s.log.WithFields(logrus.Fields{
-	// 		"ddsource": s.cfg.DDSource,
-	// 		"service":  s.cfg.DDService,
-	// 		"ddtags":   s.cfg.DDTags,
-	// 		"error":    err.Error(),
-	// 	}).Error("Failed to initialize database")
-	// 	return err
-	// }
+	// Verificar/aplicar migrations em background; a readiness só passa com o schema atualizado
+	go s.prepareSchema()
+
+	// Expurgo periódico dos registros removidos (soft delete); interrompido no desligamento
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go s.purgeJob.Start(jobsCtx)
+	s.resets.Start()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -54,61 +106,96 @@ func (s *Server) Start() error {
 	// Iniciar o servidor em uma goroutine
 	go func() {
 		s.log.WithFields(logrus.Fields{
-			"ddsource": s.cfg.DDSource,
-			"service":  s.cfg.DDService,
-			"ddtags":   s.cfg.DDTags,
-			"port":     s.cfg.Port,
+			"port": s.cfg.Port,
 		}).Info("Starting server")
 		if err := s.app.Listen(s.cfg.Port); err != nil {
 			s.log.WithFields(logrus.Fields{
-				"ddsource": s.cfg.DDSource,
-				"service":  s.cfg.DDService,
-				"ddtags":   s.cfg.DDTags,
-				"error":    err.Error(),
+				"error": err.Error(),
 			}).Error("Failed to start server")
 		}
 	}()
 
 	// Aguardar sinal de shutdown
 	<-stop
+	// Readiness passa a falhar imediatamente para que o balanceador pare de enviar tráfego
+	s.health.MarkShuttingDown()
 	s.log.WithFields(logrus.Fields{
-		"ddsource": s.cfg.DDSource,
-		"service":  s.cfg.DDService,
-		"ddtags":   s.cfg.DDTags,
+		"delay": s.cfg.ShutdownDelay.String(),
 	}).Info("Shutdown signal received, initiating graceful shutdown")
+	time.Sleep(s.cfg.ShutdownDelay)
+	stopJobs()
 
 	// Criar contexto com timeout para shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
 	defer shutdownCancel()
 
-	// Fechar conexões do Fiber
-	if err := s.app.Shutdown(); err != nil {
+	// Fechar conexões do Fiber, esperando as requisições em andamento no máximo até SHUTDOWN_TIMEOUT
+	if err := s.app.ShutdownWithContext(shutdownCtx); err != nil {
 		s.log.WithFields(logrus.Fields{
-			"ddsource": s.cfg.DDSource,
-			"service":  s.cfg.DDService,
-			"ddtags":   s.cfg.DDTags,
-			"error":    err.Error(),
+			"error": err.Error(),
 		}).Error("Failed to shutdown Fiber server")
 		return err
 	}
 
+	// Processar os pedidos de redefinição de senha já aceitos antes de desconectar do banco
+	if err := s.resets.Shutdown(shutdownCtx); err != nil {
+		s.log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to drain password reset queue")
+	}
+
 	// Fechar conexão com o banco de dados
 	if s.mongoDB != nil {
 		if err := s.mongoDB.Client.Disconnect(shutdownCtx); err != nil {
 			s.log.WithFields(logrus.Fields{
-				"ddsource": s.cfg.DDSource,
-				"service":  s.cfg.DDService,
-				"ddtags":   s.cfg.DDTags,
-				"error":    err.Error(),
+				"error": err.Error(),
 			}).Error("Failed to close database connection")
 			return err
 		}
 	}
 
-	s.log.WithFields(logrus.Fields{
-		"ddsource": s.cfg.DDSource,
-		"service":  s.cfg.DDService,
-		"ddtags":   s.cfg.DDTags,
-	}).Info("Server gracefully shutdown")
+	// Enviar spans pendentes antes de encerrar
+	if err := s.tracing.Shutdown(shutdownCtx); err != nil {
+		s.log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to flush traces")
+	}
+
+	s.log.Info("Server gracefully shutdown")
 	return nil
 }
+
+// prepareSchema aplica as migrations pendentes quando MIGRATE_ON_START está habilitado;
+// caso contrário apenas avisa que o comando migrate precisa ser executado
+func (s *Server) prepareSchema() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = logger.WithEntry(ctx, logrus.NewEntry(s.log))
+
+	if !s.cfg.MigrateOnStart {
+		pending, err := s.migrator.Pending(ctx)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Error("Failed to check database migrations")
+			return
+		}
+		if len(pending) > 0 {
+			s.log.WithFields(logrus.Fields{
+				"pending": len(pending),
+			}).Warn("Database has pending migrations; run the migrate up command or set MIGRATE_ON_START=true")
+		}
+		return
+	}
+
+	applied, err := s.migrator.Up(ctx)
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error("Failed to apply database migrations")
+		return
+	}
+	s.log.WithFields(logrus.Fields{
+		"applied": len(applied),
+	}).Info("Database schema up to date")
+}
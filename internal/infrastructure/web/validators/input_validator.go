@@ -54,6 +54,10 @@ func (v *InputValidator) FormatValidationError(err error) string {
 			messages = append(messages, fmt.Sprintf("Field '%s' must contain only letters", err.Field()))
 		case "alphanum":
 			messages = append(messages, fmt.Sprintf("Field '%s' must contain only letters and numbers", err.Field()))
+		case "oneof":
+			messages = append(messages, fmt.Sprintf("Field '%s' must be one of: %s", err.Field(), err.Param()))
+		case "datetime":
+			messages = append(messages, fmt.Sprintf("Field '%s' must be a RFC 3339 date (e.g. 2006-01-02T15:04:05Z)", err.Field()))
 		default:
 			messages = append(messages, fmt.Sprintf("Field '%s' is invalid", err.Field()))
 		}
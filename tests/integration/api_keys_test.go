@@ -0,0 +1,150 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const apiKeysEndpoint = "/api/v1/api-keys"
+
+// doAPIKey executa a requisição autenticada pelo cabeçalho X-API-Key
+func doAPIKey(t *testing.T, testApp *TestApp, method, path, key string, body interface{}) int {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewBuffer(payload)
+	}
+	req, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	req.Header.Set("X-API-Key", key)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "user-1", Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	// Apenas administradores criam chaves
+	input := dto.CreateAPIKeyRequestDTO{Name: "Batch job", Scopes: []string{"users:read"}}
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, userToken, input, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, adminToken, dto.CreateAPIKeyRequestDTO{
+		Name: "Unknown scope", Scopes: []string{"users:delete"},
+	}, nil))
+	past := testApp.Clock.Now().Add(-time.Hour)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, adminToken, dto.CreateAPIKeyRequestDTO{
+		Name: "Expired", Scopes: []string{"users:read"}, ExpiresAt: &past,
+	}, nil))
+
+	var created dto.CreateAPIKeyResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, adminToken, input, &created))
+	assert.True(t, strings.HasPrefix(created.Key, "umk_"))
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Equal(t, []string{"users:read"}, created.Scopes)
+	assert.Equal(t, "default", created.TenantID)
+
+	// Só o hash do segredo é gravado
+	var stored bson.M
+	require.NoError(t, testApp.DB.DB.Collection("api_keys").FindOne(context.Background(), bson.M{}).Decode(&stored))
+	for field, value := range stored {
+		assert.NotEqual(t, created.Key, value, field)
+	}
+
+	// A chave é aceita em X-API-Key e em Authorization: Bearer, limitada aos seus escopos
+	assert.Equal(t, http.StatusOK, doAPIKey(t, testApp, http.MethodGet, usersEndpoint, created.Key, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint, created.Key, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doAPIKey(t, testApp, http.MethodPost, usersEndpoint, created.Key, dto.CreateUserRequestDTO{
+		Name: "John", Email: "john@example.com",
+	}))
+	assert.Equal(t, http.StatusForbidden, doAPIKey(t, testApp, http.MethodGet, "/api/v1/groups", created.Key, nil))
+	assert.Equal(t, http.StatusForbidden, doAPIKey(t, testApp, http.MethodGet, apiKeysEndpoint, created.Key, nil))
+	assert.Equal(t, http.StatusUnauthorized, doAPIKey(t, testApp, http.MethodGet, usersEndpoint, "umk_unknown", nil))
+
+	var fetched dto.APIKeyResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, apiKeysEndpoint+"/"+created.ID, adminToken, nil, &fetched))
+	require.NotNil(t, fetched.LastUsedAt)
+	assert.True(t, testApp.Clock.Now().Equal(*fetched.LastUsedAt))
+
+	var list dto.ListAPIKeyResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, apiKeysEndpoint, adminToken, nil, &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, created.ID, list.Data[0].ID)
+
+	// Uma chave revogada deixa de autenticar e continua listada
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, apiKeysEndpoint+"/"+created.ID, adminToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, doAPIKey(t, testApp, http.MethodGet, usersEndpoint, created.Key, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, apiKeysEndpoint+"/"+created.ID, adminToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodDelete, apiKeysEndpoint+"/507f1f77bcf86cd799439011", adminToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, apiKeysEndpoint+"/"+created.ID, adminToken, nil, &fetched))
+	require.NotNil(t, fetched.RevokedAt)
+	assert.Equal(t, "admin-1", fetched.RevokedBy)
+}
+
+func TestAPIKeyExpiryAndScopes(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	platformToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "root", Type: auth.PrincipalUser, PlatformAdmin: true})
+	require.NoError(t, err)
+	for _, slug := range []string{"acme", "globex"} {
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: slug, Name: slug}, nil))
+	}
+
+	// A chave pertence ao tenant em que foi criada
+	expiresAt := testApp.Clock.Now().Add(time.Hour)
+	var adminKey dto.CreateAPIKeyResponseDTO
+	require.Equal(t, http.StatusCreated, doTenantJSON(t, testApp, http.MethodPost, apiKeysEndpoint, platformToken, "acme", dto.CreateAPIKeyRequestDTO{
+		Name: "Provisioning", Scopes: []string{"admin", "users:read", "users:write"}, ExpiresAt: &expiresAt,
+	}, &adminKey))
+	assert.Equal(t, "acme", adminKey.TenantID)
+
+	var created dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminKey.Key, dto.CreateUserRequestDTO{
+		Name: "John", Email: "john@example.com",
+	}, &created))
+	assert.Equal(t, "acme", created.TenantID)
+	assert.Equal(t, "api-key:"+adminKey.ID, created.CreatedBy)
+	assert.Equal(t, http.StatusForbidden, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint, adminKey.Key, "globex", nil, nil))
+
+	// Uma chave com escopo admin cria chaves apenas com escopos que ela mesma tem
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, adminKey.Key, dto.CreateAPIKeyRequestDTO{
+		Name: "Groups", Scopes: []string{"groups:write"},
+	}, nil))
+	assert.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, apiKeysEndpoint, adminKey.Key, dto.CreateAPIKeyRequestDTO{
+		Name: "Reader", Scopes: []string{"users:read"},
+	}, nil))
+
+	// Depois de expires_at a chave é recusada
+	testApp.Clock.Advance(2 * time.Hour)
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, usersEndpoint, adminKey.Key, nil, nil))
+}
@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditFieldsOnUserLifecycle(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	createdAt := testApp.Clock.Now()
+	var created dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+		Name: "Audit User", Email: "audit@example.com", Status: "active",
+	}, &created))
+	assert.True(t, createdAt.Equal(created.CreatedAt))
+	assert.True(t, createdAt.Equal(created.UpdatedAt))
+	assert.Equal(t, auth.AnonymousID, created.CreatedBy)
+	assert.Equal(t, auth.AnonymousID, created.UpdatedBy)
+
+	updatedAt := testApp.Clock.Advance(time.Hour)
+	userPath := fmt.Sprintf(usersEndpointFmt, created.ID)
+	var updated dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, userPath, "", dto.UpdateUserRequestDTO{
+		Name: "Audit User 2", Email: "audit@example.com",
+	}, &updated))
+	assert.True(t, createdAt.Equal(updated.CreatedAt))
+	assert.True(t, updatedAt.Equal(updated.UpdatedAt))
+
+	// Os valores persistidos são os mesmos retornados na criação/atualização
+	var fetched dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, "", nil, &fetched))
+	assert.True(t, createdAt.Equal(fetched.CreatedAt))
+	assert.True(t, updatedAt.Equal(fetched.UpdatedAt))
+
+	deletedAt := testApp.Clock.Advance(time.Hour)
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, userPath, "", nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath+"?include_deleted=true", "", nil, &fetched))
+	require.NotNil(t, fetched.DeletedAt)
+	assert.True(t, deletedAt.Equal(*fetched.DeletedAt))
+
+	restoredAt := testApp.Clock.Advance(time.Hour)
+	var restored dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/restore", "", nil, &restored))
+	assert.True(t, restoredAt.Equal(restored.UpdatedAt))
+}
+
+func TestAuditFieldsRecordAuthenticatedActor(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	creator, _, err := testApp.Tokens.Issue(auth.Principal{ID: "creator", Type: auth.PrincipalUser})
+	require.NoError(t, err)
+	// Só o owner do grupo ou um admin gerenciam os membros
+	editor, _, err := testApp.Tokens.Issue(auth.Principal{ID: "editor", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	const groupsEndpoint = "/api/v1/groups"
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, creator, dto.CreateGroupRequestDTO{Name: "Audited"}, &group))
+	assert.Equal(t, "creator", group.CreatedBy)
+
+	var member dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, creator, dto.CreateUserRequestDTO{
+		Name: "Member", Email: "member@example.com",
+	}, &member))
+
+	// Alterar os membros conta como atualização do grupo
+	changedAt := testApp.Clock.Advance(time.Minute)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, fmt.Sprintf("%s/%s/members/%s", groupsEndpoint, group.ID, member.ID), editor, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+group.ID, editor, nil, &group))
+	assert.Equal(t, "creator", group.CreatedBy)
+	assert.Equal(t, "editor", group.UpdatedBy)
+	assert.True(t, changedAt.Equal(group.UpdatedAt))
+}
+
+func TestListFilterAndSortByAuditFields(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	start := testApp.Clock.Now()
+	names := []string{"Bruno", "Ana", "Carla"}
+	for i, name := range names {
+		if i > 0 {
+			testApp.Clock.Advance(24 * time.Hour)
+		}
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+			Name: name, Email: fmt.Sprintf("%s@example.com", name),
+		}, nil))
+	}
+
+	list := func(query url.Values) []string {
+		var result dto.UserListResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"?"+query.Encode(), "", nil, &result))
+		var got []string
+		for _, u := range result.Data {
+			got = append(got, u.Name)
+		}
+		return got
+	}
+
+	assert.Equal(t, []string{"Carla", "Ana", "Bruno"}, list(url.Values{"sort": {"-created_at"}}))
+	assert.Equal(t, []string{"Ana", "Bruno", "Carla"}, list(url.Values{"sort": {"name"}}))
+
+	// created_after é inclusivo e created_before exclusivo
+	dayTwo := start.Add(24 * time.Hour).Format(time.RFC3339)
+	assert.Equal(t, []string{"Ana", "Carla"}, list(url.Values{"created_after": {dayTwo}, "sort": {"created_at"}}))
+	assert.Equal(t, []string{"Bruno"}, list(url.Values{"created_before": {dayTwo}}))
+	assert.Equal(t, []string{"Ana"}, list(url.Values{"search": {"an"}, "created_after": {dayTwo}, "created_before": {start.Add(48 * time.Hour).Format(time.RFC3339)}}))
+
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodGet, usersEndpoint+"?sort=is_active", "", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodGet, usersEndpoint+"?created_after=yesterday", "", nil, nil))
+}
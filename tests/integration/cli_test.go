@@ -0,0 +1,180 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"user-management/cmd"
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// setupCLI aponta a configuração da CLI, lida do ambiente, para o banco do TestApp e retorna uma
+// função que executa um comando e devolve a saída
+func setupCLI(t *testing.T, testApp *TestApp) func(args ...string) string {
+	t.Setenv("MONGO_URI", testApp.Config.MongoURI)
+	t.Setenv("MONGO_DB", testApp.Config.MongoDB)
+	t.Setenv("MAILER", "file")
+	t.Setenv("MAILER_FILE_DIR", t.TempDir())
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", testKeyEncryptionKey)
+	t.Setenv("LOG_LEVEL", "error")
+
+	return func(args ...string) string {
+		var out bytes.Buffer
+		require.NoError(t, cmd.Run(context.Background(), args, &out), strings.Join(args, " "))
+		return out.String()
+	}
+}
+
+// tableRows separa as linhas de uma saída em formato table nas suas colunas
+func tableRows(output string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			break
+		}
+		rows = append(rows, regexp.MustCompile(`\s{2,}`).Split(strings.TrimSpace(line), -1))
+	}
+	return rows
+}
+
+func TestCLIUsersCommands(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	run := setupCLI(t, testApp)
+
+	var ana, bruno dto.UserResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("users", "create", "--name", "Ana", "--email", "ana@example.com", "--status", "active", "-o", "json")), &ana))
+	assert.Equal(t, "Ana", ana.Name)
+	assert.Equal(t, "active", ana.Status)
+	require.NoError(t, json.Unmarshal([]byte(run("users", "create", "--name", "Bruno", "--email", "bruno@example.com", "--no-invite", "-o", "json")), &bruno))
+	assert.Equal(t, "pending", bruno.Status)
+
+	// table: cabeçalho, uma linha por usuário e o rodapé com a paginação
+	output := run("users", "list", "--sort", "name")
+	rows := tableRows(output)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"ID", "NAME", "EMAIL", "STATUS"}, rows[0])
+	assert.Equal(t, []string{ana.ID, "Ana", "ana@example.com", "active"}, rows[1])
+	assert.Equal(t, []string{bruno.ID, "Bruno", "bruno@example.com", "pending"}, rows[2])
+	assert.Contains(t, output, "Page 1 of 1 (2 users)")
+
+	// --page é baseada em 1, como na API
+	var list dto.UserListResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("users", "list", "--sort", "name", "--per-page", "1", "--page", "2", "-o", "json")), &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, bruno.ID, list.Data[0].ID)
+	assert.Equal(t, int64(2), list.Meta.Total)
+
+	// yaml usa os mesmos nomes de campos do json
+	var listYAML struct {
+		Users []struct {
+			ID     string `yaml:"id"`
+			Email  string `yaml:"email"`
+			Status string `yaml:"status"`
+		} `yaml:"users"`
+		Meta struct {
+			Total int64 `yaml:"total"`
+		} `yaml:"meta"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(run("users", "list", "--search", "ana", "-o", "yaml")), &listYAML))
+	require.Len(t, listYAML.Users, 1)
+	assert.Equal(t, ana.ID, listYAML.Users[0].ID)
+	assert.Equal(t, "ana@example.com", listYAML.Users[0].Email)
+	assert.Equal(t, int64(1), listYAML.Meta.Total)
+
+	// As flags de uma execução não passam para a próxima: sem -o a saída volta a ser table
+	var updated dto.UserResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("users", "update", ana.ID, "--name", "Ana Maria", "-o", "json")), &updated))
+	assert.Equal(t, "Ana Maria", updated.Name)
+	assert.Equal(t, "ana@example.com", updated.Email)
+	rows = tableRows(run("users", "get", ana.ID))
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{ana.ID, "Ana Maria", "ana@example.com", "active"}, rows[1])
+
+	var suspended dto.UserResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("users", "suspend", ana.ID, "--reason", "policy violation", "-o", "json")), &suspended))
+	assert.Equal(t, "suspended", suspended.Status)
+	assert.Equal(t, "policy violation", suspended.StatusReason)
+
+	assert.Equal(t, "User "+bruno.ID+" deleted\n", run("users", "delete", bruno.ID))
+	assert.Len(t, tableRows(run("users", "list")), 2)
+	assert.Len(t, tableRows(run("users", "list", "--include-deleted")), 3)
+	rows = tableRows(run("users", "restore", bruno.ID))
+	assert.Equal(t, []string{bruno.ID, "Bruno", "bruno@example.com", "pending"}, rows[1])
+
+	// Erros de validação e formatos desconhecidos chegam a quem executou o comando
+	var out bytes.Buffer
+	err := cmd.Run(context.Background(), []string{"users", "get", ana.ID, "-o", "xml"}, &out)
+	assert.EqualError(t, err, `unknown output format "xml" (use table, json or yaml)`)
+	err = cmd.Run(context.Background(), []string{"users", "create", "--name", "Carla", "--email", "not-an-email"}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid input")
+}
+
+func TestCLIGroupsCommands(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	run := setupCLI(t, testApp)
+
+	var ana, bruno dto.UserResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("users", "create", "--name", "Ana", "--email", "ana@example.com", "--status", "active", "-o", "json")), &ana))
+	require.NoError(t, json.Unmarshal([]byte(run("users", "create", "--name", "Bruno", "--email", "bruno@example.com", "--status", "active", "-o", "json")), &bruno))
+
+	var engineering, backend dto.GroupResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("groups", "create", "--name", "Engineering", "--owners", ana.ID, "-o", "json")), &engineering))
+	assert.Equal(t, []string{ana.ID}, engineering.Members)
+	require.NoError(t, json.Unmarshal([]byte(run("groups", "create", "--name", "Backend", "-o", "json")), &backend))
+	assert.Empty(t, backend.Members)
+
+	assert.Equal(t, "User "+bruno.ID+" added to group "+backend.ID+" as member\n", run("groups", "add-member", backend.ID, bruno.ID))
+	rows := tableRows(run("groups", "set-role", backend.ID, bruno.ID, "--role", "manager"))
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{backend.ID, "Backend", bruno.ID + ":manager", "false"}, rows[1])
+	assert.Equal(t, "Group "+backend.ID+" added to group "+engineering.ID+"\n", run("groups", "add-subgroup", engineering.ID, backend.ID))
+
+	// table: a coluna SUBGROUPS fica vazia nos grupos sem subgrupos
+	output := run("groups", "list", "--sort", "name")
+	lines := strings.Split(output, "\n")
+	assert.Regexp(t, `^ID\s+NAME\s+MEMBERS\s+SUBGROUPS\s+REQUIRE MFA$`, lines[0])
+	assert.Regexp(t, `^`+backend.ID+`\s+Backend\s+`+bruno.ID+`:manager\s+false$`, lines[1])
+	assert.Regexp(t, `^`+engineering.ID+`\s+Engineering\s+`+ana.ID+`:owner\s+`+backend.ID+`\s+false$`, lines[2])
+	assert.Contains(t, output, "Page 1 of 1 (2 groups)")
+
+	var list dto.ListGroupResponseDTO
+	require.NoError(t, json.Unmarshal([]byte(run("groups", "list", "--sort", "name", "--per-page", "1", "--page", "2", "-o", "json")), &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, engineering.ID, list.Data[0].ID)
+	assert.Equal(t, []string{backend.ID}, list.Data[0].Subgroups)
+
+	var required struct {
+		ID          string `yaml:"id"`
+		RequireMFA  bool   `yaml:"require_mfa"`
+		Memberships []struct {
+			UserID string `yaml:"user_id"`
+			Role   string `yaml:"role"`
+		} `yaml:"memberships"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(run("groups", "require-mfa", engineering.ID, "-o", "yaml")), &required))
+	assert.Equal(t, engineering.ID, required.ID)
+	assert.True(t, required.RequireMFA)
+	require.Len(t, required.Memberships, 1)
+	assert.Equal(t, ana.ID, required.Memberships[0].UserID)
+	assert.Equal(t, "owner", required.Memberships[0].Role)
+
+	// Aninhar Engineering em Backend fecharia um ciclo
+	var out bytes.Buffer
+	assert.Error(t, cmd.Run(context.Background(), []string{"groups", "add-subgroup", backend.ID, engineering.ID}, &out))
+
+	assert.Equal(t, "Group "+backend.ID+" removed from group "+engineering.ID+"\n", run("groups", "remove-subgroup", engineering.ID, backend.ID))
+	assert.Equal(t, "User "+bruno.ID+" removed from group "+backend.ID+"\n", run("groups", "remove-member", backend.ID, bruno.ID))
+	rows = tableRows(run("groups", "list", "--sort", "name"))
+	assert.Equal(t, []string{backend.ID, "Backend", "false"}, rows[1])
+}
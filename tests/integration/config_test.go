@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"user-management/internal/config"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func newConfigFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	config.RegisterFlags(flags)
+	require.NoError(t, flags.Parse(args))
+	return flags
+}
+
+func TestConfigLoadDefaultsWithoutEnvFile(t *testing.T) {
+	t.Setenv("MONGO_URI", testMongoURI)
+	t.Setenv("MONGO_DB", "defaults_db")
+	t.Setenv("MAILER", "log")
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", testKeyEncryptionKey)
+
+	cfg, err := config.Load(config.LoadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, ":8080", cfg.Port)
+	assert.Equal(t, 10*time.Second, cfg.MongoConnectTimeout)
+	assert.Equal(t, uint64(100), cfg.MongoMaxPoolSize)
+	assert.Equal(t, []string{"*"}, cfg.CORSAllowOrigins)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.False(t, cfg.AuthEnabled)
+}
+
+func TestConfigLoadLayerPrecedence(t *testing.T) {
+	file := writeConfigFile(t, "config.yaml", `
+mongo_uri: mongodb://file-host:27017
+mongo_db: file_db
+port: ":7000"
+log_level: debug
+mailer: file
+server_read_timeout: 3s
+cors_allow_origins:
+  - https://a.example.com
+  - https://b.example.com
+`)
+	t.Setenv("MONGO_DB", "env_db")
+	t.Setenv("PORT", ":7001")
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", testKeyEncryptionKey)
+
+	cfg, err := config.Load(config.LoadOptions{
+		File:  file,
+		Flags: newConfigFlags(t, "--port", ":7002"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "mongodb://file-host:27017", cfg.MongoURI) // arquivo
+	assert.Equal(t, "env_db", cfg.MongoDB)                     // env sobrescreve arquivo
+	assert.Equal(t, ":7002", cfg.Port)                         // flag sobrescreve env
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 3*time.Second, cfg.ServerReadTimeout)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.CORSAllowOrigins)
+}
+
+func TestConfigLoadTOMLFile(t *testing.T) {
+	file := writeConfigFile(t, "config.toml", `
+mongo_uri = "mongodb+srv://cluster.example.com"
+mongo_db = "toml_db"
+mailer = "smtp"
+smtp_host = "smtp.example.com"
+mongo_max_pool_size = 50
+otel_traces_sampler_arg = 0.25
+`)
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", testKeyEncryptionKey)
+
+	cfg, err := config.Load(config.LoadOptions{File: file})
+	require.NoError(t, err)
+
+	assert.Equal(t, "toml_db", cfg.MongoDB)
+	assert.Equal(t, uint64(50), cfg.MongoMaxPoolSize)
+	assert.Equal(t, 0.25, cfg.TracingSampleRatio)
+}
+
+func TestConfigLoadReportsEveryProblem(t *testing.T) {
+	t.Setenv("PORT", "8080")
+	t.Setenv("MONGO_CONNECT_TIMEOUT", "ten seconds")
+	t.Setenv("LOG_FORMAT", "xml")
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", "c2hvcnQ=")
+
+	_, err := config.Load(config.LoadOptions{})
+	require.Error(t, err)
+
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ElementsMatch(t, []string{
+		"MONGO_URI: is required",
+		"MONGO_DB: is required",
+		`MONGO_CONNECT_TIMEOUT: invalid duration "ten seconds" (use values such as 500ms, 10s, 1m)`,
+		"MONGO_CONNECT_TIMEOUT: must be greater than zero",
+		"PORT: must be in the form [host]:port, e.g. :8080",
+		"AUTH_TOKEN_SECRET: must have at least 32 characters when AUTH_ENABLED is true",
+		"MAILER: is required (smtp, file or log)",
+		"OIDC_KEY_ENCRYPTION_KEY: must be 32 random bytes encoded in base64 (e.g. openssl rand -base64 32)",
+		"LOG_FORMAT: must be json or text",
+	}, validationErr.Problems)
+}
+
+func TestConfigLoadRejectsUnknownFileSettings(t *testing.T) {
+	file := writeConfigFile(t, "config.yml", "mongo_uri: mongodb://localhost\nmongo_db: db\nmongo_url: typo\n")
+
+	_, err := config.Load(config.LoadOptions{File: file})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MONGO_URL: unknown setting")
+}
+
+func TestConfigLoadRateLimitRoutes(t *testing.T) {
+	file := writeConfigFile(t, "config.yaml", `
+mongo_uri: mongodb://localhost:27017
+mongo_db: db
+mailer: log
+rate_limit_routes:
+  - GET /api/v1/users=60/1m
+  - /api/v1/auth/*=10/30s
+`)
+	t.Setenv("OIDC_KEY_ENCRYPTION_KEY", testKeyEncryptionKey)
+
+	cfg, err := config.Load(config.LoadOptions{File: file})
+	require.NoError(t, err)
+
+	require.Len(t, cfg.RateLimitRoutes, 2)
+	assert.Equal(t, config.RateLimitRule{Method: "GET", Path: "/api/v1/users", Requests: 60, Period: time.Minute}, cfg.RateLimitRoutes[0])
+	assert.Equal(t, config.RateLimitRule{Path: "/api/v1/auth/*", Requests: 10, Period: 30 * time.Second}, cfg.RateLimitRoutes[1])
+
+	assert.True(t, cfg.RateLimitRoutes[0].Matches("GET", "/api/v1/users"))
+	assert.True(t, cfg.RateLimitRoutes[0].Matches("GET", "/api/v1/users/"))
+	assert.False(t, cfg.RateLimitRoutes[0].Matches("POST", "/api/v1/users"))
+	assert.False(t, cfg.RateLimitRoutes[0].Matches("GET", "/api/v1/users/123"))
+	assert.True(t, cfg.RateLimitRoutes[1].Matches("POST", "/api/v1/auth/password-reset/confirm"))
+	assert.False(t, cfg.RateLimitRoutes[1].Matches("POST", "/api/v1/users"))
+	assert.True(t, config.RateLimitRule{Path: "/api/v1/users/:id/sessions"}.Matches("GET", "/api/v1/users/42/sessions"))
+
+	t.Setenv("RATE_LIMIT_ROUTES", "GET /api/v1/users=60")
+	t.Setenv("RATE_LIMIT_STORE", "redis")
+	_, err = config.Load(config.LoadOptions{File: file})
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.ElementsMatch(t, []string{
+		`RATE_LIMIT_ROUTES: invalid quota "60" in rule "GET /api/v1/users=60" (use requests/period, e.g. 60/1m)`,
+		`RATE_LIMIT_STORE: unsupported store "redis" (use memory)`,
+	}, validationErr.Problems)
+}
@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/doctor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// insertInconsistentData grava dados que a API não deveria produzir, sem migrations aplicadas
+// (sem validators nem índice único de email)
+func insertInconsistentData(t *testing.T, testApp *TestApp) (keeper, duplicate, valid, group bson.ObjectID) {
+	ctx := context.Background()
+	users := testApp.DB.DB.Collection("users")
+	groups := testApp.DB.DB.Collection("groups")
+
+	keeper = bson.NewObjectIDFromTimestamp(time.Now().Add(-time.Hour))
+	duplicate = bson.NewObjectIDFromTimestamp(time.Now())
+	valid = bson.NewObjectID()
+	missing := bson.NewObjectID()
+	group = bson.NewObjectID()
+
+	_, err := users.InsertMany(ctx, []interface{}{
+		bson.M{"_id": keeper, "name": "John Doe", "email": "john@example.com", "status": "active"},
+		bson.M{"_id": duplicate, "name": "John D.", "email": "John@Example.com", "status": "active"},
+		bson.M{"_id": valid, "name": "Jane Roe", "email": "jane@example.com", "status": "active"},
+		bson.M{"_id": bson.NewObjectID(), "name": "X", "email": "not-an-email", "status": "suspended"},
+	})
+	require.NoError(t, err)
+
+	_, err = groups.InsertMany(ctx, []interface{}{
+		bson.M{"_id": group, "name": "Developers", "members": bson.A{
+			memberDoc(valid.Hex(), "owner"), memberDoc(missing.Hex(), "member"), memberDoc("user1", "member"),
+			memberDoc(duplicate.Hex(), "manager"), memberDoc(keeper.Hex(), "member"),
+		}},
+		bson.M{"_id": bson.NewObjectID(), "name": "Z", "members": bson.A{}},
+	})
+	require.NoError(t, err)
+	return keeper, duplicate, valid, group
+}
+
+// memberDoc monta um membro de grupo como gravado pelo repositório
+func memberDoc(userID, role string) bson.M {
+	return bson.M{"user_id": userID, "role": role, "joined_at": time.Now()}
+}
+
+func TestDoctorReportsIssues(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	_, duplicate, _, _ := insertInconsistentData(t, testApp)
+
+	report, err := doctor.NewDoctor(testApp.DB).Run(ctx, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.UsersScanned)
+	assert.Equal(t, 2, report.GroupsScanned)
+	assert.Equal(t, 1, report.Summary[doctor.IssueDuplicateEmail])
+	assert.Equal(t, 1, report.Summary[doctor.IssueDanglingMember])
+	assert.Equal(t, 1, report.Summary[doctor.IssueMalformedMember])
+	assert.Equal(t, 1, report.Summary[doctor.IssueInvalidUser])
+	assert.Equal(t, 1, report.Summary[doctor.IssueInvalidGroup])
+	assert.Equal(t, 0, report.Fixed)
+	assert.Equal(t, len(report.Issues), report.Unresolved)
+
+	for _, issue := range report.Issues {
+		if issue.Type == doctor.IssueDuplicateEmail {
+			assert.Equal(t, duplicate.Hex(), issue.DocumentID)
+		}
+	}
+
+	// Sem --fix nada é alterado
+	count, err := testApp.DB.DB.Collection("users").CountDocuments(ctx, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestDoctorFixRepairsReferencesAndDuplicates(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	keeper, duplicate, valid, groupID := insertInconsistentData(t, testApp)
+
+	report, err := doctor.NewDoctor(testApp.DB).Run(ctx, true)
+	require.NoError(t, err)
+	// Apenas os documentos inválidos continuam sem correção
+	assert.Equal(t, 2, report.Unresolved)
+	assert.Equal(t, 3, report.Fixed)
+
+	err = testApp.DB.DB.Collection("users").FindOne(ctx, bson.M{"_id": duplicate}).Err()
+	assert.Error(t, err, "duplicate user should be removed")
+
+	var group entities.Group
+	require.NoError(t, testApp.DB.DB.Collection("groups").FindOne(ctx, bson.M{"_id": groupID}).Decode(&group))
+	assert.Equal(t, []string{valid.Hex(), keeper.Hex()}, group.MemberIDs())
+	// A participação transferida mantém o papel do duplicado
+	member, _ := group.Member(keeper.Hex())
+	assert.Equal(t, entities.GroupRoleManager, member.Role)
+
+	// Uma segunda execução encontra apenas os problemas que exigem revisão manual
+	report, err = doctor.NewDoctor(testApp.DB).Run(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Fixed)
+	assert.Equal(t, 2, report.Unresolved)
+}
+
+func TestDoctorChecksSubgroups(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	a, b, c, missing := bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID()
+	groups := testApp.DB.DB.Collection("groups")
+	// a → b → a forma um ciclo; c referencia um grupo inexistente e um valor malformado
+	_, err := groups.InsertMany(ctx, []interface{}{
+		bson.M{"_id": a, "name": "Alpha", "members": bson.A{}, "subgroups": bson.A{b}},
+		bson.M{"_id": b, "name": "Beta", "members": bson.A{}, "subgroups": bson.A{a}},
+		bson.M{"_id": c, "name": "Gamma", "members": bson.A{}, "subgroups": bson.A{missing, "beta", b}},
+	})
+	require.NoError(t, err)
+
+	report, err := doctor.NewDoctor(testApp.DB).Run(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Summary[doctor.IssueDanglingSubgroup])
+	assert.Equal(t, 1, report.Summary[doctor.IssueMalformedSubgroup])
+	// Os dois grupos do ciclo são reportados, mas não há correção automática
+	assert.Equal(t, 2, report.Summary[doctor.IssueGroupCycle])
+	assert.Equal(t, 2, report.Fixed)
+	assert.Equal(t, 2, report.Unresolved)
+
+	var group struct {
+		Subgroups []bson.ObjectID `bson:"subgroups"`
+	}
+	require.NoError(t, groups.FindOne(ctx, bson.M{"_id": c}).Decode(&group))
+	assert.Equal(t, []bson.ObjectID{b}, group.Subgroups)
+}
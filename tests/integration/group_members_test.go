@@ -0,0 +1,233 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestListGroupMembersAndUserGroups(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	createUser := func(name string) string {
+		var created dto.UserResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+			Name: name, Email: name + "@example.com",
+		}, &created))
+		return created.ID
+	}
+	createGroup := func(name string, members ...string) string {
+		var created dto.GroupResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{
+			Name: name, Members: members,
+		}, &created))
+		return created.ID
+	}
+	members := func(groupID, query string) dto.UserListResponseDTO {
+		var list dto.UserListResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+groupID+"/members"+query, "", nil, &list))
+		return list
+	}
+	userGroups := func(userID, query string) dto.ListGroupResponseDTO {
+		var list dto.ListGroupResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, fmt.Sprintf(usersEndpointFmt, userID)+"/groups"+query, "", nil, &list))
+		return list
+	}
+	names := func(users []dto.UserResponseDTO) []string {
+		result := []string{}
+		for _, u := range users {
+			result = append(result, u.Name)
+		}
+		return result
+	}
+
+	carla, ana, bruno := createUser("carla"), createUser("ana"), createUser("bruno")
+	team := createGroup("Team", carla, ana, bruno)
+	createGroup("Design", ana)
+	createGroup("Empty")
+
+	// Os membros vêm completos e ordenados pelo nome
+	list := members(team, "")
+	assert.Equal(t, []string{"ana", "bruno", "carla"}, names(list.Data))
+	assert.Equal(t, "ana@example.com", list.Data[0].Email)
+	assert.Equal(t, int64(3), list.Meta.Total)
+
+	assert.Equal(t, []string{"bruno"}, names(members(team, "?search=BRU").Data))
+	assert.Equal(t, []string{"carla"}, names(members(team, "?search=carla@").Data))
+
+	page := members(team, "?page=2&per_page=2")
+	assert.Equal(t, []string{"carla"}, names(page.Data))
+	assert.Equal(t, int64(3), page.Meta.Total)
+	assert.Equal(t, int64(2), page.Meta.TotalPages)
+
+	// Usuários removidos não aparecem
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, fmt.Sprintf(usersEndpointFmt, carla), "", nil, nil))
+	assert.Equal(t, []string{"ana", "bruno"}, names(members(team, "").Data))
+
+	groups := userGroups(ana, "")
+	require.Len(t, groups.Data, 2)
+	assert.Equal(t, "Design", groups.Data[0].Name)
+	assert.Equal(t, "Team", groups.Data[1].Name)
+	assert.Equal(t, int64(2), groups.Meta.Total)
+
+	groups = userGroups(ana, "?search=tea")
+	require.Len(t, groups.Data, 1)
+	assert.Equal(t, team, groups.Data[0].ID)
+	assert.Empty(t, userGroups(bruno, "?search=design").Data)
+
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/507f1f77bcf86cd799439011/members", "", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/invalid-id/members", "", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, fmt.Sprintf(usersEndpointFmt, carla)+"/groups", "", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+team+"/members?per_page=500", "", nil, nil))
+}
+
+func TestBulkGroupMembership(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	createUser := func(name, status string) string {
+		var created dto.UserResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+			Name: name, Email: name + "@example.com", Status: status,
+		}, &created))
+		return created.ID
+	}
+	ana, bruno, carla, davi := createUser("ana", ""), createUser("bruno", ""), createUser("carla", ""), createUser("davi", "")
+	gone := createUser("gone", "active")
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, fmt.Sprintf(usersEndpointFmt, gone)+"/deprovision", "", dto.ChangeUserStatusRequestDTO{Reason: "left"}, nil))
+
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{Name: "Team"}, &group))
+	membersPath := groupsEndpoint + "/" + group.ID + "/members"
+	roles := func(diff dto.GroupMembersDiffResponseDTO) map[string]string {
+		result := map[string]string{}
+		for _, membership := range diff.Group.Memberships {
+			result[membership.UserID] = membership.Role
+		}
+		return result
+	}
+
+	// Repetições na lista são ignoradas
+	var diff dto.GroupMembersDiffResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, membersPath, "", dto.AddGroupMembersRequestDTO{UserIDs: []string{ana, bruno, ana}}, &diff))
+	assert.Equal(t, []string{ana, bruno}, diff.Added)
+	assert.Empty(t, diff.Removed)
+
+	// Quem já é membro mantém o papel
+	diff = dto.GroupMembersDiffResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, membersPath, "", dto.AddGroupMembersRequestDTO{UserIDs: []string{bruno, carla}, Role: "manager"}, &diff))
+	assert.Equal(t, []string{carla}, diff.Added)
+	assert.Equal(t, map[string]string{ana: "member", bruno: "member", carla: "manager"}, roles(diff))
+
+	// Com um usuário inválido nada é adicionado
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, membersPath, "", dto.AddGroupMembersRequestDTO{UserIDs: []string{davi, "507f1f77bcf86cd799439011"}}, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, membersPath, "", dto.AddGroupMembersRequestDTO{UserIDs: []string{davi, gone}}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, membersPath, "", dto.AddGroupMembersRequestDTO{UserIDs: []string{}}, nil))
+
+	diff = dto.GroupMembersDiffResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, membersPath, "", dto.RemoveGroupMembersRequestDTO{UserIDs: []string{ana, davi}}, &diff))
+	assert.Empty(t, diff.Added)
+	assert.Equal(t, []string{ana}, diff.Removed)
+	assert.ElementsMatch(t, []string{bruno, carla}, diff.Group.Members)
+
+	diff = dto.GroupMembersDiffResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{carla, davi}}, &diff))
+	assert.Equal(t, []string{davi}, diff.Added)
+	assert.Equal(t, []string{bruno}, diff.Removed)
+	assert.Equal(t, map[string]string{carla: "manager", davi: "member"}, roles(diff))
+
+	// Repetir a substituição não altera nada
+	diff = dto.GroupMembersDiffResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{davi, carla}}, &diff))
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{gone + "x"}}, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, groupsEndpoint+"/507f1f77bcf86cd799439011/members", "", dto.AddGroupMembersRequestDTO{UserIDs: []string{ana}}, nil))
+
+	diff = dto.GroupMembersDiffResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{}}, &diff))
+	assert.ElementsMatch(t, []string{carla, davi}, diff.Removed)
+	assert.Empty(t, diff.Group.Members)
+}
+
+func TestBulkGroupMembershipKeepsAnOwner(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	var owner, member dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{Name: "Owner", Email: "owner@example.com"}, &owner))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{Name: "Member", Email: "member@example.com"}, &member))
+
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{
+		Name: "Owned", Owners: []string{owner.ID}, Members: []string{member.ID},
+	}, &group))
+	membersPath := groupsEndpoint + "/" + group.ID + "/members"
+
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, membersPath, "", dto.RemoveGroupMembersRequestDTO{UserIDs: []string{owner.ID, member.ID}}, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{member.ID}}, nil))
+
+	var diff dto.GroupMembersDiffResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, membersPath, "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{owner.ID}}, &diff))
+	assert.Equal(t, []string{member.ID}, diff.Removed)
+	assert.Equal(t, []string{owner.ID}, diff.Group.Members)
+}
+
+func TestReplaceGroupMembersRejectsStaleGroup(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+	ctx := tenancy.WithTenant(context.Background(), tenancy.DefaultTenant)
+	groupRepo, err := repositories.NewGroupRepository(testApp.DB)
+	require.NoError(t, err)
+
+	var owner, member dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{Name: "Owner", Email: "owner@example.com"}, &owner))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{Name: "Member", Email: "member@example.com"}, &member))
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{
+		Name: "Owned", Owners: []string{owner.ID}, Members: []string{member.ID},
+	}, &group))
+	groupPath := groupsEndpoint + "/" + group.ID
+
+	// Uma troca de papel depois da leitura invalida a substituição calculada sobre ela
+	stale, err := groupRepo.GetByID(ctx, group.ID)
+	require.NoError(t, err)
+	testApp.Clock.Advance(time.Second)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, groupPath+"/members/"+member.ID, "", dto.UpdateGroupMemberRequestDTO{Role: "owner"}, nil))
+	err = groupRepo.ReplaceMembers(ctx, stale, []entities.GroupMember{stale.Members[0]}, testApp.Clock.Now(), "test")
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	// Assim como a saída de um membro, mesmo sem alterar updated_at
+	stale, err = groupRepo.GetByID(ctx, group.ID)
+	require.NoError(t, err)
+	require.NoError(t, groupRepo.RemoveUsersFromAllGroups(ctx, []string{member.ID}))
+	err = groupRepo.ReplaceMembers(ctx, stale, stale.Members, testApp.Clock.Now(), "test")
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	// Pela API, a substituição é refeita sobre o grupo atual
+	var diff dto.GroupMembersDiffResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, groupPath+"/members", "", dto.ReplaceGroupMembersRequestDTO{UserIDs: []string{owner.ID}}, &diff))
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestGroupMemberRoles(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// Cada usuário autentica com o próprio ID, que é o mesmo gravado em members
+	createUser := func(name string) (string, string) {
+		var created dto.UserResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+			Name: name, Email: name + "@example.com",
+		}, &created))
+		token, _, err := testApp.Tokens.Issue(auth.Principal{ID: created.ID, Type: auth.PrincipalUser})
+		require.NoError(t, err)
+		return created.ID, token
+	}
+	owner, ownerToken := createUser("owner")
+	manager, managerToken := createUser("manager")
+	member, memberToken := createUser("member")
+	outsider, outsiderToken := createUser("outsider")
+
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, ownerToken, dto.CreateGroupRequestDTO{Name: "Team"}, &group))
+	require.Len(t, group.Memberships, 1)
+	assert.Equal(t, owner, group.Memberships[0].UserID)
+	assert.Equal(t, "owner", group.Memberships[0].Role)
+	assert.Equal(t, []string{owner}, group.Members)
+
+	memberPath := func(userID string) string {
+		return fmt.Sprintf("%s/%s/members/%s", groupsEndpoint, group.ID, userID)
+	}
+	roleOf := func(userID string) string {
+		var fetched dto.GroupResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+group.ID, adminToken, nil, &fetched))
+		for _, membership := range fetched.Memberships {
+			if membership.UserID == userID {
+				return membership.Role
+			}
+		}
+		return ""
+	}
+
+	// Quem não é membro não altera o grupo
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, memberPath(outsider), outsiderToken, nil, nil))
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, memberPath(manager), ownerToken, dto.AddGroupMemberRequestDTO{Role: "manager"}, nil))
+	assert.Equal(t, "manager", roleOf(manager))
+
+	// O manager gerencia apenas membros comuns
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, memberPath(member), managerToken, nil, nil))
+	assert.Equal(t, "member", roleOf(member))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPut, memberPath(member), managerToken, dto.UpdateGroupMemberRequestDTO{Role: "manager"}, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, memberPath(outsider), managerToken, dto.AddGroupMemberRequestDTO{Role: "owner"}, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodDelete, memberPath(owner), managerToken, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, memberPath(outsider), memberToken, nil, nil))
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, memberPath(member), managerToken, nil, nil))
+	assert.Empty(t, roleOf(member))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, memberPath(member), managerToken, nil, nil))
+
+	// O owner altera papéis
+	var updated dto.GroupResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, memberPath(member), ownerToken, dto.UpdateGroupMemberRequestDTO{Role: "manager"}, &updated))
+	assert.Equal(t, "manager", roleOf(member))
+	assert.Len(t, updated.Memberships, 3)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPut, memberPath(member), ownerToken, dto.UpdateGroupMemberRequestDTO{Role: "admin"}, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPut, memberPath(outsider), ownerToken, dto.UpdateGroupMemberRequestDTO{Role: "member"}, nil))
+
+	// O grupo nunca fica sem owner
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPut, memberPath(owner), ownerToken, dto.UpdateGroupMemberRequestDTO{Role: "member"}, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, memberPath(owner), ownerToken, nil, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, memberPath(owner), adminToken, nil, nil))
+
+	// Com outro owner o primeiro pode sair
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, memberPath(manager), ownerToken, dto.UpdateGroupMemberRequestDTO{Role: "owner"}, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, memberPath(owner), ownerToken, nil, nil))
+	assert.Empty(t, roleOf(owner))
+	assert.Equal(t, "owner", roleOf(manager))
+}
+
+func TestGroupWithOwnersAndAdminOnlyLegacyGroups(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	var lead dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Lead", Email: "lead@example.com",
+	}, &lead))
+	leadToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: lead.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	// Com owners explícitos o criador não entra no grupo
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, adminToken, dto.CreateGroupRequestDTO{
+		Name: "Led", Owners: []string{lead.ID},
+	}, &group))
+	require.Len(t, group.Memberships, 1)
+	assert.Equal(t, lead.ID, group.Memberships[0].UserID)
+	assert.Equal(t, "owner", group.Memberships[0].Role)
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, groupsEndpoint+"/"+group.ID, leadToken, dto.UpdateGroupRequestDTO{
+		Name: "Led 2", Members: []string{lead.ID},
+	}, &group))
+	assert.Equal(t, "owner", group.Memberships[0].Role)
+	// Remover o último owner pelo PUT também é bloqueado
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPut, groupsEndpoint+"/"+group.ID, adminToken, dto.UpdateGroupRequestDTO{
+		Name: "Led 3", Members: []string{},
+	}, nil))
+
+	// Grupos anteriores aos papéis não têm owner e só são gerenciados por admins
+	legacyID := bson.NewObjectID()
+	_, err = testApp.DB.DB.Collection("groups").InsertOne(context.Background(), bson.M{
+		"_id": legacyID, "tenant_id": tenancy.DefaultTenant, "name": "Legacy", "members": bson.A{memberDoc(lead.ID, "member")},
+	})
+	require.NoError(t, err)
+	legacyPath := groupsEndpoint + "/" + legacyID.Hex()
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPut, legacyPath, leadToken, dto.UpdateGroupRequestDTO{
+		Name: "Legacy 2", Members: []string{},
+	}, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodDelete, legacyPath+"/members/"+lead.ID, leadToken, nil, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, legacyPath+"/members/"+lead.ID, adminToken, nil, nil))
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getHealthReport(t *testing.T, testApp *TestApp, path string) (int, health.Report) {
+	req, err := http.NewRequest("GET", path, nil)
+	require.NoError(t, err)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+
+	var report health.Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	return resp.StatusCode, report
+}
+
+func TestHealthLiveness(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	for _, path := range []string{"/health", "/health/live"} {
+		status, report := getHealthReport(t, testApp, path)
+		assert.Equal(t, 200, status, path)
+		assert.Equal(t, health.StatusUp, report.Status, path)
+	}
+}
+
+func TestHealthReadinessWaitsForMigrations(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	status, report := getHealthReport(t, testApp, "/health/ready")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Components["mongodb"].Status)
+	assert.NotEmpty(t, report.Components["mongodb"].Latency)
+	assert.Equal(t, health.StatusDown, report.Components["schema"].Status)
+	assert.Contains(t, report.Components["schema"].Error, migrations.ErrPendingMigrations.Error())
+
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	status, report = getHealthReport(t, testApp, "/health/ready")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, health.StatusUp, report.Status)
+	for name, component := range report.Components {
+		assert.Equal(t, health.StatusUp, component.Status, name)
+	}
+}
+
+func TestHealthReadinessFailsWhenShuttingDown(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+	testApp.Health.MarkShuttingDown()
+
+	status, report := getHealthReport(t, testApp, "/health/ready")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, health.StatusDown, report.Components["shutdown"].Status)
+
+	// Liveness continua respondendo durante o desligamento
+	status, _ = getHealthReport(t, testApp, "/health/live")
+	assert.Equal(t, 200, status)
+}
+
+func TestHealthReadinessFailsWhenMongoIsDown(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, testApp.DB.Client.Disconnect(context.Background()))
+
+	status, report := getHealthReport(t, testApp, "/health/ready")
+	assert.Equal(t, 503, status)
+	assert.Equal(t, health.StatusDown, report.Components["mongodb"].Status)
+	assert.NotEmpty(t, report.Components["mongodb"].Error)
+
+	// Evita que o Cleanup tente usar o cliente desconectado
+	testApp.DB = nil
+}
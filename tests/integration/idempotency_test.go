@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// doIdempotent executa o POST com o cabeçalho Idempotency-Key e retorna a resposta com o body lido
+func doIdempotent(t *testing.T, testApp *TestApp, path, bearer, key string, body interface{}) (*http.Response, []byte) {
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(payload))
+	require.NoError(t, err)
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	req.Header.Set("Idempotency-Key", key)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, content
+}
+
+func TestIdempotencyKeyReplaysCreatedUser(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	// As migrations criam o índice único das chaves
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	otherAdminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-2", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	jane := dto.CreateUserRequestDTO{Name: "Jane", Email: "jane@example.com"}
+
+	resp, created := doIdempotent(t, testApp, usersEndpoint, adminToken, "create-jane", jane)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+
+	// A retentativa recebe a mesma resposta sem criar outro usuário nem enviar outro convite
+	resp, replayed := doIdempotent(t, testApp, usersEndpoint, adminToken, "create-jane", jane)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	assert.Contains(t, resp.Header.Get("Content-Type"), contentTypeJSON)
+	assert.JSONEq(t, string(created), string(replayed))
+
+	var users dto.UserListResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint, adminToken, nil, &users))
+	assert.Equal(t, int64(1), users.Meta.Total)
+	assert.Len(t, sentEmails(t, testApp), 1)
+
+	// A mesma chave com outra requisição é recusada; outro cliente tem as próprias chaves
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, adminToken, "create-jane", dto.CreateUserRequestDTO{Name: "John", Email: "john@example.com"})
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, otherAdminToken, "create-jane", dto.CreateUserRequestDTO{Name: "John", Email: "john@example.com"})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Respostas de erro não são guardadas: a chave pode ser usada de novo
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, adminToken, "create-ann", dto.CreateUserRequestDTO{Name: "Ann"})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, adminToken, "create-ann", dto.CreateUserRequestDTO{Name: "Ann", Email: "ann@example.com"})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestIdempotencyKeyInFlightAndSecrets(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	jane := dto.CreateUserRequestDTO{Name: "Jane", Email: "jane@example.com"}
+
+	// Enquanto a primeira requisição não termina, as retentativas recebem 409
+	resp, _ := doIdempotent(t, testApp, usersEndpoint, adminToken, "create-jane", jane)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	keys := testApp.DB.DB.Collection("idempotency_keys")
+	_, err = keys.UpdateMany(context.Background(), bson.M{}, bson.M{"$unset": bson.M{"completed_at": "", "status_code": "", "content_type": "", "body": ""}})
+	require.NoError(t, err)
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, adminToken, "create-jane", jane)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Respostas com segredos (Cache-Control: no-store) não são guardadas
+	key := dto.CreateAPIKeyRequestDTO{Name: "ci", Scopes: []string{"users:read"}}
+	resp, _ = doIdempotent(t, testApp, apiKeysEndpoint, adminToken, "create-key", key)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	count, err := keys.CountDocuments(context.Background(), bson.M{"key": bson.M{"$regex": "create-key$"}})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Chaves longas demais são recusadas
+	resp, _ = doIdempotent(t, testApp, usersEndpoint, adminToken, string(bytes.Repeat([]byte("k"), 256)), jane)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
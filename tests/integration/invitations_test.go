@@ -0,0 +1,156 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var invitationLinkPattern = regexp.MustCompile(`http://localhost:3000/invitations/([A-Za-z0-9_-]+)`)
+
+// sentEmails retorna as mensagens gravadas pelo FileMailer, na ordem de envio
+func sentEmails(t *testing.T, testApp *TestApp) []string {
+	files, err := filepath.Glob(filepath.Join(testApp.MailDir, "*.eml"))
+	require.NoError(t, err)
+	sort.Strings(files)
+	emails := make([]string, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		emails = append(emails, string(content))
+	}
+	return emails
+}
+
+// lastInvitationToken extrai o token do link do último email enviado
+func lastInvitationToken(t *testing.T, testApp *TestApp) string {
+	emails := sentEmails(t, testApp)
+	require.NotEmpty(t, emails)
+	match := invitationLinkPattern.FindStringSubmatch(emails[len(emails)-1])
+	require.NotNil(t, match, "invitation link not found in email")
+	return match[1]
+}
+
+func acceptInvitationPath(token string) string {
+	return "/api/v1/invitations/" + token + "/accept"
+}
+
+func TestInvitationFlow(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// Usuários criados como pending recebem o convite por email; os ativos não
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com",
+	}, &user))
+	assert.Equal(t, "pending", user.Status)
+	assert.Nil(t, user.EmailVerifiedAt)
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "John", Email: "john@example.com", Status: "active",
+	}, nil))
+	emails := sentEmails(t, testApp)
+	require.Len(t, emails, 1)
+	assert.Contains(t, emails[0], "To: <jane@example.com>")
+	assert.Contains(t, emails[0], "From: \"User Management\" <no-reply@example.com>")
+	token := lastInvitationToken(t, testApp)
+
+	// O token é a credencial: a rota é pública e só aceita o token enviado
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, acceptInvitationPath("unknown"), "", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(token), "",
+		dto.AcceptInvitationRequestDTO{Password: "short"}, nil))
+
+	var accepted dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(token), "",
+		dto.AcceptInvitationRequestDTO{Password: "correct horse battery"}, &accepted))
+	assert.Equal(t, "active", accepted.Status)
+	require.NotNil(t, accepted.EmailVerifiedAt)
+	assert.True(t, accepted.EmailVerifiedAt.Equal(testApp.Clock.Now()))
+	assert.Equal(t, user.ID, accepted.StatusChangedBy)
+
+	// O banco guarda o hash bcrypt da senha e apenas a assinatura do token
+	objectID, err := bson.ObjectIDFromHex(user.ID)
+	require.NoError(t, err)
+	var stored bson.M
+	require.NoError(t, testApp.DB.DB.Collection("users").FindOne(context.Background(), bson.M{"_id": objectID}).Decode(&stored))
+	assert.True(t, strings.HasPrefix(stored["password_hash"].(string), "$2a$"))
+	count, err := testApp.DB.DB.Collection("invitations").CountDocuments(context.Background(), bson.M{"token_hash": token})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// O convite vale uma única vez
+	assert.Equal(t, http.StatusGone, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(token), "", nil, nil))
+
+	// Trocar o email volta a deixá-lo não verificado
+	var updated dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, usersEndpoint+"/"+user.ID, adminToken, dto.UpdateUserRequestDTO{
+		Name: "Jane", Email: "jane.doe@example.com",
+	}, &updated))
+	assert.Nil(t, updated.EmailVerifiedAt)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+user.ID, adminToken, nil, &updated))
+	assert.Nil(t, updated.EmailVerifiedAt)
+}
+
+func TestInvitationResendAndExpiration(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "user-1", Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	// send_invitation=false cria o usuário pending sem enviar o convite
+	sendInvitation := false
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", SendInvitation: &sendInvitation,
+	}, &user))
+	assert.Empty(t, sentEmails(t, testApp))
+
+	resendPath := usersEndpoint + "/" + user.ID + "/invitation"
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, resendPath, userToken, nil, nil))
+	var invitation dto.InvitationResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, resendPath, adminToken, nil, &invitation))
+	assert.Equal(t, "jane@example.com", invitation.Email)
+	assert.True(t, invitation.ExpiresAt.Equal(testApp.Clock.Now().Add(72*time.Hour)))
+	first := lastInvitationToken(t, testApp)
+
+	// Um novo convite invalida o link anterior
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, resendPath, adminToken, nil, nil))
+	second := lastInvitationToken(t, testApp)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, sentEmails(t, testApp), 2)
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(first), "", nil, nil))
+
+	// Expirado, o convite não ativa mais o usuário
+	testApp.Clock.Advance(72 * time.Hour)
+	assert.Equal(t, http.StatusGone, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(second), "", nil, nil))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, resendPath, adminToken, nil, nil))
+
+	// Sem body, o usuário é ativado sem senha
+	var accepted dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(lastInvitationToken(t, testApp)), "", nil, &accepted))
+	assert.Equal(t, "active", accepted.Status)
+	assert.NotNil(t, accepted.EmailVerifiedAt)
+
+	// Só usuários pending podem ser convidados
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, resendPath, adminToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, usersEndpoint+"/"+bson.NewObjectID().Hex()+"/invitation", adminToken, nil, nil))
+}
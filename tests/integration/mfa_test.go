@@ -0,0 +1,223 @@
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/usecases/mfa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mfaEndpoint = "/api/v1/auth/mfa"
+
+// totpCode calcula o código atual do segredo no relógio dos testes, como o aplicativo autenticador
+func totpCode(t *testing.T, testApp *TestApp, secret string) string {
+	code, err := mfa.GenerateCode(secret, testApp.Clock.Now())
+	require.NoError(t, err)
+	return code
+}
+
+// setupMFAUser cria um usuário ativo e retorna o usuário e um token dele ainda sem o segundo fator
+func setupMFAUser(t *testing.T, testApp *TestApp, adminToken string) (dto.UserResponseDTO, string) {
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", Status: "active",
+	}, &user))
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: user.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+	return user, userToken
+}
+
+// enrollMFA cadastra e confirma o TOTP do usuário e retorna o segredo e a confirmação
+func enrollMFA(t *testing.T, testApp *TestApp, userToken string) (string, dto.ConfirmMFAResponseDTO) {
+	var enrollment dto.EnrollMFAResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/enroll", userToken, nil, &enrollment))
+	var confirmed dto.ConfirmMFAResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/confirm", userToken,
+		dto.MFACodeRequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, &confirmed))
+	return enrollment.Secret, confirmed
+}
+
+func TestMFAEnrollmentAndVerification(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user, userToken := setupMFAUser(t, testApp, adminToken)
+	userPath := usersEndpoint + "/" + user.ID
+
+	var status dto.MFAStatusResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, mfaEndpoint, userToken, nil, &status))
+	assert.False(t, status.Enabled)
+	assert.False(t, status.Required)
+
+	// O cadastro traz o segredo e o otpauth URI do QR code, mas só vale depois de confirmado
+	var enrollment dto.EnrollMFAResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/enroll", userToken, nil, &enrollment))
+	assert.True(t, strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/User%20Management:jane@example.com?"))
+	assert.Contains(t, enrollment.OTPAuthURI, "secret="+enrollment.Secret)
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, userToken, nil, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, nil))
+
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/confirm", userToken,
+		dto.MFACodeRequestDTO{Code: "12345"}, nil))
+	staleCode, err := mfa.GenerateCode(enrollment.Secret, testApp.Clock.Now().Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/confirm", userToken,
+		dto.MFACodeRequestDTO{Code: staleCode}, nil))
+	var confirmed dto.ConfirmMFAResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/confirm", userToken,
+		dto.MFACodeRequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, &confirmed))
+	require.Len(t, confirmed.RecoveryCodes, 10)
+	assert.NotEmpty(t, confirmed.AccessToken)
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/enroll", userToken, nil, nil))
+
+	// Com o TOTP ativado, o token sem o segundo fator só acessa as rotas de MFA
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, userPath, userToken, nil, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, confirmed.AccessToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, mfaEndpoint, userToken, nil, &status))
+	assert.True(t, status.Enabled)
+	assert.True(t, status.Required)
+	assert.False(t, status.RequiredByPolicy)
+	assert.Equal(t, 10, status.RecoveryCodesRemaining)
+
+	// O código usado na confirmação não é aceito de novo; o do intervalo seguinte é
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, nil))
+	testApp.Clock.Advance(30 * time.Second)
+	var verified dto.MFATokenResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, &verified))
+	assert.Equal(t, "Bearer", verified.TokenType)
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, verified.AccessToken, nil, nil))
+
+	// Códigos de recuperação valem uma única vez, com ou sem hifens
+	recoveryCode := strings.ToUpper(strings.ReplaceAll(confirmed.RecoveryCodes[0], "-", ""))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{RecoveryCode: recoveryCode}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{RecoveryCode: confirmed.RecoveryCodes[0]}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{}, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, mfaEndpoint, userToken, nil, &status))
+	assert.Equal(t, 9, status.RecoveryCodesRemaining)
+
+	// Gerar novos códigos invalida os anteriores
+	testApp.Clock.Advance(30 * time.Second)
+	var regenerated dto.RecoveryCodesResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/recovery-codes", userToken,
+		dto.MFACodeRequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, &regenerated))
+	require.Len(t, regenerated.RecoveryCodes, 10)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{RecoveryCode: confirmed.RecoveryCodes[1]}, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken,
+		dto.VerifyMFARequestDTO{RecoveryCode: regenerated.RecoveryCodes[0]}, nil))
+
+	// API keys não têm segundo fator
+	var key dto.CreateAPIKeyResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/api-keys", adminToken, dto.CreateAPIKeyRequestDTO{
+		Name: "sync", Scopes: []string{auth.ScopeUsersRead},
+	}, &key))
+	assert.Equal(t, http.StatusForbidden, doAPIKey(t, testApp, http.MethodPost, mfaEndpoint+"/enroll", key.Key, nil))
+
+	// Desativar exige um código atual; depois disso o token comum volta a valer
+	testApp.Clock.Advance(30 * time.Second)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/disable", userToken,
+		dto.MFACodeRequestDTO{Code: "000000"}, nil))
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/disable", userToken,
+		dto.MFACodeRequestDTO{Code: totpCode(t, testApp, enrollment.Secret)}, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, userToken, nil, nil))
+}
+
+func TestMFAVerificationLockout(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	_, userToken := setupMFAUser(t, testApp, adminToken)
+	secret, confirmed := enrollMFA(t, testApp, userToken)
+	verify := func(input dto.VerifyMFARequestDTO) int {
+		return doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/verify", userToken, input, nil)
+	}
+
+	// Cinco códigos errados seguidos bloqueiam as verificações por um minuto, inclusive com
+	// códigos certos e de recuperação
+	testApp.Clock.Advance(30 * time.Second)
+	for range 5 {
+		require.Equal(t, http.StatusBadRequest, verify(dto.VerifyMFARequestDTO{Code: "000000"}))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, verify(dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, secret)}))
+	assert.Equal(t, http.StatusTooManyRequests, verify(dto.VerifyMFARequestDTO{RecoveryCode: confirmed.RecoveryCodes[0]}))
+
+	// Depois do bloqueio cada nova falha o renova pelo dobro do tempo
+	testApp.Clock.Advance(time.Minute)
+	assert.Equal(t, http.StatusBadRequest, verify(dto.VerifyMFARequestDTO{RecoveryCode: "not-a-code"}))
+	testApp.Clock.Advance(time.Minute)
+	assert.Equal(t, http.StatusTooManyRequests, verify(dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, secret)}))
+
+	// Um código aceito zera as tentativas
+	testApp.Clock.Advance(time.Minute)
+	assert.Equal(t, http.StatusOK, verify(dto.VerifyMFARequestDTO{Code: totpCode(t, testApp, secret)}))
+	assert.Equal(t, http.StatusBadRequest, verify(dto.VerifyMFARequestDTO{Code: "000000"}))
+	assert.Equal(t, http.StatusOK, verify(dto.VerifyMFARequestDTO{RecoveryCode: confirmed.RecoveryCodes[0]}))
+}
+
+func TestMFAGroupPolicy(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user, userToken := setupMFAUser(t, testApp, adminToken)
+
+	// O usuário é membro de Operators, aninhado em Admins
+	var admins, operators dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/groups", adminToken, dto.CreateGroupRequestDTO{Name: "Admins"}, &admins))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/groups", adminToken, dto.CreateGroupRequestDTO{Name: "Operators"}, &operators))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, "/api/v1/groups/"+operators.ID+"/members/"+user.ID, adminToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, "/api/v1/groups/"+admins.ID+"/subgroups/"+operators.ID, adminToken, nil, nil))
+
+	// Apenas administradores alteram a política
+	required, notRequired := true, false
+	policyPath := "/api/v1/groups/" + admins.ID + "/mfa-policy"
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPut, policyPath, userToken, dto.GroupMFAPolicyRequestDTO{Required: &required}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPut, policyPath, adminToken, dto.GroupMFAPolicyRequestDTO{}, nil))
+	var updated dto.GroupResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, policyPath, adminToken, dto.GroupMFAPolicyRequestDTO{Required: &required}, &updated))
+	assert.True(t, updated.RequireMFA)
+
+	// Atualizar o grupo não altera a política
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, "/api/v1/groups/"+admins.ID, adminToken, dto.UpdateGroupRequestDTO{Name: "Administrators"}, &updated))
+	assert.True(t, updated.RequireMFA)
+
+	// Membros efetivos sem o segundo fator só acessam as rotas de MFA, até cadastrá-lo
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, "/api/v1/groups", userToken, nil, nil))
+	var status dto.MFAStatusResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, mfaEndpoint, userToken, nil, &status))
+	assert.False(t, status.Enabled)
+	assert.True(t, status.Required)
+	assert.True(t, status.RequiredByPolicy)
+	secret, confirmed := enrollMFA(t, testApp, userToken)
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, "/api/v1/groups", confirmed.AccessToken, nil, nil))
+
+	// A política impede desativar o TOTP; o administrador pode reiniciá-lo
+	testApp.Clock.Advance(30 * time.Second)
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, mfaEndpoint+"/disable", userToken,
+		dto.MFACodeRequestDTO{Code: totpCode(t, testApp, secret)}, nil))
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, usersEndpoint+"/"+user.ID+"/mfa", adminToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodDelete, usersEndpoint+"/"+user.ID+"/mfa", adminToken, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, "/api/v1/groups", userToken, nil, nil))
+
+	// Desligada a política, o token comum volta a valer
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, policyPath, adminToken, dto.GroupMFAPolicyRequestDTO{Required: &notRequired}, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, "/api/v1/groups", userToken, nil, nil))
+}
@@ -0,0 +1,290 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"user-management/internal/application/dto"
+	"user-management/internal/domain/entities"
+	"user-management/internal/infrastructure/database/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func indexNames(t *testing.T, collection *mongo.Collection) []string {
+	specs, err := collection.Indexes().ListSpecifications(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+func TestMigrationsUpAppliesAllAndIsIdempotent(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	require.ErrorIs(t, testApp.Migrator.CheckSchema(ctx), migrations.ErrPendingMigrations)
+
+	applied, err := testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrations.All()))
+	require.NoError(t, testApp.Migrator.CheckSchema(ctx))
+
+	statuses, err := testApp.Migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied, status.Description)
+		assert.NotNil(t, status.AppliedAt)
+	}
+
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("users")), []string{"tenant_id_1_email_1", "name_1"})
+	assert.NotContains(t, indexNames(t, testApp.DB.DB.Collection("users")), "email_1")
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("groups")), []string{"name_1", "members.user_id_1", "tenant_id_1"})
+	assert.Contains(t, indexNames(t, testApp.DB.DB.Collection("tenants")), "slug_1")
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("api_keys")), []string{"key_hash_1", "tenant_id_1_created_at_-1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("oauth_clients")), []string{"client_id_1", "tenant_id_1_created_at_-1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("authorization_codes")), []string{"code_hash_1", "expires_at_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("signing_keys")), []string{"kid_1", "created_at_-1"})
+	assert.Contains(t, indexNames(t, testApp.DB.DB.Collection("mfa_enrollments")), "tenant_id_1_user_id_1")
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("invitations")), []string{"token_hash_1", "tenant_id_1_user_id_1", "expires_at_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("password_resets")), []string{"token_hash_1", "tenant_id_1_user_id_1_created_at_-1", "expires_at_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("audit_events")), []string{"tenant_id_1_created_at_-1", "tenant_id_1_user_id_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("sessions")), []string{"tenant_id_1_user_id_1_last_used_at_-1", "expires_at_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("refresh_tokens")), []string{"token_hash_1", "expires_at_1"})
+	assert.Subset(t, indexNames(t, testApp.DB.DB.Collection("idempotency_keys")), []string{"key_1", "expires_at_1"})
+
+	// Uma segunda execução não aplica nada
+	applied, err = testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrationsEnforceValidatorsAndIndexes(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	_, err := testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	users := testApp.DB.DB.Collection("users")
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "John", "email": "john@example.com", "status": "active"})
+	require.NoError(t, err)
+
+	// email duplicado no mesmo tenant viola o índice único; em outro tenant é permitido
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Other", "email": "john@example.com", "status": "active"})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "acme", "name": "John", "email": "john@example.com", "status": "active"})
+	assert.NoError(t, err)
+
+	// email inválido, _id em string, tenant ausente e status ausente ou desconhecido violam o validator
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Invalid", "email": "not-an-email", "status": "active"})
+	assert.Error(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"_id": "user1", "tenant_id": "default", "name": "Legacy", "email": "legacy@example.com", "status": "active"})
+	assert.Error(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"name": "No tenant", "email": "notenant@example.com", "status": "active"})
+	assert.Error(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "No status", "email": "nostatus@example.com"})
+	assert.Error(t, err)
+	_, err = users.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Locked", "email": "locked@example.com", "status": "locked"})
+	assert.Error(t, err)
+
+	groups := testApp.DB.DB.Collection("groups")
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "No members"})
+	assert.Error(t, err)
+	_, err = groups.InsertOne(ctx, bson.M{"name": "No tenant", "members": bson.A{}})
+	assert.Error(t, err)
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Team", "members": bson.A{}})
+	assert.NoError(t, err)
+	// subgroups guarda ObjectIDs para o $graphLookup
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Parent", "members": bson.A{}, "subgroups": bson.A{"team"}})
+	assert.Error(t, err)
+	// members guarda subdocumentos com papel conhecido
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Legacy", "members": bson.A{"user1"}})
+	assert.Error(t, err)
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Admins", "members": bson.A{
+		bson.M{"user_id": "user1", "role": "admin", "joined_at": time.Now()},
+	}})
+	assert.Error(t, err)
+	_, err = groups.InsertOne(ctx, bson.M{"tenant_id": "default", "name": "Owners", "members": bson.A{
+		bson.M{"user_id": "user1", "role": "owner", "joined_at": time.Now()},
+	}})
+	assert.NoError(t, err)
+
+	// o tenant padrão é criado pela migration e o slug é único
+	tenants := testApp.DB.DB.Collection("tenants")
+	_, err = tenants.InsertOne(ctx, bson.M{"slug": "default", "name": "Again"})
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+	_, err = tenants.InsertOne(ctx, bson.M{"slug": "Not A Slug", "name": "Invalid"})
+	assert.Error(t, err)
+}
+
+func TestMigrationsDownRevertsLatest(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	_, err := testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	all := migrations.All()
+	reverted, err := testApp.Migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, all[len(all)-1].Version, reverted[0].Version)
+
+	pending, err := testApp.Migrator.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.ErrorIs(t, testApp.Migrator.CheckSchema(ctx), migrations.ErrPendingMigrations)
+
+	// Os documentos continuam existindo; só os índices da última migration são removidos
+	assert.NotContains(t, indexNames(t, testApp.DB.DB.Collection("idempotency_keys")), "key_1")
+	assert.Contains(t, indexNames(t, testApp.DB.DB.Collection("refresh_tokens")), "token_hash_1")
+	assert.Contains(t, indexNames(t, testApp.DB.DB.Collection("users")), "tenant_id_1_email_1")
+
+	applied, err := testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+}
+
+func TestGroupWithoutMembersPassesValidator(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	// members omitido no payload precisa ser gravado como array vazio, não null
+	req, err := http.NewRequest("POST", "/api/v1/groups", strings.NewReader(`{"name":"Empty Group"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+}
+
+func TestMigrationsConvertIsActiveToStatus(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	users := testApp.DB.DB.Collection("users")
+	active, inactive := bson.NewObjectID(), bson.NewObjectID()
+	_, err := users.InsertMany(ctx, []interface{}{
+		bson.M{"_id": active, "name": "Active", "email": "active@example.com", "is_active": true},
+		bson.M{"_id": inactive, "name": "Inactive", "email": "inactive@example.com", "is_active": false},
+	})
+	require.NoError(t, err)
+
+	_, err = testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	statusOf := func(id bson.ObjectID) bson.M {
+		var doc bson.M
+		require.NoError(t, users.FindOne(ctx, bson.M{"_id": id}).Decode(&doc))
+		return doc
+	}
+	assert.Equal(t, "active", statusOf(active)["status"])
+	assert.NotContains(t, statusOf(active), "is_active")
+	assert.Equal(t, "suspended", statusOf(inactive)["status"])
+	assert.NotEmpty(t, statusOf(inactive)["status_reason"])
+
+	// Revertendo até a migration 005 o booleano volta a refletir o status
+	_, err = testApp.Migrator.Down(ctx, len(migrations.All())-4)
+	require.NoError(t, err)
+	assert.Equal(t, true, statusOf(active)["is_active"])
+	assert.Equal(t, false, statusOf(inactive)["is_active"])
+	assert.NotContains(t, statusOf(inactive), "status")
+}
+
+func TestMigrationsConvertGroupMembersToRoles(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	groups := testApp.DB.DB.Collection("groups")
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	withDate, withoutDate := bson.NewObjectID(), bson.NewObjectID()
+	_, err := groups.InsertMany(ctx, []interface{}{
+		bson.M{"_id": withDate, "name": "Team", "members": bson.A{"user1", "user2"}, "created_at": createdAt},
+		bson.M{"_id": withoutDate, "name": "Legacy", "members": bson.A{"user3"}},
+	})
+	require.NoError(t, err)
+
+	_, err = testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	membersOf := func(id bson.ObjectID) []entities.GroupMember {
+		var group entities.Group
+		require.NoError(t, groups.FindOne(ctx, bson.M{"_id": id}).Decode(&group))
+		return group.Members
+	}
+	members := membersOf(withDate)
+	require.Len(t, members, 2)
+	assert.Equal(t, "user1", members[0].UserID)
+	assert.Equal(t, entities.GroupRoleMember, members[0].Role)
+	assert.True(t, createdAt.Equal(members[0].JoinedAt))
+	// Sem created_at a data de entrada é a da migração
+	require.Len(t, membersOf(withoutDate), 1)
+	assert.False(t, membersOf(withoutDate)[0].JoinedAt.IsZero())
+
+	// Revertendo até a migration 007 members volta a ser a lista de IDs
+	_, err = testApp.Migrator.Down(ctx, len(migrations.All())-6)
+	require.NoError(t, err)
+	var legacy struct {
+		Members []string `bson:"members"`
+	}
+	require.NoError(t, groups.FindOne(ctx, bson.M{"_id": withDate}).Decode(&legacy))
+	assert.Equal(t, []string{"user1", "user2"}, legacy.Members)
+}
+
+func TestMigrationsAssignExistingDataToDefaultTenant(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	users := testApp.DB.DB.Collection("users")
+	groups := testApp.DB.DB.Collection("groups")
+	userID, groupID := bson.NewObjectID(), bson.NewObjectID()
+	_, err := users.InsertOne(ctx, bson.M{"_id": userID, "name": "John", "email": "john@example.com", "is_active": true})
+	require.NoError(t, err)
+	_, err = groups.InsertOne(ctx, bson.M{"_id": groupID, "name": "Team", "members": bson.A{userID.Hex()}})
+	require.NoError(t, err)
+
+	_, err = testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	tenantOf := func(collection *mongo.Collection, id bson.ObjectID) interface{} {
+		var doc bson.M
+		require.NoError(t, collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc))
+		return doc["tenant_id"]
+	}
+	assert.Equal(t, "default", tenantOf(users, userID))
+	assert.Equal(t, "default", tenantOf(groups, groupID))
+	count, err := testApp.DB.DB.Collection("tenants").CountDocuments(ctx, bson.M{"slug": "default"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// Os dados continuam visíveis pela API, que usa o tenant padrão
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+userID.Hex(), "", nil, &user))
+	assert.Equal(t, "default", user.TenantID)
+
+	// Revertendo até a migration 008 o tenant_id é removido
+	_, err = testApp.Migrator.Down(ctx, len(migrations.All())-7)
+	require.NoError(t, err)
+	assert.Nil(t, tenantOf(users, userID))
+	assert.Nil(t, tenantOf(groups, groupID))
+}
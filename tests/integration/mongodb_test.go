@@ -6,6 +6,7 @@ import (
 	"time"
 	"user-management/internal/config"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/logger"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -197,14 +198,14 @@ func TestNewMongoDBLoggingFields(t *testing.T) {
 		DDTags:    "env:test,version:1.0",
 	}
 
-	// Create a custom logger to capture log entries
-	logger := logrus.New()
+	// Create the application logger so the Datadog fields are injected by its hook
+	log := logger.NewLogger(cfg)
 
 	// Use a hook to capture log entries
 	var logEntries []*logrus.Entry
-	logger.AddHook(&testLogHook{entries: &logEntries})
+	log.AddHook(&testLogHook{entries: &logEntries})
 
-	mongodb, err := database.NewMongoDB(cfg, logger)
+	mongodb, err := database.NewMongoDB(cfg, log)
 
 	assert.Error(t, err)
 	assert.Nil(t, mongodb)
@@ -0,0 +1,164 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/application/usecases/group"
+	"user-management/internal/domain/entities"
+	domainrepos "user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestedGroupsEffectiveMembership(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	createUser := func(name string) string {
+		var created dto.UserResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+			Name: name, Email: name + "@example.com",
+		}, &created))
+		return created.ID
+	}
+	createGroup := func(name string, members ...string) string {
+		var created dto.GroupResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{
+			Name: name, Members: members,
+		}, &created))
+		return created.ID
+	}
+	nest := func(groupID, subgroupID string) int {
+		return doJSON(t, testApp, http.MethodPost, fmt.Sprintf("%s/%s/subgroups/%s", groupsEndpoint, groupID, subgroupID), "", nil, nil)
+	}
+	effectiveMembers := func(groupID string) []string {
+		var list dto.UserListResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+groupID+"/effective-members", "", nil, &list))
+		ids := []string{}
+		for _, u := range list.Data {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+	effectiveGroups := func(userID string) []string {
+		var list dto.ListGroupResponseDTO
+		require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, fmt.Sprintf(usersEndpointFmt, userID)+"/effective-groups", "", nil, &list))
+		names := []string{}
+		for _, g := range list.Data {
+			names = append(names, g.Name)
+		}
+		return names
+	}
+
+	ana, bruno, carla := createUser("ana"), createUser("bruno"), createUser("carla")
+	engineering := createGroup("Engineering", ana)
+	backend := createGroup("Backend", bruno)
+	frontend := createGroup("Frontend", carla, bruno)
+	platform := createGroup("Platform")
+
+	require.Equal(t, http.StatusOK, nest(engineering, backend))
+	require.Equal(t, http.StatusOK, nest(engineering, frontend))
+	require.Equal(t, http.StatusOK, nest(backend, platform))
+	// Repetir o aninhamento não é um erro
+	require.Equal(t, http.StatusOK, nest(engineering, backend))
+
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+engineering, "", nil, &group))
+	assert.ElementsMatch(t, []string{backend, frontend}, group.Subgroups)
+
+	// Ciclos diretos e indiretos são recusados
+	assert.Equal(t, http.StatusConflict, nest(engineering, engineering))
+	assert.Equal(t, http.StatusConflict, nest(backend, engineering))
+	assert.Equal(t, http.StatusConflict, nest(platform, engineering))
+	assert.Equal(t, http.StatusNotFound, nest(engineering, "64b7f0c2a1b2c3d4e5f60718"))
+
+	// bruno é membro de dois subgrupos e aparece uma única vez
+	assert.ElementsMatch(t, []string{ana, bruno, carla}, effectiveMembers(engineering))
+	assert.ElementsMatch(t, []string{bruno}, effectiveMembers(backend))
+	assert.Equal(t, []string{"Backend", "Engineering", "Frontend"}, effectiveGroups(bruno))
+	assert.Equal(t, []string{"Engineering"}, effectiveGroups(ana))
+
+	var page dto.UserListResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+engineering+"/effective-members?page=2&per_page=2", "", nil, &page))
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, int64(3), page.Meta.Total)
+	assert.Equal(t, int64(2), page.Meta.TotalPages)
+
+	// Um subgrupo removido deixa de contribuir com membros e de ser caminho para os pais
+	web := createGroup("Web")
+	require.Equal(t, http.StatusOK, nest(frontend, web))
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, groupsEndpoint+"/"+frontend, "", nil, nil))
+	assert.ElementsMatch(t, []string{ana, bruno}, effectiveMembers(engineering))
+	assert.Empty(t, effectiveGroups(carla))
+
+	// Mas continua na hierarquia para a verificação de ciclos, para que restaurá-lo seja seguro
+	assert.Equal(t, http.StatusConflict, nest(web, engineering))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, groupsEndpoint+"/"+frontend+"/restore", "", nil, nil))
+	assert.Equal(t, []string{"Engineering", "Frontend"}, effectiveGroups(carla))
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, fmt.Sprintf("%s/%s/subgroups/%s", groupsEndpoint, engineering, backend), "", nil, nil))
+	// Sem o aninhamento anterior, a hierarquia pode ser invertida
+	require.Equal(t, http.StatusOK, nest(backend, engineering))
+	assert.Equal(t, []string{"Backend", "Engineering"}, effectiveGroups(ana))
+	assert.Empty(t, effectiveMembers(platform))
+
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/64b7f0c2a1b2c3d4e5f60718/effective-members", "", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, fmt.Sprintf(usersEndpointFmt, "64b7f0c2a1b2c3d4e5f60718")+"/effective-groups", "", nil, nil))
+}
+
+// racingGroupRepository grava o aninhamento concorrente logo antes da escrita do caso de uso, isto
+// é, depois da verificação de ciclo
+type racingGroupRepository struct {
+	domainrepos.IGroupRepository
+	concurrent func(ctx context.Context)
+}
+
+func (r *racingGroupRepository) AddSubgroup(ctx context.Context, groupID, subgroupID string, updatedAt time.Time, updatedBy string) error {
+	if r.concurrent != nil {
+		r.concurrent(ctx)
+		r.concurrent = nil
+	}
+	return r.IGroupRepository.AddSubgroup(ctx, groupID, subgroupID, updatedAt, updatedBy)
+}
+
+func TestAddSubgroupRollsBackConcurrentCycle(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	ctx := auth.WithPrincipal(tenancy.WithTenant(context.Background(), tenancy.DefaultTenant), auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	groupRepo, err := repositories.NewGroupRepository(testApp.DB)
+	require.NoError(t, err)
+
+	createGroup := func(name string) string {
+		var created dto.GroupResponseDTO
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/groups", "", dto.CreateGroupRequestDTO{Name: name}, &created))
+		return created.ID
+	}
+	alpha, beta := createGroup("Alpha"), createGroup("Beta")
+
+	// Beta entra em Alpha enquanto Alpha é aninhado em Beta: a verificação não vê o ciclo, a
+	// releitura depois da escrita sim
+	racing := &racingGroupRepository{IGroupRepository: groupRepo, concurrent: func(ctx context.Context) {
+		require.NoError(t, groupRepo.AddSubgroup(ctx, alpha, beta, testApp.Clock.Now(), "concurrent"))
+	}}
+	err = group.NewAddSubgroupUseCase(racing, testApp.Clock).Execute(ctx, beta, alpha)
+	assert.ErrorIs(t, err, entities.ErrGroupCycle)
+
+	betaGroup, err := groupRepo.GetByID(ctx, beta)
+	require.NoError(t, err)
+	assert.Empty(t, betaGroup.Subgroups)
+	descendants, err := groupRepo.GetDescendantIDs(ctx, alpha)
+	require.NoError(t, err)
+	assert.Equal(t, []string{beta}, descendants)
+}
@@ -0,0 +1,352 @@
+package integration
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	oauthClientsEndpoint = "/api/v1/oauth-clients"
+	oidcRedirectURI      = "https://app.example.com/callback"
+	oidcVerifier         = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+// oidcChallenge é o code_challenge S256 de oidcVerifier
+func oidcChallenge() string {
+	sum := sha256.Sum256([]byte(oidcVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authorizeParams monta os parâmetros de uma autorização válida para o cliente
+func authorizeParams(clientID string) url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {oidcRedirectURI},
+		"scope":                 {"openid profile email groups"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {oidcChallenge()},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+// authorize chama o endpoint de autorização e retorna o status e, nos redirecionamentos, os
+// parâmetros da query do Location
+func authorize(t *testing.T, testApp *TestApp, bearer string, params url.Values) (int, url.Values) {
+	req, err := http.NewRequest(http.MethodGet, "/oauth2/authorize?"+params.Encode(), nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return resp.StatusCode, nil
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(location.String(), oidcRedirectURI))
+	return resp.StatusCode, location.Query()
+}
+
+// exchangeCode envia o formulário ao endpoint de token, autenticando o cliente com Basic quando
+// clientSecret não é vazio, e retorna o status e o corpo da resposta
+func exchangeCode(t *testing.T, testApp *TestApp, clientID, clientSecret string, form url.Values) (int, map[string]interface{}) {
+	req, err := http.NewRequest(http.MethodPost, "/oauth2/token", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set(contentTypeHeader, "application/x-www-form-urlencoded")
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func tokenForm(clientID, code string) url.Values {
+	return url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {oidcRedirectURI},
+		"client_id":     {clientID},
+		"code_verifier": {oidcVerifier},
+	}
+}
+
+// fetchJWKS retorna as chaves públicas publicadas, indexadas pelo kid
+func fetchJWKS(t *testing.T, testApp *TestApp) map[string]*rsa.PublicKey {
+	req, err := http.NewRequest(http.MethodGet, "/oauth2/jwks", nil)
+	require.NoError(t, err)
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var jwks struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		assert.Equal(t, "RSA", k.KeyType)
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		require.NoError(t, err)
+		keys[k.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys
+}
+
+// userInfoStatus chama o userinfo com o token e decodifica a resposta em out quando é 200
+func userInfoStatus(t *testing.T, testApp *TestApp, accessToken string, out interface{}) int {
+	req, err := http.NewRequest(http.MethodGet, "/oauth2/userinfo", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func setupOIDCApp(t *testing.T) *TestApp {
+	return SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+}
+
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// Usuário ativo, membro direto de Developers e, pelo aninhamento, de Engineering
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", Status: "active",
+	}, &user))
+	var developers, engineering dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/groups", adminToken, dto.CreateGroupRequestDTO{Name: "Developers"}, &developers))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, "/api/v1/groups", adminToken, dto.CreateGroupRequestDTO{Name: "Engineering"}, &engineering))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, "/api/v1/groups/"+developers.ID+"/members/"+user.ID, adminToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, "/api/v1/groups/"+engineering.ID+"/subgroups/"+developers.ID, adminToken, nil, nil))
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: user.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	// Apenas administradores registram clientes, sempre com redirect_uris absolutas
+	input := dto.CreateOAuthClientRequestDTO{Name: "Portal", RedirectURIs: []string{oidcRedirectURI}}
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, oauthClientsEndpoint, userToken, input, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, oauthClientsEndpoint, adminToken, dto.CreateOAuthClientRequestDTO{
+		Name: "Fragment", RedirectURIs: []string{"https://app.example.com/callback#frag"},
+	}, nil))
+	var client dto.CreateOAuthClientResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, oauthClientsEndpoint, adminToken, input, &client))
+	require.NotEmpty(t, client.ClientSecret)
+	assert.False(t, client.Public)
+	var list dto.ListOAuthClientResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, oauthClientsEndpoint, adminToken, nil, &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, client.ClientID, list.Data[0].ClientID)
+
+	// A discovery aponta para os endpoints a partir de OIDC_ISSUER
+	var discovery dto.DiscoveryResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, "/.well-known/openid-configuration", "", nil, &discovery))
+	assert.Equal(t, "http://localhost:8080", discovery.Issuer)
+	assert.Equal(t, "http://localhost:8080/oauth2/jwks", discovery.JWKSURI)
+	assert.Equal(t, []string{"S256"}, discovery.CodeChallengeMethodsSupported)
+
+	// Cliente ou redirect_uri desconhecidos não redirecionam; os demais erros voltam ao cliente
+	params := authorizeParams(client.ClientID)
+	params.Set("redirect_uri", "https://evil.example.com/callback")
+	status, _ := authorize(t, testApp, userToken, params)
+	assert.Equal(t, http.StatusBadRequest, status)
+	params = authorizeParams(client.ClientID)
+	params.Del("code_challenge")
+	status, query := authorize(t, testApp, userToken, params)
+	require.Equal(t, http.StatusFound, status)
+	assert.Equal(t, "invalid_request", query.Get("error"))
+	assert.Equal(t, "xyz", query.Get("state"))
+	status, query = authorize(t, testApp, adminToken, authorizeParams(client.ClientID))
+	require.Equal(t, http.StatusFound, status)
+	assert.Equal(t, "access_denied", query.Get("error"))
+
+	// Um code_verifier errado invalida o código
+	status, query = authorize(t, testApp, userToken, authorizeParams(client.ClientID))
+	require.Equal(t, http.StatusFound, status)
+	form := tokenForm(client.ClientID, query.Get("code"))
+	form.Set("code_verifier", strings.Repeat("a", 43))
+	status, body := exchangeCode(t, testApp, client.ClientID, client.ClientSecret, form)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+	status, body = exchangeCode(t, testApp, client.ClientID, client.ClientSecret, tokenForm(client.ClientID, query.Get("code")))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+
+	status, query = authorize(t, testApp, userToken, authorizeParams(client.ClientID))
+	require.Equal(t, http.StatusFound, status)
+	code := query.Get("code")
+	require.NotEmpty(t, code)
+	assert.Equal(t, "xyz", query.Get("state"))
+
+	// Cliente confidencial sem o segredo é recusado (e o código, consumido)
+	status, body = exchangeCode(t, testApp, client.ClientID, "wrong", tokenForm(client.ClientID, code))
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+
+	status, query = authorize(t, testApp, userToken, authorizeParams(client.ClientID))
+	require.Equal(t, http.StatusFound, status)
+	code = query.Get("code")
+	status, body = exchangeCode(t, testApp, client.ClientID, client.ClientSecret, tokenForm(client.ClientID, code))
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, "Bearer", body["token_type"])
+	assert.Equal(t, "openid profile email groups", body["scope"])
+
+	// O código vale uma única vez
+	status, body = exchangeCode(t, testApp, client.ClientID, client.ClientSecret, tokenForm(client.ClientID, code))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+
+	// O ID token é verificável com o JWKS e traz os grupos efetivos do usuário
+	keys := fetchJWKS(t, testApp)
+	require.Len(t, keys, 1)
+	idClaims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(stringField(t, body, "id_token"), idClaims, func(token *jwt.Token) (interface{}, error) {
+		return keys[token.Header["kid"].(string)], nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithTimeFunc(testApp.Clock.Now), jwt.WithIssuer("http://localhost:8080"), jwt.WithAudience(client.ClientID))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, idClaims["sub"])
+	assert.Equal(t, "n-0S6_WzA2Mj", idClaims["nonce"])
+	assert.Equal(t, "jane@example.com", idClaims["email"])
+	assert.Equal(t, "default", idClaims["tenant"])
+	assert.Equal(t, []interface{}{"Developers", "Engineering"}, idClaims["groups"])
+
+	// O userinfo aceita o access token, mas não o ID token
+	var info dto.UserInfoResponseDTO
+	require.Equal(t, http.StatusOK, userInfoStatus(t, testApp, stringField(t, body, "access_token"), &info))
+	assert.Equal(t, user.ID, info.Subject)
+	assert.Equal(t, "Jane", info.Name)
+	assert.Equal(t, []string{"Developers", "Engineering"}, info.Groups)
+	assert.Equal(t, http.StatusUnauthorized, userInfoStatus(t, testApp, stringField(t, body, "id_token"), nil))
+
+	// Um usuário suspenso deixa de ser atendido pelo userinfo
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, usersEndpoint+"/"+user.ID+"/suspend", adminToken, dto.ChangeUserStatusRequestDTO{Reason: "test"}, nil))
+	assert.Equal(t, http.StatusUnauthorized, userInfoStatus(t, testApp, stringField(t, body, "access_token"), nil))
+
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, oauthClientsEndpoint+"/"+client.ClientID, adminToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, oauthClientsEndpoint+"/"+client.ClientID, adminToken, nil, nil))
+}
+
+func TestOIDCPublicClientAndKeyRotation(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", Status: "active",
+	}, &user))
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: user.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	var client dto.CreateOAuthClientResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, oauthClientsEndpoint, adminToken, dto.CreateOAuthClientRequestDTO{
+		Name: "SPA", RedirectURIs: []string{oidcRedirectURI}, Public: true,
+	}, &client))
+	assert.True(t, client.Public)
+	assert.Empty(t, client.ClientSecret)
+
+	// Clientes públicos trocam o código só com o code_verifier
+	issue := func() string {
+		status, query := authorize(t, testApp, userToken, authorizeParams(client.ClientID))
+		require.Equal(t, http.StatusFound, status)
+		status, body := exchangeCode(t, testApp, client.ClientID, "", tokenForm(client.ClientID, query.Get("code")))
+		require.Equal(t, http.StatusOK, status, body)
+		return stringField(t, body, "access_token")
+	}
+
+	// A primeira emissão gera a chave; pouco antes de ela vencer é emitido um token que ainda
+	// vale depois da troca
+	issue()
+	firstKeys := fetchJWKS(t, testApp)
+	require.Len(t, firstKeys, 1)
+	testApp.Clock.Advance(23*time.Hour + 30*time.Minute)
+	oldToken := issue()
+	require.Len(t, fetchJWKS(t, testApp), 1)
+
+	// Passado OIDC_KEY_ROTATION_INTERVAL uma nova chave assina e a anterior continua publicada
+	testApp.Clock.Advance(45 * time.Minute)
+	newToken := issue()
+	keys := fetchJWKS(t, testApp)
+	require.Len(t, keys, 2)
+	assert.Equal(t, http.StatusOK, userInfoStatus(t, testApp, oldToken, nil))
+	assert.Equal(t, http.StatusOK, userInfoStatus(t, testApp, newToken, nil))
+
+	// O código expira em OIDC_CODE_TTL
+	status, query := authorize(t, testApp, userToken, authorizeParams(client.ClientID))
+	require.Equal(t, http.StatusFound, status)
+	testApp.Clock.Advance(2 * time.Minute)
+	status, body := exchangeCode(t, testApp, client.ClientID, "", tokenForm(client.ClientID, query.Get("code")))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid_grant", body["error"])
+
+	// Quando os tokens assinados com ela expiram, a chave antiga sai do JWKS
+	testApp.Clock.Advance(2 * time.Hour)
+	keys = fetchJWKS(t, testApp)
+	require.Len(t, keys, 1)
+	for kid := range firstKeys {
+		assert.NotContains(t, keys, kid)
+	}
+	assert.Equal(t, http.StatusUnauthorized, userInfoStatus(t, testApp, oldToken, nil))
+
+	// rotate-keys troca a chave imediatamente
+	kid, err := testApp.Keys.Rotate(t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, fetchJWKS(t, testApp), kid)
+
+	// A chave privada é gravada cifrada com OIDC_KEY_ENCRYPTION_KEY
+	var stored entities.SigningKey
+	require.NoError(t, testApp.DB.DB.Collection("signing_keys").FindOne(t.Context(), bson.M{"kid": kid}).Decode(&stored))
+	assert.NotContains(t, stored.PrivateKey, "PRIVATE KEY")
+	assert.True(t, strings.HasPrefix(stored.PrivateKey, "aes256gcm:"))
+}
+
+func stringField(t *testing.T, body map[string]interface{}, field string) string {
+	value, ok := body[field].(string)
+	require.True(t, ok, field)
+	return value
+}
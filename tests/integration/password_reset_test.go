@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	passwordResetEndpoint        = "/api/v1/auth/password-reset"
+	passwordResetConfirmEndpoint = "/api/v1/auth/password-reset/confirm"
+)
+
+var passwordResetLinkPattern = regexp.MustCompile(`http://localhost:3000/password-reset/([A-Za-z0-9_-]+)`)
+
+// waitForEmails espera o processamento em segundo plano dos pedidos até existirem count emails
+func waitForEmails(t *testing.T, testApp *TestApp, count int) []string {
+	require.Eventually(t, func() bool {
+		return len(sentEmails(t, testApp)) >= count
+	}, 5*time.Second, 20*time.Millisecond)
+	return sentEmails(t, testApp)
+}
+
+// lastPasswordResetToken extrai o token do link do último email enviado
+func lastPasswordResetToken(t *testing.T, emails []string) string {
+	require.NotEmpty(t, emails)
+	match := passwordResetLinkPattern.FindStringSubmatch(emails[len(emails)-1])
+	require.NotNil(t, match, "password reset link not found in email")
+	return match[1]
+}
+
+func TestPasswordResetFlow(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", Status: "active",
+	}, &user))
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: user.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+user.ID, userToken, nil, nil))
+
+	// A resposta é a mesma para emails desconhecidos, que não recebem nada
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+		dto.PasswordResetRequestDTO{Email: "not-an-email"}, nil))
+	assert.Equal(t, http.StatusAccepted, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+		dto.PasswordResetRequestDTO{Email: "nobody@example.com"}, nil))
+	assert.Equal(t, http.StatusAccepted, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+		dto.PasswordResetRequestDTO{Email: "jane@example.com"}, nil))
+	emails := waitForEmails(t, testApp, 1)
+	require.Len(t, emails, 1)
+	assert.Contains(t, emails[0], "To: <jane@example.com>")
+	token := lastPasswordResetToken(t, emails)
+
+	// Só o hash do token é gravado
+	var stored bson.M
+	require.NoError(t, testApp.DB.DB.Collection("password_resets").FindOne(ctx, bson.M{"user_id": user.ID}).Decode(&stored))
+	assert.NotEqual(t, token, stored["token_hash"])
+
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "",
+		dto.ConfirmPasswordResetRequestDTO{Token: token, Password: "short"}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "",
+		dto.ConfirmPasswordResetRequestDTO{Token: "unknown", Password: "a-new-long-password"}, nil))
+
+	// Os tokens de acesso emitidos antes da redefinição deixam de valer; o relógio dos testes
+	// começa em 2024 e os tokens usam o horário real, então ele é adiantado para depois da emissão
+	testApp.Clock.Advance(time.Since(testApp.Clock.Now()) + time.Minute)
+	req := dto.ConfirmPasswordResetRequestDTO{Token: token, Password: "a-new-long-password"}
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "", req, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "", req, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+user.ID, userToken, nil, nil))
+
+	objectID, err := bson.ObjectIDFromHex(user.ID)
+	require.NoError(t, err)
+	var raw bson.M
+	require.NoError(t, testApp.DB.DB.Collection("users").FindOne(ctx, bson.M{"_id": objectID}).Decode(&raw))
+	assert.Regexp(t, `^\$2[aby]\$`, raw["password_hash"])
+	assert.NotNil(t, raw["sessions_revoked_at"])
+
+	var event bson.M
+	require.NoError(t, testApp.DB.DB.Collection("audit_events").FindOne(ctx, bson.M{"user_id": user.ID}).Decode(&event))
+	assert.Equal(t, "user.password_reset", event["action"])
+	assert.Equal(t, user.ID, event["actor_id"])
+	assert.Equal(t, "default", event["tenant_id"])
+}
+
+func TestPasswordResetRateLimitAndExpiration(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com", Status: "active",
+	}, nil))
+
+	// No máximo PASSWORD_RESET_MAX_REQUESTS emails por janela de PASSWORD_RESET_TTL
+	for i := 1; i <= int(testApp.Config.PasswordResetMaxRequests); i++ {
+		require.Equal(t, http.StatusAccepted, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+			dto.PasswordResetRequestDTO{Email: "jane@example.com"}, nil))
+		waitForEmails(t, testApp, i)
+	}
+	require.Equal(t, http.StatusAccepted, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+		dto.PasswordResetRequestDTO{Email: "jane@example.com"}, nil))
+	time.Sleep(200 * time.Millisecond)
+	emails := sentEmails(t, testApp)
+	assert.Len(t, emails, int(testApp.Config.PasswordResetMaxRequests))
+	token := lastPasswordResetToken(t, emails)
+
+	// Os tokens expiram depois de PASSWORD_RESET_TTL
+	testApp.Clock.Advance(testApp.Config.PasswordResetTTL + time.Second)
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "",
+		dto.ConfirmPasswordResetRequestDTO{Token: token, Password: "a-new-long-password"}, nil))
+
+	// Passada a janela, um novo pedido volta a ser atendido
+	require.Equal(t, http.StatusAccepted, doJSON(t, testApp, http.MethodPost, passwordResetEndpoint, "",
+		dto.PasswordResetRequestDTO{Email: "jane@example.com"}, nil))
+	emails = waitForEmails(t, testApp, len(emails)+1)
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodPost, passwordResetConfirmEndpoint, "",
+		dto.ConfirmPasswordResetRequestDTO{Token: lastPasswordResetToken(t, emails), Password: "a-new-long-password"}, nil))
+}
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doRateLimited executa um GET com o token informado e retorna a resposta, para os testes
+// inspecionarem os headers do rate limiting
+func doRateLimited(t *testing.T, testApp *TestApp, path, bearer string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestRateLimitPerClientAndRoute(t *testing.T) {
+	const groupsEndpoint = "/api/v1/groups"
+
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+		cfg.RateLimitEnabled = true
+		cfg.RateLimitRequests = 3
+		cfg.RateLimitPeriod = time.Minute
+		cfg.RateLimitRoutes = []config.RateLimitRule{{Method: http.MethodGet, Path: "/api/v1/users", Requests: 2, Period: time.Minute}}
+	})
+	defer testApp.Cleanup(t)
+
+	aliceToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	bobToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-2", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// A busca de usuários tem a cota própria de 2 requisições por minuto
+	resp := doRateLimited(t, testApp, usersEndpoint, aliceToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", resp.Header.Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", resp.Header.Get("RateLimit-Policy"))
+
+	require.Equal(t, http.StatusOK, doRateLimited(t, testApp, usersEndpoint, aliceToken).StatusCode)
+	resp = doRateLimited(t, testApp, usersEndpoint, aliceToken)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+
+	// Os outros clientes e as outras rotas têm baldes próprios
+	assert.Equal(t, http.StatusOK, doRateLimited(t, testApp, usersEndpoint, bobToken).StatusCode)
+	resp = doRateLimited(t, testApp, groupsEndpoint, aliceToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "3", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "2", resp.Header.Get("RateLimit-Remaining"))
+
+	// As fichas voltam aos poucos: uma a cada 30 segundos
+	testApp.Clock.Advance(30 * time.Second)
+	assert.Equal(t, http.StatusOK, doRateLimited(t, testApp, usersEndpoint, aliceToken).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimited(t, testApp, usersEndpoint, aliceToken).StatusCode)
+}
+
+func TestRateLimitPublicRoutesByIP(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+		cfg.RateLimitEnabled = true
+		cfg.RateLimitRequests = 3
+		cfg.RateLimitPeriod = time.Minute
+	})
+	defer testApp.Cleanup(t)
+
+	credentials := dto.LoginRequestDTO{Email: "nobody@example.com", Password: sessionPassword}
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodPost, loginEndpoint, "", credentials, nil))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doJSON(t, testApp, http.MethodPost, loginEndpoint, "", credentials, nil))
+
+	testApp.Clock.Advance(time.Minute)
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodPost, loginEndpoint, "", credentials, nil))
+}
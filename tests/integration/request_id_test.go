@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+func TestRequestIDIsGeneratedWhenMissing(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	req, err := http.NewRequest("GET", usersEndpoint, nil)
+	require.NoError(t, err)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(requestIDHeader))
+}
+
+func TestRequestIDIsEchoedWhenProvided(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	req, err := http.NewRequest("GET", usersEndpoint, nil)
+	require.NoError(t, err)
+	req.Header.Set(requestIDHeader, "client-request-123")
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, "client-request-123", resp.Header.Get(requestIDHeader))
+}
+
+func TestRequestIDRejectsInvalidValues(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	invalidID := strings.Repeat("a", 200)
+	req, err := http.NewRequest("GET", usersEndpoint, nil)
+	require.NoError(t, err)
+	req.Header.Set(requestIDHeader, invalidID)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	requestID := resp.Header.Get(requestIDHeader)
+	assert.NotEmpty(t, requestID)
+	assert.NotEqual(t, invalidID, requestID)
+}
+
+func TestRequestIDInErrorBody(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(usersEndpointFmt, "507f1f77bcf86cd799439011"), nil)
+	require.NoError(t, err)
+	req.Header.Set(requestIDHeader, "error-request-456")
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	var errorResponse map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+	assert.Equal(t, userNotFoundMsg, errorResponse[errorKeyName])
+	assert.Equal(t, "error-request-456", errorResponse["request_id"])
+}
+
+func TestAccessLogCarriesRequestID(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	var logEntries []*logrus.Entry
+	testApp.Log.AddHook(&testLogHook{entries: &logEntries})
+
+	req, err := http.NewRequest("GET", usersEndpoint, nil)
+	require.NoError(t, err)
+	req.Header.Set(requestIDHeader, "access-log-789")
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var accessEntry *logrus.Entry
+	for _, entry := range logEntries {
+		if entry.Message == "Request completed" {
+			accessEntry = entry
+		}
+	}
+	require.NotNil(t, accessEntry, "expected an access log entry")
+	assert.Equal(t, "access-log-789", accessEntry.Data["request_id"])
+	assert.Equal(t, "GET", accessEntry.Data["method"])
+	assert.Equal(t, 200, accessEntry.Data["status"])
+	assert.NotEmpty(t, accessEntry.Data["latency"])
+}
@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"user-management/internal/application/tenancy"
+	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/user"
+	"user-management/internal/domain/entities"
+	domainrepos "user-management/internal/domain/interfaces/repositories"
+	"user-management/internal/infrastructure/mail"
+	"user-management/internal/infrastructure/repositories"
+	"user-management/internal/infrastructure/seed"
+	"user-management/internal/infrastructure/web/validators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestSeedGeneratorIsReproducible(t *testing.T) {
+	first := seed.NewGenerator(42)
+	second := seed.NewGenerator(42)
+	other := seed.NewGenerator(7)
+
+	validator := validators.NewInputValidator()
+	emails := make(map[string]bool)
+	differs := false
+	for i := 0; i < 200; i++ {
+		u1, u2, u3 := first.User(), second.User(), other.User()
+		assert.Equal(t, u1, u2)
+		if u1 != u3 {
+			differs = true
+		}
+
+		// Os dados gerados passam pelas mesmas regras de validação da API
+		require.NoError(t, validator.ValidateStruct(&u1), u1.Email)
+		assert.False(t, emails[u1.Email], "duplicate email %s", u1.Email)
+		emails[u1.Email] = true
+	}
+	assert.True(t, differs, "different seeds should generate different data")
+
+	assert.Equal(t, first.GroupName(), second.GroupName())
+}
+
+func TestSeedCreatesUsersGroupsAndMemberships(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := tenancy.WithTenant(context.Background(), tenancy.DefaultTenant)
+
+	_, err := testApp.Migrator.Up(ctx)
+	require.NoError(t, err)
+
+	userRepo, err := repositories.NewUserRepository(testApp.DB)
+	require.NoError(t, err)
+	groupRepo, err := repositories.NewGroupRepository(testApp.DB)
+	require.NoError(t, err)
+	invitationRepo, err := repositories.NewInvitationRepository(testApp.DB)
+	require.NoError(t, err)
+	mailer, err := mail.NewMailer(testApp.Config)
+	require.NoError(t, err)
+	seeder := seed.NewSeeder(
+		user.NewCreateUserUseCase(testApp.Config, userRepo, invitationRepo, mailer, testApp.Clock),
+		group.NewCreateGroupUseCase(groupRepo, testApp.Clock),
+		group.NewAddUserToGroupUseCase(groupRepo, userRepo, testApp.Clock),
+	)
+
+	opts := seed.Options{Users: 30, Groups: 5, MaxMembers: 10, Seed: 42}
+	result, err := seeder.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 30, result.UsersCreated)
+	assert.Equal(t, 5, result.Groups)
+
+	users, err := testApp.DB.DB.Collection("users").CountDocuments(ctx, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), users)
+	assert.Empty(t, sentEmails(t, testApp), "seeded users must not be invited")
+
+	groups, err := groupRepo.List(ctx, 0, 100, domainrepos.ListFilter{})
+	require.NoError(t, err)
+	require.Len(t, groups, 5)
+	memberships := 0
+	for _, g := range groups {
+		assert.LessOrEqual(t, len(g.Members), 10)
+		memberships += len(g.Members)
+		for i, member := range g.Members {
+			_, err := userRepo.GetByID(ctx, member.UserID)
+			assert.NoError(t, err, member.UserID)
+			// O primeiro membro de cada grupo é o owner
+			if i == 0 {
+				assert.Equal(t, entities.GroupRoleOwner, member.Role)
+			}
+		}
+	}
+	assert.Equal(t, result.Memberships, memberships)
+
+	// Repetir a mesma seed não duplica usuários: os emails já existem
+	result, err = seeder.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.UsersCreated)
+	assert.Equal(t, 30, result.UsersSkipped)
+}
@@ -0,0 +1,191 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	loginEndpoint   = "/api/v1/auth/login"
+	refreshEndpoint = "/api/v1/auth/refresh"
+	sessionPassword = "correct horse battery"
+)
+
+// createUserWithPassword cria o usuário pelo convite, aceito com sessionPassword
+func createUserWithPassword(t *testing.T, testApp *TestApp, adminToken, email string) dto.UserResponseDTO {
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, adminToken, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: email,
+	}, &user))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, acceptInvitationPath(lastInvitationToken(t, testApp)), "",
+		dto.AcceptInvitationRequestDTO{Password: sessionPassword}, nil))
+	return user
+}
+
+func login(t *testing.T, testApp *TestApp, email string) dto.SessionTokenResponseDTO {
+	var tokens dto.SessionTokenResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, loginEndpoint, "",
+		dto.LoginRequestDTO{Email: email, Password: sessionPassword}, &tokens))
+	return tokens
+}
+
+func refresh(t *testing.T, testApp *TestApp, refreshToken string, out *dto.SessionTokenResponseDTO) int {
+	return doJSON(t, testApp, http.MethodPost, refreshEndpoint, "", dto.RefreshSessionRequestDTO{RefreshToken: refreshToken}, out)
+}
+
+func TestSessionLoginRefreshAndReuseDetection(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user := createUserWithPassword(t, testApp, adminToken, "jane@example.com")
+	userPath := usersEndpoint + "/" + user.ID
+
+	// Email desconhecido e senha errada têm a mesma resposta
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodPost, loginEndpoint, "",
+		dto.LoginRequestDTO{Email: "nobody@example.com", Password: sessionPassword}, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodPost, loginEndpoint, "",
+		dto.LoginRequestDTO{Email: "jane@example.com", Password: "wrong password"}, nil))
+
+	tokens := login(t, testApp, "jane@example.com")
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.True(t, tokens.RefreshTokenExpiresAt.Equal(testApp.Clock.Now().Add(testApp.Config.SessionTTL)))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+
+	var sessions dto.ListSessionsResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath+"/sessions", tokens.AccessToken, nil, &sessions))
+	require.Len(t, sessions.Data, 1)
+	assert.Equal(t, tokens.SessionID, sessions.Data[0].ID)
+	assert.True(t, sessions.Data[0].Current)
+	assert.NotEmpty(t, sessions.Data[0].IP)
+
+	// A renovação troca o refresh token e mantém a sessão
+	testApp.Clock.Advance(time.Minute)
+	var rotated dto.SessionTokenResponseDTO
+	require.Equal(t, http.StatusOK, refresh(t, testApp, tokens.RefreshToken, &rotated))
+	assert.Equal(t, tokens.SessionID, rotated.SessionID)
+	assert.NotEqual(t, tokens.RefreshToken, rotated.RefreshToken)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath+"/sessions", rotated.AccessToken, nil, &sessions))
+	require.Len(t, sessions.Data, 1)
+	assert.True(t, testApp.Clock.Now().Equal(sessions.Data[0].LastUsedAt))
+
+	// Reapresentar um refresh token já usado revoga a sessão inteira
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, tokens.RefreshToken, nil))
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, rotated.RefreshToken, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, rotated.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, "unknown", nil))
+}
+
+func TestSessionListAndRevoke(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user := createUserWithPassword(t, testApp, adminToken, "jane@example.com")
+	other := createUserWithPassword(t, testApp, adminToken, "john@example.com")
+	sessionsPath := usersEndpoint + "/" + user.ID + "/sessions"
+
+	laptop := login(t, testApp, "jane@example.com")
+	phone := login(t, testApp, "jane@example.com")
+	var sessions dto.ListSessionsResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, sessionsPath, adminToken, nil, &sessions))
+	assert.Len(t, sessions.Data, 2)
+
+	// Só o próprio usuário e os administradores veem e revogam as sessões
+	otherTokens := login(t, testApp, "john@example.com")
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, sessionsPath, otherTokens.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodDelete, sessionsPath+"/"+phone.SessionID, otherTokens.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodDelete, usersEndpoint+"/"+other.ID+"/sessions/"+phone.SessionID, adminToken, nil, nil))
+
+	// Revogar uma sessão derruba o access token e o refresh token dela, e só dela
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, sessionsPath+"/"+phone.SessionID, laptop.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodDelete, sessionsPath+"/"+phone.SessionID, laptop.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, sessionsPath, phone.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, phone.RefreshToken, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, sessionsPath, laptop.AccessToken, nil, &sessions))
+	require.Len(t, sessions.Data, 1)
+	assert.Equal(t, laptop.SessionID, sessions.Data[0].ID)
+
+	var revoked dto.RevokeSessionsResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodDelete, sessionsPath, adminToken, nil, &revoked))
+	assert.Equal(t, int64(1), revoked.Revoked)
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, sessionsPath, laptop.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+other.ID, otherTokens.AccessToken, nil, nil))
+
+	// As sessões expiram depois de SESSION_TTL
+	expiring := login(t, testApp, "jane@example.com")
+	testApp.Clock.Advance(testApp.Config.SessionTTL)
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, expiring.RefreshToken, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, sessionsPath, expiring.AccessToken, nil, nil))
+}
+
+func TestSessionsRevokedWhenUserIsSuspendedOrDeleted(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user := createUserWithPassword(t, testApp, adminToken, "jane@example.com")
+	userPath := usersEndpoint + "/" + user.ID
+
+	// Os tokens sem sessão usam o horário real; o relógio dos testes, que começa em 2024, é
+	// adiantado para que a revogação venha depois da emissão
+	statelessToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: user.ID, Type: auth.PrincipalUser})
+	require.NoError(t, err)
+	testApp.Clock.Advance(time.Since(testApp.Clock.Now()) + time.Minute)
+	tokens := login(t, testApp, "jane@example.com")
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, statelessToken, nil, nil))
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/suspend", adminToken,
+		dto.ChangeUserStatusRequestDTO{Reason: "security review"}, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, statelessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, tokens.RefreshToken, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodPost, loginEndpoint, "",
+		dto.LoginRequestDTO{Email: "jane@example.com", Password: sessionPassword}, nil))
+
+	// Reativado, o usuário precisa de um novo login
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/activate", adminToken,
+		dto.ChangeUserStatusRequestDTO{}, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+	tokens = login(t, testApp, "jane@example.com")
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+
+	// Remover o usuário revoga as sessões, que continuam revogadas se ele for restaurado
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, userPath, adminToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/restore", adminToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, refresh(t, testApp, tokens.RefreshToken, nil))
+}
+
+func TestSessionKeepsMFAVerificationAcrossRefresh(t *testing.T) {
+	testApp := setupOIDCApp(t)
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	user := createUserWithPassword(t, testApp, adminToken, "jane@example.com")
+	userPath := usersEndpoint + "/" + user.ID
+
+	tokens := login(t, testApp, "jane@example.com")
+	_, confirmed := enrollMFA(t, testApp, tokens.AccessToken)
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, userPath, tokens.AccessToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, confirmed.AccessToken, nil, nil))
+
+	// O segundo fator confirmado fica na sessão; um novo login precisa confirmá-lo de novo
+	var rotated dto.SessionTokenResponseDTO
+	require.Equal(t, http.StatusOK, refresh(t, testApp, tokens.RefreshToken, &rotated))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, rotated.AccessToken, nil, nil))
+	fresh := login(t, testApp, "jane@example.com")
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, userPath, fresh.AccessToken, nil, nil))
+}
@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/application/tenancy"
+	"user-management/internal/config"
+	"user-management/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const testTokenSecret = "integration-test-secret-with-32-chars!"
+
+// doJSON executa a requisição com body JSON opcional e decodifica a resposta em out (se informado)
+func doJSON(t *testing.T, testApp *TestApp, method, path, bearer string, body, out interface{}) int {
+	return doTenantJSON(t, testApp, method, path, bearer, "", body, out)
+}
+
+// doTenantJSON é o doJSON com o cabeçalho X-Tenant-ID (omitido se tenantID for vazio)
+func doTenantJSON(t *testing.T, testApp *TestApp, method, path, bearer, tenantID string, body, out interface{}) int {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewBuffer(payload)
+	}
+
+	req, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	if tenantID != "" {
+		req.Header.Set(tenancy.Header, tenantID)
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode < 300 {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestSoftDeleteUser(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	var created dto.UserResponseDTO
+	status := doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+		Name: "Soft Delete", Email: "soft@example.com", Status: "active",
+	}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	userPath := fmt.Sprintf(usersEndpointFmt, created.ID)
+
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, userPath, "", nil, nil))
+	// Remover de novo continua idempotente
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, userPath, "", nil, nil))
+
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, userPath, "", nil, nil))
+
+	var list dto.UserListResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint, "", nil, &list))
+	assert.Empty(t, list.Data)
+	assert.Equal(t, int64(0), list.Meta.Total)
+
+	list = dto.UserListResponseDTO{}
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint+"?include_deleted=true", "", nil, &list))
+	require.Len(t, list.Data, 1)
+	require.NotNil(t, list.Data[0].DeletedAt)
+	assert.Equal(t, auth.AnonymousID, list.Data[0].DeletedBy)
+
+	var deleted dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath+"?include_deleted=true", "", nil, &deleted))
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// A atualização não alcança usuários removidos
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPut, userPath, "", dto.UpdateUserRequestDTO{
+		Name: "Updated", Email: "soft@example.com",
+	}, nil))
+
+	var restored dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/restore", "", nil, &restored))
+	assert.Nil(t, restored.DeletedAt)
+	assert.Empty(t, restored.DeletedBy)
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, "", nil, nil))
+
+	// Restaurar um usuário que não está removido retorna 404
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, userPath+"/restore", "", nil, nil))
+}
+
+func TestSoftDeleteGroup(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	var created dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{Name: "Team"}, &created))
+	groupPath := groupsEndpoint + "/" + created.ID
+
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, groupPath, "", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, groupPath, "", nil, nil))
+
+	var list dto.ListGroupResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"?include_deleted=true", "", nil, &list))
+	require.Len(t, list.Data, 1)
+	assert.NotNil(t, list.Data[0].DeletedAt)
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, groupPath+"/restore", "", nil, nil))
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupPath, "", nil, nil))
+}
+
+func TestSoftDeleteRequiresAdmin(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "user-1", Type: auth.PrincipalUser})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnauthorized, doJSON(t, testApp, http.MethodGet, usersEndpoint, "", nil, nil))
+
+	var created dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, userToken, dto.CreateUserRequestDTO{
+		Name: "Audited", Email: "audited@example.com",
+	}, &created))
+	userPath := fmt.Sprintf(usersEndpointFmt, created.ID)
+	require.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, userPath, userToken, nil, nil))
+
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, usersEndpoint+"?include_deleted=true", userToken, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, userPath+"?include_deleted=true", userToken, nil, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, userPath+"/restore", userToken, nil, nil))
+
+	var deleted dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath+"?include_deleted=true", adminToken, nil, &deleted))
+	assert.Equal(t, "user-1", deleted.DeletedBy)
+	assert.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/restore", adminToken, nil, nil))
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+	ctx := context.Background()
+
+	users := testApp.DB.DB.Collection("users")
+	groups := testApp.DB.DB.Collection("groups")
+
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	recent := time.Now().UTC()
+	expired, kept, active := bson.NewObjectID(), bson.NewObjectID(), bson.NewObjectID()
+	_, err := users.InsertMany(ctx, []interface{}{
+		bson.M{"_id": expired, "name": "Expired", "email": "expired@example.com", "status": "active", "deleted_at": old, "deleted_by": "admin"},
+		bson.M{"_id": kept, "name": "Kept", "email": "kept@example.com", "status": "active", "deleted_at": recent, "deleted_by": "admin"},
+		bson.M{"_id": active, "name": "Active", "email": "active@example.com", "status": "active"},
+	})
+	require.NoError(t, err)
+
+	team, expiredGroup := bson.NewObjectID(), bson.NewObjectID()
+	_, err = groups.InsertMany(ctx, []interface{}{
+		bson.M{"_id": team, "name": "Team", "members": bson.A{
+			memberDoc(expired.Hex(), "owner"), memberDoc(kept.Hex(), "member"), memberDoc(active.Hex(), "member"),
+		}, "subgroups": bson.A{expiredGroup}},
+		bson.M{"_id": expiredGroup, "name": "Old", "members": bson.A{}, "deleted_at": old},
+	})
+	require.NoError(t, err)
+
+	result, err := testApp.Purge.Execute(ctx, time.Now().UTC().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.UsersPurged)
+	assert.Equal(t, 1, result.GroupsPurged)
+
+	count, err := users.CountDocuments(ctx, bson.M{"_id": expired})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = users.CountDocuments(ctx, bson.M{"_id": kept})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = groups.CountDocuments(ctx, bson.M{"_id": expiredGroup})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// O usuário e o subgrupo apagados definitivamente saem dos grupos; os demais continuam
+	var group entities.Group
+	require.NoError(t, groups.FindOne(ctx, bson.M{"_id": team}).Decode(&group))
+	assert.ElementsMatch(t, []string{kept.Hex(), active.Hex()}, group.MemberIDs())
+	assert.Empty(t, group.Subgroups)
+}
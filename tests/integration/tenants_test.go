@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenantsEndpoint = "/api/v1/tenants"
+
+func TestTenantAdminAPI(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	platformToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "root", Type: auth.PrincipalUser, PlatformAdmin: true})
+	require.NoError(t, err)
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// Administradores de um tenant não gerenciam tenants
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, adminToken, dto.CreateTenantRequestDTO{Slug: "acme", Name: "Acme"}, nil))
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodGet, tenantsEndpoint, adminToken, nil, nil))
+
+	var created dto.TenantResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: "acme", Name: "Acme"}, &created))
+	assert.Equal(t, "acme", created.Slug)
+	assert.Equal(t, "root", created.CreatedBy)
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: "acme", Name: "Acme 2"}, nil))
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: "Not_A_Slug", Name: "Invalid"}, nil))
+
+	var updated dto.TenantResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, tenantsEndpoint+"/acme", platformToken, dto.UpdateTenantRequestDTO{Name: "Acme Corp"}, &updated))
+	assert.Equal(t, "Acme Corp", updated.Name)
+
+	var fetched dto.TenantResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, tenantsEndpoint+"/acme", platformToken, nil, &fetched))
+	assert.Equal(t, "Acme Corp", fetched.Name)
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, tenantsEndpoint+"/missing", platformToken, nil, nil))
+
+	var list dto.ListTenantResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, tenantsEndpoint, platformToken, nil, &list))
+	assert.Equal(t, int64(1), list.Meta.Total)
+
+	// O tenant padrão não pode ser removido; um tenant vazio pode
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, tenantsEndpoint+"/default", platformToken, nil, nil))
+	assert.Equal(t, http.StatusNoContent, doJSON(t, testApp, http.MethodDelete, tenantsEndpoint+"/acme", platformToken, nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodDelete, tenantsEndpoint+"/acme", platformToken, nil, nil))
+}
+
+func TestTenantIsolation(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.TenantBaseDomain = "example.com"
+	})
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	// As migrations criam o índice único de email por tenant
+	_, err := testApp.Migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	// Com a autenticação desativada o chamador é administrador da plataforma e escolhe o tenant
+	for _, slug := range []string{"acme", "globex"} {
+		require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, "", dto.CreateTenantRequestDTO{Slug: slug, Name: slug}, nil))
+	}
+	createUser := func(tenantID, email string) dto.UserResponseDTO {
+		var created dto.UserResponseDTO
+		require.Equal(t, http.StatusCreated, doTenantJSON(t, testApp, http.MethodPost, usersEndpoint, "", tenantID, dto.CreateUserRequestDTO{
+			Name: "John", Email: email,
+		}, &created))
+		return created
+	}
+
+	// O email é único por tenant
+	acmeUser := createUser("acme", "john@example.com")
+	globexUser := createUser("globex", "john@example.com")
+	assert.Equal(t, "acme", acmeUser.TenantID)
+	assert.Equal(t, "globex", globexUser.TenantID)
+	assert.Equal(t, http.StatusConflict, doTenantJSON(t, testApp, http.MethodPost, usersEndpoint, "", "acme", dto.CreateUserRequestDTO{
+		Name: "John", Email: "john@example.com",
+	}, nil))
+
+	// Listas e buscas só enxergam o próprio tenant
+	var list dto.UserListResponseDTO
+	require.Equal(t, http.StatusOK, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint, "", "acme", nil, &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, acmeUser.ID, list.Data[0].ID)
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint, "", nil, &list))
+	assert.Empty(t, list.Data)
+	assert.Equal(t, http.StatusNotFound, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+globexUser.ID, "", "acme", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doTenantJSON(t, testApp, http.MethodDelete, usersEndpoint+"/"+globexUser.ID, "", "acme", nil, nil))
+	assert.Equal(t, http.StatusOK, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+globexUser.ID, "", "globex", nil, nil))
+
+	// Grupos não referenciam usuários nem subgrupos de outro tenant
+	var acmeGroup, globexGroup dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doTenantJSON(t, testApp, http.MethodPost, groupsEndpoint, "", "acme", dto.CreateGroupRequestDTO{Name: "Team"}, &acmeGroup))
+	require.Equal(t, http.StatusCreated, doTenantJSON(t, testApp, http.MethodPost, groupsEndpoint, "", "globex", dto.CreateGroupRequestDTO{Name: "Team"}, &globexGroup))
+	membersPath := groupsEndpoint + "/" + acmeGroup.ID + "/members"
+	assert.Equal(t, http.StatusBadRequest, doTenantJSON(t, testApp, http.MethodPost, membersPath, "", "acme", dto.AddGroupMembersRequestDTO{UserIDs: []string{globexUser.ID}}, nil))
+	assert.Equal(t, http.StatusOK, doTenantJSON(t, testApp, http.MethodPost, membersPath, "", "acme", dto.AddGroupMembersRequestDTO{UserIDs: []string{acmeUser.ID}}, nil))
+	assert.Equal(t, http.StatusNotFound, doTenantJSON(t, testApp, http.MethodPost, groupsEndpoint+"/"+acmeGroup.ID+"/subgroups/"+globexGroup.ID, "", "acme", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doTenantJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+acmeGroup.ID, "", "globex", nil, nil))
+
+	// O subdomínio de TENANT_BASE_DOMAIN também seleciona o tenant
+	req, err := http.NewRequest(http.MethodGet, usersEndpoint+"/"+acmeUser.ID, nil)
+	require.NoError(t, err)
+	req.Host = "acme.example.com"
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Tenants desconhecidos não são aceitos
+	assert.Equal(t, http.StatusNotFound, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint, "", "initech", nil, nil))
+
+	// Um tenant com dados não pode ser removido
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodDelete, tenantsEndpoint+"/acme", "", nil, nil))
+}
+
+func TestTenantBoundByTokenClaim(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	platformToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "root", Type: auth.PrincipalUser, PlatformAdmin: true})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: "acme", Name: "Acme"}, nil))
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, tenantsEndpoint, platformToken, dto.CreateTenantRequestDTO{Slug: "globex", Name: "Globex"}, nil))
+
+	acmeAdmin, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-acme", Type: auth.PrincipalUser, TenantID: "acme", Admin: true})
+	require.NoError(t, err)
+	defaultAdmin, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-default", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	// Sem cabeçalho o tenant vem da claim do token
+	var created dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, acmeAdmin, dto.CreateUserRequestDTO{
+		Name: "Jane", Email: "jane@example.com",
+	}, &created))
+	assert.Equal(t, "acme", created.TenantID)
+
+	// Pedir outro tenant que não o do token é proibido, mesmo para administradores do tenant
+	assert.Equal(t, http.StatusForbidden, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint, acmeAdmin, "globex", nil, nil))
+	assert.Equal(t, http.StatusForbidden, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+created.ID, defaultAdmin, "acme", nil, nil))
+	assert.Equal(t, http.StatusOK, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+created.ID, acmeAdmin, "acme", nil, nil))
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodGet, usersEndpoint+"/"+created.ID, defaultAdmin, nil, nil))
+
+	var list dto.UserListResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, usersEndpoint, defaultAdmin, nil, &list))
+	assert.Empty(t, list.Data)
+
+	// O administrador da plataforma acessa qualquer tenant pelo cabeçalho
+	require.Equal(t, http.StatusOK, doTenantJSON(t, testApp, http.MethodGet, usersEndpoint, platformToken, "acme", nil, &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, created.ID, list.Data[0].ID)
+}
@@ -3,31 +3,64 @@ package integration
 import (
 	"context"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
+	"user-management/internal/application/usecases/apikey"
 	"user-management/internal/application/usecases/group"
+	"user-management/internal/application/usecases/maintenance"
+	"user-management/internal/application/usecases/mfa"
+	"user-management/internal/application/usecases/oidc"
+	"user-management/internal/application/usecases/passwordreset"
+	"user-management/internal/application/usecases/session"
+	"user-management/internal/application/usecases/tenant"
 	"user-management/internal/application/usecases/user"
 	"user-management/internal/config"
 	"user-management/internal/infrastructure/database"
+	"user-management/internal/infrastructure/database/migrations"
+	"user-management/internal/infrastructure/health"
+	"user-management/internal/infrastructure/jobs"
 	"user-management/internal/infrastructure/logger"
+	"user-management/internal/infrastructure/mail"
+	"user-management/internal/infrastructure/password"
+	"user-management/internal/infrastructure/ratelimit"
 	"user-management/internal/infrastructure/repositories"
+	"user-management/internal/infrastructure/signing"
+	"user-management/internal/infrastructure/token"
 	"user-management/internal/infrastructure/web/controllers"
+	"user-management/internal/infrastructure/web/middleware"
 	"user-management/internal/infrastructure/web/routes"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mongodb"
 )
 
+// testKeyEncryptionKey cifra as chaves de assinatura OIDC nos testes (32 bytes em base64)
+const testKeyEncryptionKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
 type TestApp struct {
 	App       *fiber.App
+	Config    *config.Config
 	DB        *database.MongoDB
+	Log       *logrus.Logger
+	Health    *health.Service
+	Migrator  *migrations.Migrator
+	Tokens    *token.Service
+	Keys      *signing.KeyManager
+	Clock     *FakeClock
+	Purge     *maintenance.PurgeDeletedUseCase
+	Resets    *jobs.PasswordResetQueue
+	MailDir   string // emails enviados, gravados como .eml pelo FileMailer
 	Container testcontainers.Container
 }
 
-func SetupTestApp(t *testing.T) *TestApp {
+// SetupTestApp sobe um MongoDB em container e monta a aplicação completa.
+// As funções configure permitem ajustar a configuração antes da montagem (ex.: habilitar autenticação)
+func SetupTestApp(t *testing.T, configure ...func(*config.Config)) *TestApp {
 	ctx := context.Background()
 
 	// Start MongoDB container
@@ -40,14 +73,46 @@ func SetupTestApp(t *testing.T) *TestApp {
 
 	// Setup config with test values
 	cfg := &config.Config{
-		MongoURI:     connectionString,
-		MongoDB:      "testdb",
-		Port:         "8080",
-		DatabaseType: "mongodb",
+		MongoURI:        connectionString,
+		MongoDB:         "testdb",
+		Port:            "8080",
+		DatabaseType:    "mongodb",
+		AuthTokenIssuer: "user-management",
+		AuthTokenTTL:    time.Minute,
+		SessionTTL:      720 * time.Hour,
+
+		// Rate limiting desligado; os testes que o exercitam habilitam com configure
+		RateLimitRequests: 300,
+		RateLimitPeriod:   time.Minute,
+		RateLimitStore:    "memory",
+
+		IdempotencyTTL: 24 * time.Hour,
+
+		OIDCIssuer:              "http://localhost:8080",
+		OIDCCodeTTL:             time.Minute,
+		OIDCTokenTTL:            time.Hour,
+		OIDCKeyRotationInterval: 24 * time.Hour,
+		OIDCKeyEncryptionKey:    testKeyEncryptionKey,
+
+		MFAIssuer: "User Management",
+
+		InvitationTTL: 72 * time.Hour,
+		InvitationURL: "http://localhost:3000/invitations/{token}",
+
+		PasswordResetTTL:         time.Hour,
+		PasswordResetURL:         "http://localhost:3000/password-reset/{token}",
+		PasswordResetMaxRequests: 3,
+
+		Mailer:        "file",
+		MailFrom:      "User Management <no-reply@example.com>",
+		MailerFileDir: t.TempDir(),
+	}
+	for _, fn := range configure {
+		fn(cfg)
 	}
 
 	// Initialize logger
-	log := logger.NewLogger()
+	log := logger.NewLogger(cfg)
 
 	// Initialize database
 	db, err := database.NewMongoDB(cfg, log)
@@ -58,21 +123,112 @@ func SetupTestApp(t *testing.T) *TestApp {
 	require.NoError(t, err)
 	groupRepo, err := repositories.NewGroupRepository(db)
 	require.NoError(t, err)
+	tenantRepo, err := repositories.NewTenantRepository(db)
+	require.NoError(t, err)
+	apiKeyRepo, err := repositories.NewAPIKeyRepository(db)
+	require.NoError(t, err)
+	oauthClientRepo, err := repositories.NewOAuthClientRepository(db)
+	require.NoError(t, err)
+	authorizationCodeRepo, err := repositories.NewAuthorizationCodeRepository(db)
+	require.NoError(t, err)
+	signingKeyRepo, err := repositories.NewSigningKeyRepository(db)
+	require.NoError(t, err)
+	mfaEnrollmentRepo, err := repositories.NewMFAEnrollmentRepository(db)
+	require.NoError(t, err)
+	invitationRepo, err := repositories.NewInvitationRepository(db)
+	require.NoError(t, err)
+	passwordResetRepo, err := repositories.NewPasswordResetRepository(db)
+	require.NoError(t, err)
+	auditEventRepo, err := repositories.NewAuditEventRepository(db)
+	require.NoError(t, err)
+	sessionRepo, err := repositories.NewSessionRepository(db)
+	require.NoError(t, err)
+	refreshTokenRepo, err := repositories.NewRefreshTokenRepository(db)
+	require.NoError(t, err)
+	idempotencyKeyRepo, err := repositories.NewIdempotencyKeyRepository(db)
+	require.NoError(t, err)
+
+	mailer, err := mail.NewMailer(cfg)
+	require.NoError(t, err)
+	hasher := password.NewBcryptHasher()
+	rateLimitStore, err := ratelimit.NewStore(cfg)
+	require.NoError(t, err)
+
+	// Relógio controlado pelos testes para os campos de auditoria
+	testClock := NewFakeClock(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC))
 
 	// Initialize use cases
-	createUserUseCase := user.NewCreateUserUseCase(userRepo)
+	createUserUseCase := user.NewCreateUserUseCase(cfg, userRepo, invitationRepo, mailer, testClock)
 	getUserUseCase := user.NewGetUserUseCase(userRepo)
-	updateUserUseCase := user.NewUpdateUserUseCase(userRepo)
-	deleteUserUseCase := user.NewDeleteUserUseCase(userRepo)
+	updateUserUseCase := user.NewUpdateUserUseCase(userRepo, testClock)
+	deleteUserUseCase := user.NewDeleteUserUseCase(userRepo, sessionRepo, testClock)
 	listUsersUseCase := user.NewListUsersUseCase(userRepo)
+	restoreUserUseCase := user.NewRestoreUserUseCase(userRepo, testClock)
+	changeUserStatusUseCase := user.NewChangeUserStatusUseCase(userRepo, groupRepo, sessionRepo, testClock)
+	listEffectiveGroupsUseCase := user.NewListEffectiveGroupsUseCase(userRepo, groupRepo)
+	listUserGroupsUseCase := user.NewListUserGroupsUseCase(userRepo, groupRepo)
+	resendInvitationUseCase := user.NewResendInvitationUseCase(cfg, userRepo, invitationRepo, mailer, testClock)
+	acceptInvitationUseCase := user.NewAcceptInvitationUseCase(cfg, userRepo, invitationRepo, hasher, testClock)
+	requestPasswordResetUseCase := passwordreset.NewRequestUseCase(cfg, userRepo, passwordResetRepo, mailer, testClock)
+	confirmPasswordResetUseCase := passwordreset.NewConfirmUseCase(userRepo, passwordResetRepo, sessionRepo, auditEventRepo, hasher, testClock)
 
-	createGroupUseCase := group.NewCreateGroupUseCase(groupRepo)
+	createGroupUseCase := group.NewCreateGroupUseCase(groupRepo, testClock)
 	getGroupUseCase := group.NewGetGroupUseCase(groupRepo)
-	updateGroupUseCase := group.NewUpdateGroupUseCase(groupRepo)
-	deleteGroupUseCase := group.NewDeleteGroupUseCase(groupRepo)
+	updateGroupUseCase := group.NewUpdateGroupUseCase(groupRepo, testClock)
+	deleteGroupUseCase := group.NewDeleteGroupUseCase(groupRepo, testClock)
 	listGroupsUseCase := group.NewListGroupsUseCase(groupRepo)
-	addUserToGroupUseCase := group.NewAddUserToGroupUseCase(groupRepo, userRepo)
-	removeUserFromGroupUseCase := group.NewRemoveUserFromGroupUseCase(groupRepo)
+	addUserToGroupUseCase := group.NewAddUserToGroupUseCase(groupRepo, userRepo, testClock)
+	removeUserFromGroupUseCase := group.NewRemoveUserFromGroupUseCase(groupRepo, testClock)
+	restoreGroupUseCase := group.NewRestoreGroupUseCase(groupRepo, testClock)
+	addSubgroupUseCase := group.NewAddSubgroupUseCase(groupRepo, testClock)
+	removeSubgroupUseCase := group.NewRemoveSubgroupUseCase(groupRepo, testClock)
+	listEffectiveMembersUseCase := group.NewListEffectiveMembersUseCase(groupRepo)
+	updateMemberRoleUseCase := group.NewUpdateMemberRoleUseCase(groupRepo, testClock)
+	listGroupMembersUseCase := group.NewListGroupMembersUseCase(groupRepo)
+	addGroupMembersUseCase := group.NewAddGroupMembersUseCase(groupRepo, userRepo, testClock)
+	removeGroupMembersUseCase := group.NewRemoveGroupMembersUseCase(groupRepo, testClock)
+	replaceGroupMembersUseCase := group.NewReplaceGroupMembersUseCase(groupRepo, userRepo, testClock)
+	setGroupMFAPolicyUseCase := group.NewSetGroupMFAPolicyUseCase(groupRepo, testClock)
+	purgeDeletedUseCase := maintenance.NewPurgeDeletedUseCase(userRepo, groupRepo, mfaEnrollmentRepo)
+
+	createTenantUseCase := tenant.NewCreateTenantUseCase(tenantRepo, testClock)
+	getTenantUseCase := tenant.NewGetTenantUseCase(tenantRepo)
+	listTenantsUseCase := tenant.NewListTenantsUseCase(tenantRepo)
+	updateTenantUseCase := tenant.NewUpdateTenantUseCase(tenantRepo, testClock)
+	deleteTenantUseCase := tenant.NewDeleteTenantUseCase(tenantRepo, userRepo, groupRepo)
+
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(apiKeyRepo, testClock)
+	getAPIKeyUseCase := apikey.NewGetAPIKeyUseCase(apiKeyRepo)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(apiKeyRepo)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(apiKeyRepo, testClock)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(apiKeyRepo, testClock)
+
+	signingKeys, err := signing.NewKeyManager(cfg, signingKeyRepo, testClock)
+	require.NoError(t, err)
+	registerClientUseCase := oidc.NewRegisterClientUseCase(oauthClientRepo, testClock)
+	getClientUseCase := oidc.NewGetClientUseCase(oauthClientRepo)
+	listClientsUseCase := oidc.NewListClientsUseCase(oauthClientRepo)
+	deleteClientUseCase := oidc.NewDeleteClientUseCase(oauthClientRepo)
+	authorizeUseCase := oidc.NewAuthorizeUseCase(cfg, oauthClientRepo, authorizationCodeRepo, userRepo, testClock)
+	exchangeCodeUseCase := oidc.NewExchangeCodeUseCase(cfg, oauthClientRepo, authorizationCodeRepo, userRepo, groupRepo, signingKeys, testClock)
+	userInfoUseCase := oidc.NewUserInfoUseCase(cfg, userRepo, groupRepo, signingKeys)
+
+	tokens := token.NewService(cfg)
+	getMFAStatusUseCase := mfa.NewGetStatusUseCase(mfaEnrollmentRepo, groupRepo)
+	enrollMFAUseCase := mfa.NewEnrollUseCase(cfg, mfaEnrollmentRepo, userRepo, testClock)
+	confirmMFAUseCase := mfa.NewConfirmUseCase(mfaEnrollmentRepo, sessionRepo, tokens, testClock)
+	verifyMFAUseCase := mfa.NewVerifyUseCase(mfaEnrollmentRepo, sessionRepo, tokens, testClock)
+	regenerateRecoveryCodesUseCase := mfa.NewRegenerateRecoveryCodesUseCase(mfaEnrollmentRepo, testClock)
+	disableMFAUseCase := mfa.NewDisableUseCase(mfaEnrollmentRepo, groupRepo, testClock)
+	resetMFAUseCase := mfa.NewResetUseCase(mfaEnrollmentRepo)
+	checkMFAUseCase := mfa.NewCheckUseCase(mfaEnrollmentRepo, groupRepo)
+
+	validateSessionUseCase := session.NewValidateUseCase(userRepo, sessionRepo, testClock)
+	loginUseCase := session.NewLoginUseCase(cfg, userRepo, sessionRepo, refreshTokenRepo, hasher, tokens, testClock)
+	refreshSessionUseCase := session.NewRefreshUseCase(userRepo, sessionRepo, refreshTokenRepo, tokens, testClock)
+	listSessionsUseCase := session.NewListUseCase(userRepo, sessionRepo, testClock)
+	revokeSessionUseCase := session.NewRevokeUseCase(userRepo, sessionRepo, testClock)
+	revokeAllSessionsUseCase := session.NewRevokeAllUseCase(userRepo, sessionRepo, testClock)
 
 	// Initialize controllers
 	userController := controllers.NewUserController(
@@ -81,7 +237,17 @@ func SetupTestApp(t *testing.T) *TestApp {
 		updateUserUseCase,
 		deleteUserUseCase,
 		listUsersUseCase,
+		restoreUserUseCase,
+		changeUserStatusUseCase,
+		listEffectiveGroupsUseCase,
+		listUserGroupsUseCase,
+		resendInvitationUseCase,
 	)
+	invitationController := controllers.NewInvitationController(acceptInvitationUseCase)
+	passwordResetQueue := jobs.NewPasswordResetQueue(requestPasswordResetUseCase, log)
+	passwordResetQueue.Start()
+	passwordResetController := controllers.NewPasswordResetController(passwordResetQueue, confirmPasswordResetUseCase)
+	sessionController := controllers.NewSessionController(loginUseCase, refreshSessionUseCase, listSessionsUseCase, revokeSessionUseCase, revokeAllSessionsUseCase)
 
 	groupController := controllers.NewGroupController(
 		createGroupUseCase,
@@ -91,8 +257,54 @@ func SetupTestApp(t *testing.T) *TestApp {
 		listGroupsUseCase,
 		addUserToGroupUseCase,
 		removeUserFromGroupUseCase,
+		restoreGroupUseCase,
+		addSubgroupUseCase,
+		removeSubgroupUseCase,
+		listEffectiveMembersUseCase,
+		updateMemberRoleUseCase,
+		listGroupMembersUseCase,
+		addGroupMembersUseCase,
+		removeGroupMembersUseCase,
+		replaceGroupMembersUseCase,
+		setGroupMFAPolicyUseCase,
+	)
+
+	tenantController := controllers.NewTenantController(
+		createTenantUseCase,
+		getTenantUseCase,
+		listTenantsUseCase,
+		updateTenantUseCase,
+		deleteTenantUseCase,
+	)
+
+	apiKeyController := controllers.NewAPIKeyController(
+		createAPIKeyUseCase,
+		getAPIKeyUseCase,
+		listAPIKeysUseCase,
+		revokeAPIKeyUseCase,
+	)
+
+	oauthClientController := controllers.NewOAuthClientController(
+		registerClientUseCase,
+		getClientUseCase,
+		listClientsUseCase,
+		deleteClientUseCase,
+	)
+	oidcController := controllers.NewOIDCController(cfg, signingKeys, authorizeUseCase, exchangeCodeUseCase, userInfoUseCase)
+	mfaController := controllers.NewMFAController(
+		getMFAStatusUseCase,
+		enrollMFAUseCase,
+		confirmMFAUseCase,
+		verifyMFAUseCase,
+		regenerateRecoveryCodesUseCase,
+		disableMFAUseCase,
+		resetMFAUseCase,
 	)
 
+	migrator := migrations.NewMigrator(db)
+	healthService := health.NewService(cfg, db, migrator)
+	healthController := controllers.NewHealthController(healthService)
+
 	// Setup Fiber app
 	app := fiber.New(fiber.Config{
 		DisableStartupMessage: true,
@@ -101,23 +313,71 @@ func SetupTestApp(t *testing.T) *TestApp {
 			if e, ok := err.(*fiber.Error); ok {
 				code = e.Code
 			}
-			return c.Status(code).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(code).JSON(fiber.Map{
+				"error":      err.Error(),
+				"request_id": middleware.GetRequestID(c),
+			})
 		},
 	})
 
+	authenticator := middleware.NewAuthenticator(cfg, tokens, authenticateAPIKeyUseCase, validateSessionUseCase)
+	tenantResolver := middleware.NewTenantResolver(cfg, tenantRepo)
+	mfaEnforcer := middleware.NewMFAEnforcer(checkMFAUseCase)
+	rateLimiter := middleware.NewRateLimiter(cfg, rateLimitStore, testClock)
+	idempotency := middleware.NewIdempotency(cfg, idempotencyKeyRepo, testClock)
+
 	// Setup routes
-	routes.SetupRoutes(app, userController, groupController)
+	routes.SetupRoutes(app, log, authenticator, tenantResolver, mfaEnforcer, rateLimiter, idempotency, healthController, userController, groupController, tenantController, apiKeyController, oauthClientController, oidcController, mfaController, invitationController, passwordResetController, sessionController)
 
 	return &TestApp{
 		App:       app,
+		Config:    cfg,
 		DB:        db,
+		Log:       log,
+		Health:    healthService,
+		Migrator:  migrator,
+		Tokens:    tokens,
+		Keys:      signingKeys,
+		Clock:     testClock,
+		Purge:     purgeDeletedUseCase,
+		Resets:    passwordResetQueue,
+		MailDir:   cfg.MailerFileDir,
 		Container: mongoContainer,
 	}
 }
 
+// FakeClock implementa services.IClock com um horário fixo, avançado explicitamente pelos testes
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance avança o relógio em d e retorna o novo horário
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
 func (ta *TestApp) Cleanup(t *testing.T) {
 	ctx := context.Background()
 
+	// Terminar os pedidos de redefinição de senha antes de apagar o banco
+	if ta.Resets != nil {
+		require.NoError(t, ta.Resets.Shutdown(ctx))
+	}
+
 	// Clean up database
 	if ta.DB != nil && ta.DB.DB != nil {
 		err := ta.DB.DB.Drop(ctx)
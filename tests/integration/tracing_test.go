@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"user-management/internal/application/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testTraceParent = "00-" + testTraceID + "-00f067aa0ba902b7-01"
+)
+
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestTracingPropagatesIncomingTraceContext(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	recorder := setupSpanRecorder(t)
+
+	payload, err := json.Marshal(dto.CreateUserRequestDTO{
+		Name:   "Traced User",
+		Email:  "traced@example.com",
+		Status: "active",
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", usersEndpoint, bytes.NewBuffer(payload))
+	require.NoError(t, err)
+	req.Header.Set(contentTypeHeader, contentTypeJSON)
+	req.Header.Set("traceparent", testTraceParent)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	spansByName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spansByName[span.Name()] = span
+	}
+
+	for _, name := range []string{"UserController.Create", "CreateUserUseCase.Execute", "mongodb.insert"} {
+		span, ok := spansByName[name]
+		require.True(t, ok, "expected span %q to be recorded", name)
+		assert.Equal(t, testTraceID, span.SpanContext().TraceID().String(), "span %q should join the incoming trace", name)
+	}
+
+	var serverSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.SpanKind() == trace.SpanKindServer {
+			serverSpan = span
+		}
+	}
+	require.NotNil(t, serverSpan, "expected an HTTP server span")
+	assert.Equal(t, testTraceID, serverSpan.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", serverSpan.Parent().SpanID().String())
+
+	// O span do use case deve ser filho do span do controller
+	assert.Equal(t,
+		spansByName["UserController.Create"].SpanContext().SpanID(),
+		spansByName["CreateUserUseCase.Execute"].Parent().SpanID())
+}
+
+func TestTracingStartsNewTraceWithoutTraceParent(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	recorder := setupSpanRecorder(t)
+
+	req, err := http.NewRequest("GET", usersEndpoint, nil)
+	require.NoError(t, err)
+
+	resp, err := testApp.Request(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	spans := recorder.Ended()
+	require.NotEmpty(t, spans)
+	traceID := spans[0].SpanContext().TraceID()
+	assert.True(t, traceID.IsValid())
+	for _, span := range spans {
+		assert.Equal(t, traceID, span.SpanContext().TraceID())
+	}
+}
@@ -41,35 +41,35 @@ func TestUserControllerCreate(t *testing.T) {
 		{
 			name: "Valid user creation",
 			payload: dto.CreateUserRequestDTO{
-				Name:     "John Doe",
-				Email:    "john.doe@example.com",
-				IsActive: true,
+				Name:   "John Doe",
+				Email:  "john.doe@example.com",
+				Status: "active",
 			},
 			expectedStatus: 201,
 		},
 		{
-			name: "Valid user creation with IsActive false",
+			name: "Valid user creation with pending status",
 			payload: dto.CreateUserRequestDTO{
-				Name:     "Jane Doe",
-				Email:    "jane.doe@example.com",
-				IsActive: false,
+				Name:   "Jane Doe",
+				Email:  "jane.doe@example.com",
+				Status: "pending",
 			},
 			expectedStatus: 201,
 		},
 		{
 			name: "Invalid email format",
 			payload: dto.CreateUserRequestDTO{
-				Name:     "John Doe",
-				Email:    "invalid-email",
-				IsActive: true,
+				Name:   "John Doe",
+				Email:  "invalid-email",
+				Status: "active",
 			},
 			expectedStatus: 400,
 		},
 		{
 			name: "Missing required fields",
 			payload: dto.CreateUserRequestDTO{
-				Name:     "John Doe",
-				IsActive: true,
+				Name:   "John Doe",
+				Status: "active",
 				// Email missing
 			},
 			expectedStatus: 400,
@@ -77,9 +77,9 @@ func TestUserControllerCreate(t *testing.T) {
 		{
 			name: "Empty name",
 			payload: dto.CreateUserRequestDTO{
-				Name:     "",
-				Email:    "john.doe@example.com",
-				IsActive: true,
+				Name:   "",
+				Email:  "john.doe@example.com",
+				Status: "active",
 			},
 			expectedStatus: 400,
 		},
@@ -206,9 +206,9 @@ func TestUserControllerGet(t *testing.T) {
 
 		// First create a user
 		createUserDTO := dto.CreateUserRequestDTO{
-			Name:     "John Doe",
-			Email:    "john@example.com",
-			IsActive: true,
+			Name:   "John Doe",
+			Email:  "john@example.com",
+			Status: "active",
 		}
 
 		payloadBytes, err := json.Marshal(createUserDTO)
@@ -242,7 +242,7 @@ func TestUserControllerGet(t *testing.T) {
 		assert.Equal(t, createdUser.ID, retrievedUser.ID)
 		assert.Equal(t, createdUser.Name, retrievedUser.Name)
 		assert.Equal(t, createdUser.Email, retrievedUser.Email)
-		assert.Equal(t, createdUser.IsActive, retrievedUser.IsActive)
+		assert.Equal(t, createdUser.Status, retrievedUser.Status)
 	})
 
 	t.Run("Get non-existent user with valid ObjectID", func(t *testing.T) {
@@ -308,9 +308,9 @@ func TestUserControllerGet(t *testing.T) {
 
 		// Create multiple users
 		users := []dto.CreateUserRequestDTO{
-			{Name: "User 1", Email: "user1@example.com", IsActive: true},
-			{Name: "User 2", Email: "user2@example.com", IsActive: false},
-			{Name: "User 3", Email: "user3@example.com", IsActive: true},
+			{Name: "User 1", Email: "user1@example.com", Status: "active"},
+			{Name: "User 2", Email: "user2@example.com", Status: "pending"},
+			{Name: "User 3", Email: "user3@example.com", Status: "active"},
 		}
 
 		var createdUsers []dto.UserResponseDTO
@@ -350,7 +350,7 @@ func TestUserControllerGet(t *testing.T) {
 			assert.Equal(t, createdUser.ID, retrievedUser.ID)
 			assert.Equal(t, users[i].Name, retrievedUser.Name)
 			assert.Equal(t, users[i].Email, retrievedUser.Email)
-			assert.Equal(t, users[i].IsActive, retrievedUser.IsActive)
+			assert.Equal(t, users[i].Status, retrievedUser.Status)
 		}
 
 		// Verify all users have unique IDs
@@ -376,9 +376,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Verify normal operation works first
 		createUserDTO := dto.CreateUserRequestDTO{
-			Name:     "Test User Before Disconnect",
-			Email:    "test.before@example.com",
-			IsActive: true,
+			Name:   "Test User Before Disconnect",
+			Email:  "test.before@example.com",
+			Status: "active",
 		}
 
 		payloadBytes, err := json.Marshal(createUserDTO)
@@ -399,9 +399,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Try to create a user after closing connection - should return repository error
 		createUserDTO2 := dto.CreateUserRequestDTO{
-			Name:     "Test User After Disconnect",
-			Email:    "test.after@example.com",
-			IsActive: true,
+			Name:   "Test User After Disconnect",
+			Email:  "test.after@example.com",
+			Status: "active",
 		}
 
 		payloadBytes2, err := json.Marshal(createUserDTO2)
@@ -445,9 +445,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Create first user
 		createUserDTO := dto.CreateUserRequestDTO{
-			Name:     "First User",
-			Email:    duplicateEmail,
-			IsActive: true,
+			Name:   "First User",
+			Email:  duplicateEmail,
+			Status: "active",
 		}
 
 		payloadBytes, err := json.Marshal(createUserDTO)
@@ -463,9 +463,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Try to create second user with same email
 		createUserDTO2 := dto.CreateUserRequestDTO{
-			Name:     "Second User",
-			Email:    duplicateEmail,
-			IsActive: false,
+			Name:   "Second User",
+			Email:  duplicateEmail,
+			Status: "pending",
 		}
 
 		payloadBytes2, err := json.Marshal(createUserDTO2)
@@ -503,9 +503,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Create first user normally
 		createUserDTO := dto.CreateUserRequestDTO{
-			Name:     "Test User Before Drop",
-			Email:    "before.drop@example.com",
-			IsActive: true,
+			Name:   "Test User Before Drop",
+			Email:  "before.drop@example.com",
+			Status: "active",
 		}
 
 		payloadBytes, err := json.Marshal(createUserDTO)
@@ -527,9 +527,9 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 
 		// Try to create another user after dropping collection
 		createUserDTO2 := dto.CreateUserRequestDTO{
-			Name:     "Test User After Drop",
-			Email:    "after.drop@example.com",
-			IsActive: true,
+			Name:   "Test User After Drop",
+			Email:  "after.drop@example.com",
+			Status: "active",
 		}
 
 		payloadBytes2, err := json.Marshal(createUserDTO2)
@@ -552,7 +552,7 @@ func TestUserControllerCreateRepositoryErrors(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, createUserDTO2.Name, createdUser.Name)
 			assert.Equal(t, createUserDTO2.Email, createdUser.Email)
-			assert.Equal(t, createUserDTO2.IsActive, createdUser.IsActive)
+			assert.Equal(t, createUserDTO2.Status, createdUser.Status)
 		} else {
 			var errorResponse map[string]interface{}
 			err = json.NewDecoder(createResp2.Body).Decode(&errorResponse)
@@ -624,9 +624,9 @@ func TestUserControllerUseCaseUpdateErrors(t *testing.T) {
 
 		// Now simulate an update with invalid data to trigger repository error
 		updateData := dto.CreateUserRequestDTO{
-			Name:     "",                     // Empty name should trigger validation error
-			Email:    "invalid-email-format", // Invalid email format
-			IsActive: true,
+			Name:   "",                     // Empty name should trigger validation error
+			Email:  "invalid-email-format", // Invalid email format
+			Status: "active",
 		}
 		payload, _ := json.Marshal(updateData)
 
@@ -650,9 +650,9 @@ func TestUserControllerUseCaseUpdateErrors(t *testing.T) {
 	t.Run("Update user - concurrent modification scenario", func(t *testing.T) {
 		// First create a user
 		userData := dto.CreateUserRequestDTO{
-			Name:     testUserName,
-			Email:    "concurrent@example.com",
-			IsActive: true,
+			Name:   testUserName,
+			Email:  "concurrent@example.com",
+			Status: "active",
 		}
 		userPayload, _ := json.Marshal(userData)
 
@@ -705,9 +705,9 @@ func TestUserControllerUpdate(t *testing.T) {
 
 	// Create a user first
 	createPayload := dto.CreateUserRequestDTO{
-		Name:     "Original Name",
-		Email:    "original@example.com",
-		IsActive: true,
+		Name:   "Original Name",
+		Email:  "original@example.com",
+		Status: "active",
 	}
 
 	payloadBytes, err := json.Marshal(createPayload)
@@ -726,10 +726,9 @@ func TestUserControllerUpdate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Update the user
-	updatePayload := dto.CreateUserRequestDTO{
-		Name:     "Updated Name",
-		Email:    "updated@example.com",
-		IsActive: false, // Change to test the field
+	updatePayload := dto.UpdateUserRequestDTO{
+		Name:  "Updated Name",
+		Email: "updated@example.com",
 	}
 
 	payloadBytes, err = json.Marshal(updatePayload)
@@ -750,7 +749,8 @@ func TestUserControllerUpdate(t *testing.T) {
 	assert.Equal(t, createdUser.ID, updatedUser.ID)
 	assert.Equal(t, updatePayload.Name, updatedUser.Name)
 	assert.Equal(t, updatePayload.Email, updatedUser.Email)
-	assert.Equal(t, updatePayload.IsActive, updatedUser.IsActive)
+	// O status só muda pelos endpoints de transição
+	assert.Equal(t, createdUser.Status, updatedUser.Status)
 }
 
 func TestUserControllerDelete(t *testing.T) {
@@ -759,9 +759,9 @@ func TestUserControllerDelete(t *testing.T) {
 
 	// Create a user first
 	createPayload := dto.CreateUserRequestDTO{
-		Name:     "To Be Deleted",
-		Email:    "delete@example.com",
-		IsActive: true,
+		Name:   "To Be Deleted",
+		Email:  "delete@example.com",
+		Status: "active",
 	}
 
 	payloadBytes, err := json.Marshal(createPayload)
@@ -802,9 +802,9 @@ func TestUserControllerList(t *testing.T) {
 
 	// Create multiple users
 	users := []dto.CreateUserRequestDTO{
-		{Name: "User 1", Email: "user1@example.com", IsActive: true},
-		{Name: "User 2", Email: "user2@example.com", IsActive: false},
-		{Name: "User 3", Email: "user3@example.com", IsActive: true},
+		{Name: "User 1", Email: "user1@example.com", Status: "active"},
+		{Name: "User 2", Email: "user2@example.com", Status: "pending"},
+		{Name: "User 3", Email: "user3@example.com", Status: "active"},
 	}
 
 	for _, user := range users {
@@ -856,9 +856,9 @@ func TestUserControllerListWithSearch(t *testing.T) {
 
 	// Create users with different names
 	users := []dto.CreateUserRequestDTO{
-		{Name: "Alice Johnson", Email: "alice@example.com", IsActive: true},
-		{Name: "Bob Smith", Email: "bob@example.com", IsActive: true},
-		{Name: "Alice Cooper", Email: "alice.cooper@example.com", IsActive: false},
+		{Name: "Alice Johnson", Email: "alice@example.com", Status: "active"},
+		{Name: "Bob Smith", Email: "bob@example.com", Status: "active"},
+		{Name: "Alice Cooper", Email: "alice.cooper@example.com", Status: "pending"},
 	}
 
 	for _, user := range users {
@@ -955,9 +955,9 @@ func TestUserControllerRepositoryErrors(t *testing.T) {
 
 	t.Run("Update with invalid ObjectID format", func(t *testing.T) {
 		updateData := dto.CreateUserRequestDTO{
-			Name:     updatedName,
-			Email:    updatedEmail,
-			IsActive: true,
+			Name:   updatedName,
+			Email:  updatedEmail,
+			Status: "active",
 		}
 		payload, _ := json.Marshal(updateData)
 
@@ -979,9 +979,9 @@ func TestUserControllerRepositoryErrors(t *testing.T) {
 
 	t.Run("Update with valid ObjectID format but non-existent user", func(t *testing.T) {
 		updateData := dto.CreateUserRequestDTO{
-			Name:     updatedName,
-			Email:    updatedEmail,
-			IsActive: true,
+			Name:   updatedName,
+			Email:  updatedEmail,
+			Status: "active",
 		}
 		payload, _ := json.Marshal(updateData)
 
@@ -1068,8 +1068,8 @@ func TestUserControllerListRepositoryErrors(t *testing.T) {
 		// Note: Don't defer cleanup here since we'll disconnect the database
 		// Create some test users first
 		users := []dto.CreateUserRequestDTO{
-			{Name: "Test User 1", Email: "test1@example.com", IsActive: true},
-			{Name: "Test User 2", Email: "test2@example.com", IsActive: true},
+			{Name: "Test User 1", Email: "test1@example.com", Status: "active"},
+			{Name: "Test User 2", Email: "test2@example.com", Status: "active"},
 		}
 
 		for _, user := range users {
@@ -1129,7 +1129,7 @@ func TestUserControllerListRepositoryErrors(t *testing.T) {
 
 		// Create test users first
 		users := []dto.CreateUserRequestDTO{
-			{Name: "Regex Test User", Email: "regex@example.com", IsActive: true},
+			{Name: "Regex Test User", Email: "regex@example.com", Status: "active"},
 		}
 
 		for _, user := range users {
@@ -1186,9 +1186,9 @@ func TestUserControllerListRepositoryErrors(t *testing.T) {
 		// First create some users
 		for i := 0; i < 3; i++ {
 			userData := dto.CreateUserRequestDTO{
-				Name:     fmt.Sprintf("Timeout Test User %d", i),
-				Email:    fmt.Sprintf("timeout%d@example.com", i),
-				IsActive: true,
+				Name:   fmt.Sprintf("Timeout Test User %d", i),
+				Email:  fmt.Sprintf("timeout%d@example.com", i),
+				Status: "active",
 			}
 			payloadBytes, err := json.Marshal(userData)
 			require.NoError(t, err)
@@ -1233,9 +1233,9 @@ func TestUserControllerListRepositoryErrors(t *testing.T) {
 
 		// Create test users
 		userData := dto.CreateUserRequestDTO{
-			Name:     "Collection Test User",
-			Email:    "collection@example.com",
-			IsActive: true,
+			Name:   "Collection Test User",
+			Email:  "collection@example.com",
+			Status: "active",
 		}
 		payloadBytes, err := json.Marshal(userData)
 		require.NoError(t, err)
@@ -1289,9 +1289,9 @@ func TestUserControllerListRepositoryErrors(t *testing.T) {
 
 		// Create test data
 		userData := dto.CreateUserRequestDTO{
-			Name:     "Pagination Test User",
-			Email:    "pagination@example.com",
-			IsActive: true,
+			Name:   "Pagination Test User",
+			Email:  "pagination@example.com",
+			Status: "active",
 		}
 		payloadBytes, err := json.Marshal(userData)
 		require.NoError(t, err)
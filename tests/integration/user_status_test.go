@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"user-management/internal/application/auth"
+	"user-management/internal/application/dto"
+	"user-management/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserStatusLifecycle(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	var created dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+		Name: "Lifecycle", Email: "lifecycle@example.com",
+	}, &created))
+	assert.Equal(t, "pending", created.Status)
+	userPath := fmt.Sprintf(usersEndpointFmt, created.ID)
+
+	// pending não pode ser suspenso
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, userPath+"/suspend", "", dto.ChangeUserStatusRequestDTO{Reason: "abuse"}, nil))
+
+	activatedAt := testApp.Clock.Advance(time.Minute)
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/activate", "", nil, &user))
+	assert.Equal(t, "active", user.Status)
+	require.NotNil(t, user.StatusChangedAt)
+	assert.True(t, activatedAt.Equal(*user.StatusChangedAt))
+	assert.Equal(t, auth.AnonymousID, user.StatusChangedBy)
+
+	// Suspender exige um motivo
+	assert.Equal(t, http.StatusBadRequest, doJSON(t, testApp, http.MethodPost, userPath+"/suspend", "", nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/suspend", "", dto.ChangeUserStatusRequestDTO{Reason: "Chargeback under review"}, &user))
+	assert.Equal(t, "suspended", user.Status)
+	assert.Equal(t, "Chargeback under review", user.StatusReason)
+
+	// O status persistido é o mesmo retornado pela transição
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, userPath, "", nil, &user))
+	assert.Equal(t, "suspended", user.Status)
+	assert.Equal(t, "Chargeback under review", user.StatusReason)
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/activate", "", dto.ChangeUserStatusRequestDTO{Reason: "Review cleared"}, &user))
+	assert.Equal(t, "active", user.Status)
+
+	// PUT não altera o status
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPut, userPath, "", dto.UpdateUserRequestDTO{
+		Name: "Lifecycle 2", Email: "lifecycle@example.com",
+	}, &user))
+	assert.Equal(t, "active", user.Status)
+
+	assert.Equal(t, http.StatusNotFound, doJSON(t, testApp, http.MethodPost, fmt.Sprintf(usersEndpointFmt, "64b7f0c2a1b2c3d4e5f60718")+"/activate", "", nil, nil))
+}
+
+func TestUserDeprovisionRemovesFromGroups(t *testing.T) {
+	testApp := SetupTestApp(t)
+	defer testApp.Cleanup(t)
+
+	const groupsEndpoint = "/api/v1/groups"
+
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, "", dto.CreateUserRequestDTO{
+		Name: "Leaving", Email: "leaving@example.com", Status: "active",
+	}, &user))
+	userPath := fmt.Sprintf(usersEndpointFmt, user.ID)
+
+	var group dto.GroupResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, groupsEndpoint, "", dto.CreateGroupRequestDTO{
+		Name: "Team", Members: []string{user.ID},
+	}, &group))
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/deprovision", "", dto.ChangeUserStatusRequestDTO{Reason: "Left the company"}, &user))
+	assert.Equal(t, "deprovisioned", user.Status)
+
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodGet, groupsEndpoint+"/"+group.ID, "", nil, &group))
+	assert.Empty(t, group.Members)
+
+	// deprovisioned é final e não pode voltar para nenhum grupo
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, userPath+"/activate", "", nil, nil))
+	assert.Equal(t, http.StatusConflict, doJSON(t, testApp, http.MethodPost, fmt.Sprintf("%s/%s/members/%s", groupsEndpoint, group.ID, user.ID), "", nil, nil))
+}
+
+func TestUserStatusChangeRequiresAdmin(t *testing.T) {
+	testApp := SetupTestApp(t, func(cfg *config.Config) {
+		cfg.AuthEnabled = true
+		cfg.AuthTokenSecret = testTokenSecret
+	})
+	defer testApp.Cleanup(t)
+
+	userToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "user-1", Type: auth.PrincipalUser})
+	require.NoError(t, err)
+	adminToken, _, err := testApp.Tokens.Issue(auth.Principal{ID: "admin-1", Type: auth.PrincipalUser, Admin: true})
+	require.NoError(t, err)
+
+	var user dto.UserResponseDTO
+	require.Equal(t, http.StatusCreated, doJSON(t, testApp, http.MethodPost, usersEndpoint, userToken, dto.CreateUserRequestDTO{
+		Name: "Someone", Email: "someone@example.com",
+	}, &user))
+	userPath := fmt.Sprintf(usersEndpointFmt, user.ID)
+
+	assert.Equal(t, http.StatusForbidden, doJSON(t, testApp, http.MethodPost, userPath+"/activate", userToken, nil, nil))
+	require.Equal(t, http.StatusOK, doJSON(t, testApp, http.MethodPost, userPath+"/activate", adminToken, nil, &user))
+	assert.Equal(t, "admin-1", user.StatusChangedBy)
+}